@@ -5,32 +5,48 @@ INSERT INTO cameras (id, camera_slug, camera_model, camera_make, camera_type, ca
 INSERT INTO cameras (id, camera_slug, camera_model, camera_make, camera_type, camera_owner, camera_description, camera_notes, created_at, updated_at, deleted_at) VALUES (5, 'canon-eos-6d', 'EOS 6D', 'Canon', '', '', '', '', '2020-01-06 02:06:35', '2020-01-06 02:06:54', null);
 INSERT INTO cameras (id, camera_slug, camera_model, camera_make, camera_type, camera_owner, camera_description, camera_notes, created_at, updated_at, deleted_at) VALUES (6, 'apple-iphone-6', 'iPhone 6', 'Apple', '', '', '', '', '2020-01-06 02:06:42', '2020-01-06 02:06:42', null);
 INSERT INTO cameras (id, camera_slug, camera_model, camera_make, camera_type, camera_owner, camera_description, camera_notes, created_at, updated_at, deleted_at) VALUES (7, 'apple-iphone-7', 'iPhone 7', 'Apple', '', '', '', '', '2020-01-06 02:06:51', '2020-01-06 02:06:51', null);
+INSERT INTO lenses (id, lens_slug, lens_model, lens_make, lens_type, lens_owner, lens_description, lens_notes, created_at, updated_at, deleted_at) VALUES (1, 'unknown', 'Unknown', '', '', '', '', '', '2020-01-06 02:06:29', '2020-01-06 02:07:26', null);
 INSERT INTO countries (id, country_slug, country_name, country_description, country_notes, country_photo_id) VALUES ('de', 'germany', 'Germany', 'Country Description', 'Country Notes', 0);
 INSERT INTO albums (id, album_uuid, album_name, album_slug, album_favorite) VALUES ('2', '3', 'Christmas2030', 'christmas2030', 0);
 INSERT INTO albums (id, album_uuid, cover_uuid, album_name, album_slug, album_favorite) VALUES ('1', '4', '654', 'Holiday2030', 'holiday-2030', 1);
 INSERT INTO albums (id, album_uuid, cover_uuid, album_name, album_slug, album_favorite) VALUES ('3', '5', '654', 'Berlin2019', 'berlin-2019', 0);
+INSERT INTO albums (id, album_uuid, parent_uuid, album_name, album_slug, album_favorite) VALUES ('4', '6', '5', 'Kreuzberg2019', 'kreuzberg-2019', 0);
 INSERT INTO photos_albums (album_uuid, photo_uuid) VALUES ('4', '654');
 INSERT INTO photos_albums (album_uuid, photo_uuid) VALUES ('5', '658');
 INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing) VALUES ('1', '1', '654', 'exampleFileName.jpg', 1, '123xxx', 0);
 INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing) VALUES ('2', '2', '655', 'exampleDNGFile.dng', 1, '124xxx', 0);
 INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing) VALUES ('3', '2', '655', 'exampleXmpFile.xmp', 0, '125xxx', 0);
 INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing) VALUES ('4', '5', '658', 'bridge.jpg', 1, '126xxx', 0);
-INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing) VALUES ('5', '6', '659', 'reunion.jpg', 1, '127xxx', 0);
-INSERT INTO photos (id, photo_uuid, photo_year, photo_month, photo_lat, photo_lng) VALUES ('1', '654', 2790, 2, '48.519235', '9.057996666666666');
-INSERT INTO photos (id, photo_uuid, photo_year, photo_month, photo_lat, photo_lng) VALUES ('2', '655', 2790, 2, '48.519235', '9.057996666666666');
+INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing, file_software) VALUES ('5', '6', '659', 'reunion.jpg', 1, '127xxx', 0, 'Adobe Lightroom 9.2');
+INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing, file_error) VALUES ('6', '5', '658', 'bridge.cr2', 0, '128xxx', 0, 'darktable-cli: timeout');
+INSERT INTO files (id, photo_id, photo_uuid, file_name, file_primary, file_hash, file_missing, file_error) VALUES ('7', '5', '658', 'bridge.heic', 0, '129xxx', 0, 'heif-convert: unsupported');
+INSERT INTO photos (id, photo_uuid, photo_year, photo_month, photo_lat, photo_lng, camera_id, lens_id, place_id) VALUES ('1', '654', 2790, 2, '48.519235', '9.057996666666666', 1, 1, 'zz');
+INSERT INTO photos (id, photo_uuid, photo_year, photo_month, photo_lat, photo_lng, camera_id, lens_id, place_id) VALUES ('2', '655', 2790, 2, '48.519235', '9.057996666666666', 1, 1, 'zz');
 INSERT INTO photos (id, photo_uuid, photo_year, photo_month, photo_lat, photo_lng) VALUES ('3', '656', 1990, 3, '48.519235', '9.057996666666666');
 INSERT INTO photos (id, photo_uuid, photo_year, photo_month, photo_lat, photo_lng) VALUES ('4', '657', 1990, 4, '48.519235', '9.057996666666666');
-INSERT INTO photos (id, photo_uuid, taken_at, photo_lat, photo_lng, photo_title) VALUES ('5', '658', '2014-07-17 15:42:12', '48.519235', '9.057996666666666', 'Neckarbrücke');
-INSERT INTO photos (id, photo_uuid, taken_at, photo_lat, photo_lng, photo_title) VALUES ('6', '659', '2015-11-11 09:07:18', '-21.34263611111111', '55.466944444444444', 'Reunion');
+INSERT INTO photos (id, photo_uuid, taken_at, photo_lat, photo_lng, photo_title, camera_id, lens_id, place_id) VALUES ('5', '658', '2014-07-17 15:42:12', '48.519235', '9.057996666666666', 'Neckarbrücke', 1, 1, 'zz');
+INSERT INTO photos (id, photo_uuid, taken_at, photo_lat, photo_lng, photo_title, camera_id, lens_id, place_id, photo_f_number, photo_bias, photo_distance, photo_direction, photo_program, photo_flash, gps_speed) VALUES ('6', '659', '2015-11-11 09:07:18', '-21.34263611111111', '55.466944444444444', 'Reunion', 1, 1, 'zz', 5.6, -0.3333333333333333, 1.27, 123.456, 'aperture', 1, 12.34);
 INSERT INTO keywords (id, keyword, skip) VALUES (1, 'bridge', 0);
 INSERT INTO keywords (id, keyword, skip) VALUES (2, 'beach', 0);
 INSERT INTO photos_keywords (photo_id, keyword_id) VALUES (5, 1);
+INSERT INTO photos_terms (id, photo_id, field, term, position, weight) VALUES (1, 5, 'title', 'neckarbrücke', 1, 8);
+INSERT INTO photos_terms (id, photo_id, field, term, position, weight) VALUES (2, 5, 'keywords', 'bridge', 1, 4);
+INSERT INTO photos_terms (id, photo_id, field, term, position, weight) VALUES (3, 1, 'labels', 'cake', 1, 6);
+INSERT INTO photos_terms (id, photo_id, field, term, position, weight) VALUES (4, 1, 'labels', 'flower', 2, 6);
+INSERT INTO photos_terms (id, photo_id, field, term, position, weight) VALUES (5, 99, 'title', 'orphan', 1, 8);
 INSERT INTO categories (label_id, category_id) VALUES ('1', '1');
 INSERT INTO labels (id, label_uuid, label_slug, label_name, label_priority, label_favorite) VALUES ('1', '12', 'flower', 'Flower', 1, 1);
 INSERT INTO labels (id, label_uuid, label_slug, label_name, label_priority, label_favorite) VALUES ('2', '13', 'cake', 'Cake', 5, 0);
 INSERT INTO labels (id, label_uuid, label_slug, label_name, label_priority, label_favorite) VALUES ('3', '14', 'cow', 'COW', -1, 1);
 INSERT INTO photos_labels (photo_id, label_id, label_uncertainty, label_source) VALUES ('1', '1', '38', 'image');
 INSERT INTO photos_labels (photo_id, label_id, label_uncertainty, label_source) VALUES ('1', '2', '10', 'image');
+INSERT INTO people (id, person_uuid, person_slug, person_name, face_count, embedding) VALUES ('1', '20', 'jens-mander', 'Jens Mander', 2, '[0.95,0.05]');
+INSERT INTO people (id, person_uuid, person_slug, person_name, face_count, embedding) VALUES ('2', '21', '', '', 1, '[0,1]');
+INSERT INTO people (id, person_uuid, person_slug, person_name, face_count, embedding) VALUES ('3', '22', '', '', 1, '[0.5,0.5]');
+INSERT INTO files_faces (id, file_id, person_id, face_x, face_y, face_w, face_h, face_score, embedding) VALUES ('1', '1', '1', 0.1, 0.1, 0.2, 0.3, 0.98, '[1,0]');
+INSERT INTO files_faces (id, file_id, person_id, face_x, face_y, face_w, face_h, face_score, embedding) VALUES ('2', '4', '1', 0.5, 0.2, 0.1, 0.15, 0.91, '[0.9,0.1]');
+INSERT INTO files_faces (id, file_id, person_id, face_x, face_y, face_w, face_h, face_score, embedding) VALUES ('3', '5', '2', 0.4, 0.4, 0.2, 0.2, 0.88, '[0,1]');
+INSERT INTO files_faces (id, file_id, person_id, face_x, face_y, face_w, face_h, face_score, embedding) VALUES ('4', '4', '3', 0.2, 0.2, 0.1, 0.1, 0.8, '[0.5,0.5]');
 
 
 
@@ -29,6 +29,8 @@ func main() {
 		commands.CopyCommand,
 		commands.ConvertCommand,
 		commands.ThumbsCommand,
+		commands.ClassifyCommand,
+		commands.ReclassifyCommand,
 		commands.MigrateCommand,
 		commands.ConfigCommand,
 		commands.VersionCommand,
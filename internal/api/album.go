@@ -2,6 +2,7 @@ package api
 
 import (
 	"archive/zip"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -91,6 +92,13 @@ func CreateAlbum(router *gin.RouterGroup, conf *config.Config) {
 		m := entity.NewAlbum(f.AlbumName)
 		m.AlbumFavorite = f.AlbumFavorite
 
+		if f.ParentUUID != nil {
+			if err := setAlbumParent(q, m, *f.ParentUUID); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
 		log.Debugf("create album: %+v %+v", f, m)
 
 		if res := conf.Db().Create(m); res.Error != nil {
@@ -135,6 +143,21 @@ func UpdateAlbum(router *gin.RouterGroup, conf *config.Config) {
 		}
 
 		m.Rename(f.AlbumName)
+
+		if f.AlbumOrder != nil {
+			if err := m.SetOrder(*f.AlbumOrder); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
+		if f.ParentUUID != nil {
+			if err := setAlbumParent(q, &m, *f.ParentUUID); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
 		conf.Db().Save(&m)
 
 		event.Publish("config.updated", event.Data(conf.ClientConfig()))
@@ -146,6 +169,40 @@ func UpdateAlbum(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// setAlbumParent nests an album below another album, an empty parent moves it to the top level.
+func setAlbumParent(q *query.Repo, m *entity.Album, parentUUID string) error {
+	if parentUUID == "" {
+		m.ParentUUID = ""
+		return nil
+	}
+
+	if _, err := q.FindAlbumByUUID(parentUUID); err != nil {
+		return errors.New("parent album not found")
+	}
+
+	if m.AlbumUUID != "" {
+		if parentUUID == m.AlbumUUID {
+			return errors.New("album can't be its own parent")
+		}
+
+		descendants, err := q.AlbumDescendants(m.AlbumUUID)
+
+		if err != nil {
+			return err
+		}
+
+		for _, uuid := range descendants {
+			if uuid == parentUUID {
+				return errors.New("album can't be nested below its descendants")
+			}
+		}
+	}
+
+	m.ParentUUID = parentUUID
+
+	return nil
+}
+
 // DELETE /api/v1/albums/:uuid
 func DeleteAlbum(router *gin.RouterGroup, conf *config.Config) {
 	router.DELETE("/albums/:uuid", func(c *gin.Context) {
@@ -166,6 +223,9 @@ func DeleteAlbum(router *gin.RouterGroup, conf *config.Config) {
 
 		PublishAlbumEvent(EntityDeleted, id, c, q)
 
+		// Move nested albums up one level
+		conf.Db().Model(&entity.Album{}).Where("parent_uuid = ?", m.AlbumUUID).UpdateColumn("parent_uuid", m.ParentUUID)
+
 		conf.Db().Delete(&m)
 
 		event.Publish("config.updated", event.Data(conf.ClientConfig()))
@@ -328,6 +388,54 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// PUT /api/v1/albums/:uuid/position
+//
+// Moves a photo to a new index and switches the album to manual sort order.
+//
+// Parameters:
+//   uuid: string Album UUID
+func UpdateAlbumPosition(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uuid/position", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.AlbumPosition
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		a, err := q.FindAlbumByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		db := conf.Db()
+
+		if err := entity.MovePhotoInAlbum(db, a.AlbumUUID, f.Photo, f.Index); err != nil {
+			log.Errorf("album: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrPhotoNotFound)
+			return
+		}
+
+		if a.AlbumOrder != entity.AlbumOrderManual {
+			a.AlbumOrder = entity.AlbumOrderManual
+			db.Save(&a)
+		}
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUUID, c, q)
+
+		c.JSON(http.StatusOK, a)
+	})
+}
+
 // GET /albums/:uuid/download
 func DownloadAlbum(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/albums/:uuid/download", func(c *gin.Context) {
@@ -376,9 +484,12 @@ func DownloadAlbum(router *gin.RouterGroup, conf *config.Config) {
 		zipWriter := zip.NewWriter(newZipFile)
 		defer zipWriter.Close()
 
-		for _, f := range p {
+		// Number files so that they keep the album order when sorted by name.
+		digits := len(strconv.Itoa(len(p)))
+
+		for i, f := range p {
 			fileName := path.Join(conf.OriginalsPath(), f.FileName)
-			fileAlias := f.DownloadFileName()
+			fileAlias := fmt.Sprintf("%0*d-%s", digits, i+1, f.DownloadFileName())
 
 			if fs.FileExists(fileName) {
 				if err := addFileToZip(zipWriter, fileName, fileAlias); err != nil {
@@ -498,3 +609,74 @@ func AlbumThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		}
 	})
 }
+
+// PUT /api/v1/albums/:uuid/cover/:photo
+//
+// Parameters:
+//   uuid:  string Album UUID
+//   photo: string Photo UUID, must be in the album
+func SetAlbumCover(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/albums/:uuid/cover/:photo", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		photoUUID := c.Param("photo")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		album, err := q.FindAlbumByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		if _, err := q.FindPhotoAlbum(id, photoUUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrPhotoNotFound)
+			return
+		}
+
+		album.CoverUUID = photoUUID
+		conf.Db().Save(&album)
+
+		RemoveThumbCache(conf, "album", id)
+		PublishAlbumEvent(EntityUpdated, id, c, q)
+
+		event.Success("album cover changed")
+
+		c.JSON(http.StatusOK, album)
+	})
+}
+
+// DELETE /api/v1/albums/:uuid/cover
+//
+// Parameters:
+//   uuid: string Album UUID
+func RemoveAlbumCover(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/albums/:uuid/cover", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		album, err := q.FindAlbumByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrAlbumNotFound)
+			return
+		}
+
+		album.CoverUUID = ""
+		conf.Db().Save(&album)
+
+		RemoveThumbCache(conf, "album", id)
+		PublishAlbumEvent(EntityUpdated, id, c, q)
+
+		c.JSON(http.StatusOK, album)
+	})
+}
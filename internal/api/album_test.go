@@ -1,9 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,6 +42,74 @@ func TestGetAlbum(t *testing.T) {
 	})
 }
 
+func TestUpdateAlbum(t *testing.T) {
+	t.Run("rename keeps order", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbum(router, conf)
+		UpdateAlbum(router, conf)
+		GetAlbum(router, conf)
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"AlbumName": "Order Test"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		var album entity.Album
+
+		if err := json.Unmarshal(result.Body.Bytes(), &album); err != nil {
+			t.Fatal(err)
+		}
+
+		result = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+album.AlbumUUID, `{"AlbumName": "Order Test", "AlbumOrder": "manual"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		result = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+album.AlbumUUID, `{"AlbumName": "Order Test Renamed"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		result = PerformRequest(app, "GET", "/api/v1/albums/"+album.AlbumUUID)
+		assert.Contains(t, result.Body.String(), "\"AlbumName\":\"Order Test Renamed\"")
+		assert.Contains(t, result.Body.String(), "\"AlbumOrder\":\"manual\"")
+	})
+	t.Run("rename keeps parent", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		CreateAlbum(router, conf)
+		UpdateAlbum(router, conf)
+		GetAlbum(router, conf)
+
+		var parent, child entity.Album
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"AlbumName": "Parent Test"}`)
+
+		if err := json.Unmarshal(result.Body.Bytes(), &parent); err != nil {
+			t.Fatal(err)
+		}
+
+		result = PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"AlbumName": "Child Test", "ParentUUID": "`+parent.AlbumUUID+`"}`)
+
+		if err := json.Unmarshal(result.Body.Bytes(), &child); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, parent.AlbumUUID, child.ParentUUID)
+
+		result = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+child.AlbumUUID, `{"AlbumName": "Child Test Renamed"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		result = PerformRequest(app, "GET", "/api/v1/albums/"+child.AlbumUUID)
+		assert.Contains(t, result.Body.String(), "\"ParentUUID\":\""+parent.AlbumUUID+"\"")
+
+		result = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+child.AlbumUUID, `{"AlbumName": "Child Test Renamed", "ParentUUID": ""}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		result = PerformRequest(app, "GET", "/api/v1/albums/"+child.AlbumUUID)
+		assert.Contains(t, result.Body.String(), "\"ParentUUID\":\"\"")
+	})
+	t.Run("invalid order", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbum(router, conf)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/albums/4", `{"AlbumName": "Holiday2030", "AlbumOrder": "xxx"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
+
 func TestDeleteAlbum(t *testing.T) {
 	t.Run("delete existing album", func(t *testing.T) {
 		app, router, conf := NewApiTest()
@@ -138,3 +208,56 @@ func TestAlbumThumbnail(t *testing.T) {
 		assert.Equal(t, http.StatusOK, result.Code)
 	})
 }
+
+func TestSetAlbumCover(t *testing.T) {
+	t.Run("photo in album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SetAlbumCover(router, conf)
+		result := PerformRequest(app, "PUT", "/api/v1/albums/4/cover/654")
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Contains(t, result.Body.String(), "\"CoverUUID\":\"654\"")
+	})
+	t.Run("photo not in album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SetAlbumCover(router, conf)
+		result := PerformRequest(app, "PUT", "/api/v1/albums/4/cover/658")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SetAlbumCover(router, conf)
+		result := PerformRequest(app, "PUT", "/api/v1/albums/xxx/cover/654")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestRemoveAlbumCover(t *testing.T) {
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RemoveAlbumCover(router, conf)
+		result := PerformRequest(app, "DELETE", "/api/v1/albums/xxx/cover")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestUpdateAlbumPosition(t *testing.T) {
+	t.Run("move photo", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumPosition(router, conf)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/albums/4/position", `{"photo": "654", "index": 0}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Contains(t, result.Body.String(), "\"AlbumOrder\":\"manual\"")
+	})
+	t.Run("photo not in album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumPosition(router, conf)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/albums/4/position", `{"photo": "658", "index": 0}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		UpdateAlbumPosition(router, conf)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/albums/xxx/position", `{"photo": "654", "index": 0}`)
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// RemoveThumbCache removes cached thumbnails of an album or label, e.g. after the cover has changed.
+func RemoveThumbCache(conf *config.Config, model, uuid string) {
+	gc := conf.Cache()
+
+	for typeName := range thumb.Types {
+		gc.Delete(fmt.Sprintf("%s-thumbnail:%s:%s", model, uuid, typeName))
+	}
+}
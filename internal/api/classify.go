@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/classify
+func StartClassify(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/classify", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		if conf.TensorFlowDisabled() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Image classification is disabled"})
+			return
+		}
+
+		start := time.Now()
+
+		var f form.ClassifyOptions
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		initIndex(conf)
+
+		event.Info("classifying photos")
+
+		count, err := photoprism.NewClassify(conf, ind).Start(f)
+
+		elapsed := int(time.Since(start).Seconds())
+
+		event.Publish("index.completed", event.Data{"path": conf.OriginalsPath(), "seconds": elapsed})
+
+		if err != nil {
+			log.Error(err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success(fmt.Sprintf("%d photos classified in %d s", count, elapsed))
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%d photos classified in %d s", count, elapsed), "count": count})
+	})
+}
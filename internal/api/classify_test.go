@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartClassify(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		StartClassify(router, ctx)
+		result := PerformRequestWithBody(app, "POST", "/api/v1/classify", `{"camera": "xxx"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("label not found", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		StartClassify(router, ctx)
+		result := PerformRequestWithBody(app, "POST", "/api/v1/classify", `{"label": "xxx-not-existing"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
@@ -14,6 +14,11 @@ var (
 	ErrUploadNSFW      = gin.H{"code": http.StatusForbidden, "error": txt.UcFirst(config.ErrUploadNSFW.Error())}
 	ErrAlbumNotFound   = gin.H{"code": http.StatusNotFound, "error": "Album not found"}
 	ErrPhotoNotFound   = gin.H{"code": http.StatusNotFound, "error": "Photo not found"}
+	ErrFileNotFound    = gin.H{"code": http.StatusNotFound, "error": "File not found"}
 	ErrLabelNotFound   = gin.H{"code": http.StatusNotFound, "error": "Label not found"}
+	ErrFolderNotFound  = gin.H{"code": http.StatusNotFound, "error": "Folder not found"}
+	ErrPersonNotFound  = gin.H{"code": http.StatusNotFound, "error": "Person not found"}
+	ErrRuleNotFound    = gin.H{"code": http.StatusNotFound, "error": "Rule not found"}
+	ErrSaveFailed      = gin.H{"code": http.StatusInternalServerError, "error": "Changes could not be saved"}
 	ErrUnexpectedError = gin.H{"code": http.StatusInternalServerError, "error": "Unexpected error"}
 )
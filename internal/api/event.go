@@ -55,3 +55,16 @@ func PublishLabelEvent(e EntityEvent, uuid string, c *gin.Context, q *query.Repo
 
 	event.PublishEntities("labels", string(e), result)
 }
+
+func PublishPersonEvent(e EntityEvent, uuid string, c *gin.Context, q *query.Repo) {
+	f := form.PersonSearch{ID: uuid}
+	result, err := q.People(f)
+
+	if err != nil {
+		log.Error(err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+		return
+	}
+
+	event.PublishEntities("people", string(e), result)
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/files/:uuid/metadata
+//
+// Returns all Exif, ExifTool and XMP tags of a file and its sidecar files,
+// and which of them were used for each photo field.
+//
+// Parameters:
+//   uuid: string FileUUID as returned by the API
+func GetFileMetadata(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/files/:uuid/metadata", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		file, err := q.FindFileByUUID(c.Param("uuid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		sidecars, err := q.FindSidecarFiles(file.PhotoID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		result, err := photoprism.Inspect(conf, file, sidecars)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,19 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFileMetadata(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		GetFileMetadata(router, conf)
+
+		result := PerformRequest(app, "GET", "/api/v1/files/xxx/metadata")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
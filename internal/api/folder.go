@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/folders
+//
+// Query:
+//   path: string Folder path relative to originals (default: top level)
+func GetFolders(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/folders", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.FolderSearch
+
+		if err := c.BindQuery(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		result, err := q.Folders(f.Path)
+
+		if err != nil {
+			log.Errorf("folders: %s", err)
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFolderNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"path": query.FolderPath(f.Path), "folders": result})
+	})
+}
+
+// GET /api/v1/folders/thumbnail/:type
+//
+// Parameters:
+//   type: string Thumbnail type, see photoprism.ThumbnailTypes
+//
+// Query:
+//   path: string Folder path relative to originals
+func FolderThumbnail(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/folders/thumbnail/:type", func(c *gin.Context) {
+		typeName := c.Param("type")
+		start := time.Now()
+
+		thumbType, ok := thumb.Types[typeName]
+
+		if !ok {
+			log.Errorf("folder: invalid thumb type \"%s\"", typeName)
+			c.Data(http.StatusBadRequest, "image/svg+xml", photoIconSvg)
+			return
+		}
+
+		folder := query.FolderPath(c.Query("path"))
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		f, err := q.FindFolderThumb(folder)
+
+		if err != nil {
+			log.Debugf("folder: no photos in \"%s\", using generic image", folder)
+			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			return
+		}
+
+		fileName := path.Join(conf.OriginalsPath(), f.FileName)
+
+		if !fs.FileExists(fileName) {
+			log.Errorf("folder: could not find original for %s", fileName)
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+
+			// Set missing flag so that the file doesn't show up in search results anymore
+			f.FileMissing = true
+			conf.Db().Save(&f)
+			return
+		}
+
+		// Use original file if thumb size exceeds limit, see https://github.com/photoprism/photoprism/issues/157
+		if thumbType.ExceedsLimit() {
+			c.File(fileName)
+			return
+		}
+
+		thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbnailsPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+
+		if err != nil {
+			log.Errorf("folder: %s", err)
+			c.Data(http.StatusOK, "image/svg+xml", albumIconSvg)
+			return
+		}
+
+		log.Debugf("folder: thumbnail for \"%s\" created in %s", folder, time.Since(start))
+
+		c.File(thumbnail)
+	})
+}
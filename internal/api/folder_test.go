@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFolders(t *testing.T) {
+	t.Run("originals", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetFolders(router, conf)
+		result := PerformRequest(app, "GET", "/api/v1/folders")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("folder not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetFolders(router, conf)
+		result := PerformRequest(app, "GET", "/api/v1/folders?path=xxx/yyy")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestFolderThumbnail(t *testing.T) {
+	t.Run("invalid type", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		FolderThumbnail(router, conf)
+		result := PerformRequest(app, "GET", "/api/v1/folders/thumbnail/xxx")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
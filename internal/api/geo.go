@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"sort"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/query"
@@ -41,25 +42,10 @@ func GetGeo(router *gin.RouterGroup, conf *config.Config) {
 
 		fc := geojson.NewFeatureCollection()
 
-		bbox := make([]float64, 4)
-
-		bboxMin := func(pos int, val float64) {
-			if bbox[pos] == 0.0 || bbox[pos] > val {
-				bbox[pos] = val
-			}
-		}
-
-		bboxMax := func(pos int, val float64) {
-			if bbox[pos] == 0.0 || bbox[pos] < val {
-				bbox[pos] = val
-			}
-		}
+		var boxes [][4]float64
 
 		for _, p := range photos {
-			bboxMin(0, p.PhotoLng)
-			bboxMin(1, p.PhotoLat)
-			bboxMax(2, p.PhotoLng)
-			bboxMax(3, p.PhotoLat)
+			boxes = append(boxes, [4]float64{p.PhotoLng, p.PhotoLat, p.PhotoLng, p.PhotoLat})
 
 			feat := geojson.NewPointFeature([]float64{p.PhotoLng, p.PhotoLat})
 			feat.ID = p.ID
@@ -74,7 +60,7 @@ func GetGeo(router *gin.RouterGroup, conf *config.Config) {
 			fc.AddFeature(feat)
 		}
 
-		fc.BoundingBox = bbox
+		fc.BoundingBox = geoBbox(boxes)
 
 		resp, err := fc.MarshalJSON()
 
@@ -86,3 +72,120 @@ func GetGeo(router *gin.RouterGroup, conf *config.Config) {
 		c.Data(http.StatusOK, "application/json", resp)
 	})
 }
+
+// GET /api/v1/geo/clusters
+//
+// Query:
+//   zoom: int Map zoom level, photos are grouped by grid cells of about a quarter tile
+//   Other parameters are the same as for /api/v1/geo
+func GetGeoClusters(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/geo/clusters", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.GeoSearch
+
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		clusters, err := q.GeoClusters(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		fc := geojson.NewFeatureCollection()
+
+		var boxes [][4]float64
+
+		for _, cl := range clusters {
+			boxes = append(boxes, [4]float64{cl.LngMin, cl.LatMin, cl.LngMax, cl.LatMax})
+
+			feat := geojson.NewPointFeature([]float64{cl.Lng, cl.Lat})
+			feat.ID = cl.ID
+			feat.BoundingBox = []float64{cl.LngMin, cl.LatMin, cl.LngMax, cl.LatMax}
+			feat.Properties = gin.H{
+				"Count":      cl.Count,
+				"PhotoUUID":  cl.PhotoUUID,
+				"FileHash":   cl.FileHash,
+				"FileWidth":  cl.FileWidth,
+				"FileHeight": cl.FileHeight,
+			}
+			fc.AddFeature(feat)
+		}
+
+		fc.BoundingBox = geoBbox(boxes)
+
+		resp, err := fc.MarshalJSON()
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Data(http.StatusOK, "application/json", resp)
+	})
+}
+
+// geoBbox returns the smallest GeoJSON bounding box [west, south, east, north] that contains all boxes,
+// west is greater than east if it crosses the antimeridian.
+func geoBbox(boxes [][4]float64) []float64 {
+	if len(boxes) == 0 {
+		return nil
+	}
+
+	south, north := boxes[0][1], boxes[0][3]
+	ranges := make([][2]float64, len(boxes))
+
+	for i, b := range boxes {
+		if b[1] < south {
+			south = b[1]
+		}
+
+		if b[3] > north {
+			north = b[3]
+		}
+
+		ranges[i] = [2]float64{b[0], b[2]}
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i][0] < ranges[j][0]
+	})
+
+	// Merge overlapping longitude ranges.
+	merged := [][2]float64{ranges[0]}
+
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+
+		if r[0] <= last[1] {
+			if r[1] > last[1] {
+				last[1] = r[1]
+			}
+		} else {
+			merged = append(merged, r)
+		}
+	}
+
+	// The box ends before the largest gap between ranges, which may be the one across the antimeridian.
+	west, east := merged[0][0], merged[len(merged)-1][1]
+	gap := west + 360 - east
+
+	for i := 1; i < len(merged); i++ {
+		if g := merged[i][0] - merged[i-1][1]; g > gap {
+			gap = g
+			west, east = merged[i][0], merged[i-1][1]
+		}
+	}
+
+	return []float64{west, south, east, north}
+}
@@ -17,3 +17,32 @@ func TestGetGeo(t *testing.T) {
 		assert.Equal(t, http.StatusOK, result.Code)
 	})
 }
+
+func TestGetGeoClusters(t *testing.T) {
+	t.Run("get clusters", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		GetGeoClusters(router, conf)
+
+		result := PerformRequest(app, "GET", "/api/v1/geo/clusters?zoom=5")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+}
+
+func TestGeoBbox(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, geoBbox(nil))
+	})
+	t.Run("points", func(t *testing.T) {
+		bbox := geoBbox([][4]float64{{13.4, -52.5, 13.4, -52.5}, {-122.4, 37.8, -122.4, 37.8}})
+		assert.Equal(t, []float64{-122.4, -52.5, 13.4, 37.8}, bbox)
+	})
+	t.Run("antimeridian", func(t *testing.T) {
+		bbox := geoBbox([][4]float64{{179.5, -17, 179.8, -16.5}, {-179.9, -18, -179.2, -17.2}})
+		assert.Equal(t, []float64{179.5, -18, -179.2, -16.5}, bbox)
+	})
+	t.Run("overlapping ranges", func(t *testing.T) {
+		bbox := geoBbox([][4]float64{{10, 0, 20, 1}, {15, 2, 30, 3}, {-170, 0, -160, 1}})
+		assert.Equal(t, []float64{10, 0, -160, 3}, bbox)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -18,6 +19,8 @@ import (
 
 var ind *photoprism.Index
 var nd *nsfw.Detector
+var fd *face.Detector
+var tf *classify.Classifiers
 
 func initIndex(conf *config.Config) {
 	if ind != nil {
@@ -25,10 +28,18 @@ func initIndex(conf *config.Config) {
 	}
 
 	initNsfwDetector(conf)
+	initFaceDetector(conf)
+	initTensorFlow(conf)
 
-	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
+	ind = photoprism.NewIndex(conf, tf, nd, fd)
+}
+
+func initTensorFlow(conf *config.Config) {
+	if tf != nil {
+		return
+	}
 
-	ind = photoprism.NewIndex(conf, tf, nd)
+	tf = classify.NewClassifiers(conf.ResourcesPath(), conf.ModelsFile(), conf.TensorFlowDisabled())
 }
 
 func initNsfwDetector(conf *config.Config) {
@@ -39,6 +50,14 @@ func initNsfwDetector(conf *config.Config) {
 	nd = nsfw.New(conf.NSFWModelPath())
 }
 
+func initFaceDetector(conf *config.Config) {
+	if fd != nil {
+		return
+	}
+
+	fd = face.New(conf.FacesModelPath())
+}
+
 // POST /api/v1/index
 func StartIndexing(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/index", func(c *gin.Context) {
@@ -66,8 +85,10 @@ func StartIndexing(router *gin.RouterGroup, conf *config.Config) {
 			c.JSON(http.StatusOK, gin.H{"error": err.Error()})
 		}
 
+		var convert *photoprism.Convert
+
 		if f.ConvertRaw && !conf.ReadOnly() {
-			convert := photoprism.NewConvert(conf)
+			convert = photoprism.NewConvert(conf)
 
 			if err := convert.Start(conf.OriginalsPath()); err != nil {
 				cancel(err)
@@ -92,6 +113,11 @@ func StartIndexing(router *gin.RouterGroup, conf *config.Config) {
 			ind.Start(photoprism.IndexOptionsAll())
 		}
 
+		// Conversion errors can only be saved once the file has been indexed.
+		if convert != nil {
+			convert.SavePendingErrors()
+		}
+
 		elapsed := int(time.Since(start).Seconds())
 
 		event.Success(fmt.Sprintf("indexing completed in %d s", elapsed))
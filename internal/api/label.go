@@ -10,7 +10,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
+	"github.com/gosimple/slug"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/query"
@@ -75,9 +77,35 @@ func UpdateLabel(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
+		// Renaming a label to the name of another label merges both
+		if target, err := q.FindLabelBySlug(slug.Make(f.LabelName)); err == nil && target.ID != m.ID {
+			if err := m.MergeInto(conf.Db(), &target); err != nil {
+				log.Errorf("label: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+				return
+			}
+
+			if err := entity.IndexLabelTerms(conf.Db(), target.ID); err != nil {
+				log.Errorf("label: %s", err)
+			}
+
+			event.Success(fmt.Sprintf("label merged into %s", target.LabelName))
+
+			event.EntitiesDeleted("labels", []string{id})
+			PublishLabelEvent(EntityUpdated, target.LabelUUID, c, q)
+			RemoveThumbCache(conf, "label", target.LabelUUID)
+
+			c.JSON(http.StatusOK, target)
+			return
+		}
+
 		m.Rename(f.LabelName)
 		conf.Db().Save(&m)
 
+		if err := entity.IndexLabelTerms(conf.Db(), m.ID); err != nil {
+			log.Errorf("label: %s", err)
+		}
+
 		event.Success("label saved")
 
 		PublishLabelEvent(EntityUpdated, id, c, q)
@@ -241,3 +269,192 @@ func LabelThumbnail(router *gin.RouterGroup, conf *config.Config) {
 		}
 	})
 }
+
+// PUT /api/v1/labels/:uuid/cover/:photo
+//
+// Parameters:
+//   uuid:  string Label UUID
+//   photo: string Photo UUID, must have the label
+func SetLabelCover(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/labels/:uuid/cover/:photo", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		photoUUID := c.Param("photo")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		label, err := q.FindLabelByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+			return
+		}
+
+		if _, err := q.FindPhotoLabel(label.ID, photoUUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrPhotoNotFound)
+			return
+		}
+
+		label.CoverUUID = photoUUID
+		conf.Db().Save(&label)
+
+		RemoveThumbCache(conf, "label", id)
+		PublishLabelEvent(EntityUpdated, id, c, q)
+
+		event.Success("label cover changed")
+
+		c.JSON(http.StatusOK, label)
+	})
+}
+
+// DELETE /api/v1/labels/:uuid/cover
+//
+// Parameters:
+//   uuid: string Label UUID
+func RemoveLabelCover(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/labels/:uuid/cover", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		label, err := q.FindLabelByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+			return
+		}
+
+		label.CoverUUID = ""
+		conf.Db().Save(&label)
+
+		RemoveThumbCache(conf, "label", id)
+		PublishLabelEvent(EntityUpdated, id, c, q)
+
+		c.JSON(http.StatusOK, label)
+	})
+}
+
+// POST /api/v1/labels/:uuid/merge/:target
+//
+// Moves all photos of a label to the target label and deletes it.
+//
+// Parameters:
+//   uuid:   string Label UUID
+//   target: string Target label UUID
+func MergeLabel(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/labels/:uuid/merge/:target", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		label, err := q.FindLabelByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+			return
+		}
+
+		target, err := q.FindLabelByUUID(c.Param("target"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+			return
+		}
+
+		if err := label.MergeInto(conf.Db(), &target); err != nil {
+			log.Errorf("label: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := entity.IndexLabelTerms(conf.Db(), target.ID); err != nil {
+			log.Errorf("label: %s", err)
+		}
+
+		event.Success(fmt.Sprintf("%s merged into %s", label.LabelName, target.LabelName))
+
+		event.EntitiesDeleted("labels", []string{id})
+		PublishLabelEvent(EntityUpdated, target.LabelUUID, c, q)
+		RemoveThumbCache(conf, "label", target.LabelUUID)
+
+		c.JSON(http.StatusOK, target)
+	})
+}
+
+// POST /api/v1/labels/:uuid/categories/:category
+//
+// Parameters:
+//   uuid:     string Label UUID
+//   category: string Category label UUID
+func AddLabelCategory(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/labels/:uuid/categories/:category", func(c *gin.Context) {
+		updateLabelCategory(c, conf, true)
+	})
+}
+
+// DELETE /api/v1/labels/:uuid/categories/:category
+//
+// Parameters:
+//   uuid:     string Label UUID
+//   category: string Category label UUID
+func RemoveLabelCategory(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/labels/:uuid/categories/:category", func(c *gin.Context) {
+		updateLabelCategory(c, conf, false)
+	})
+}
+
+// updateLabelCategory adds or removes a label category.
+func updateLabelCategory(c *gin.Context, conf *config.Config, add bool) {
+	if Unauthorized(c, conf) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	id := c.Param("uuid")
+	q := query.New(conf.OriginalsPath(), conf.Db())
+
+	label, err := q.FindLabelByUUID(id)
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+		return
+	}
+
+	category, err := q.FindLabelByUUID(c.Param("category"))
+
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusNotFound, ErrLabelNotFound)
+		return
+	}
+
+	if add {
+		err = label.AddCategory(conf.Db(), &category)
+	} else {
+		err = label.RemoveCategory(conf.Db(), &category)
+	}
+
+	if err != nil {
+		log.Errorf("label: %s", err)
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+		return
+	}
+
+	if err := entity.IndexLabelTerms(conf.Db(), label.ID); err != nil {
+		log.Errorf("label: %s", err)
+	}
+
+	PublishLabelEvent(EntityUpdated, id, c, q)
+
+	c.JSON(http.StatusOK, label)
+}
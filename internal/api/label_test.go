@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -95,3 +96,101 @@ func TestLabelThumbnail(t *testing.T) {
 		assert.Equal(t, http.StatusOK, result.Code)
 	})
 }
+
+func TestSetLabelCover(t *testing.T) {
+	t.Run("photo with label", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SetLabelCover(router, conf)
+		result := PerformRequest(app, "PUT", "/api/v1/labels/12/cover/654")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("photo without label", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SetLabelCover(router, conf)
+		result := PerformRequest(app, "PUT", "/api/v1/labels/12/cover/658")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("label not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		RemoveLabelCover(router, conf)
+		result := PerformRequest(app, "DELETE", "/api/v1/labels/xxx/cover")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestMergeLabel(t *testing.T) {
+	t.Run("merge with itself", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLabel(router, conf)
+		result := PerformRequest(app, "POST", "/api/v1/labels/14/merge/14")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("move photo labels", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLabel(router, conf)
+		db := conf.Db()
+
+		source := entity.NewLabel("Merge Source", 0)
+		target := entity.NewLabel("Merge Target", 0)
+
+		if err := db.Create(source).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := db.Create(target).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		// Photo 990001 only has the source label, photo 990002 has both.
+		db.Create(entity.NewPhotoLabel(990001, source.ID, 30, "image"))
+		db.Create(entity.NewPhotoLabel(990002, source.ID, 10, "manual"))
+		db.Create(entity.NewPhotoLabel(990002, target.ID, 50, "image"))
+
+		result := PerformRequest(app, "POST", "/api/v1/labels/"+source.LabelUUID+"/merge/"+target.LabelUUID)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		var photoLabels []entity.PhotoLabel
+
+		db.Where("photo_id IN (?)", []uint{990001, 990002}).Order("photo_id").Find(&photoLabels)
+
+		if assert.Len(t, photoLabels, 2) {
+			assert.Equal(t, target.ID, photoLabels[0].LabelID)
+			assert.Equal(t, 30, photoLabels[0].LabelUncertainty)
+			assert.Equal(t, target.ID, photoLabels[1].LabelID)
+			assert.Equal(t, 10, photoLabels[1].LabelUncertainty)
+			assert.Equal(t, "manual", photoLabels[1].LabelSource)
+		}
+
+		assert.True(t, db.First(&entity.Label{}, source.ID).RecordNotFound())
+	})
+	t.Run("target not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		MergeLabel(router, conf)
+		result := PerformRequest(app, "POST", "/api/v1/labels/14/merge/xxx")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestLabelCategory(t *testing.T) {
+	t.Run("add and remove", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddLabelCategory(router, conf)
+		RemoveLabelCategory(router, conf)
+		result := PerformRequest(app, "POST", "/api/v1/labels/14/categories/13")
+		assert.Equal(t, http.StatusOK, result.Code)
+		result = PerformRequest(app, "DELETE", "/api/v1/labels/14/categories/13")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("own category", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddLabelCategory(router, conf)
+		result := PerformRequest(app, "POST", "/api/v1/labels/14/categories/14")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("category not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		AddLabelCategory(router, conf)
+		result := PerformRequest(app, "POST", "/api/v1/labels/14/categories/xxx")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
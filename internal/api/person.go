@@ -0,0 +1,223 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/people
+func GetPeople(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/people", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.PersonSearch
+
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		result, err := q.People(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.Header("X-Result-Count", strconv.Itoa(f.Count))
+		c.Header("X-Result-Offset", strconv.Itoa(f.Offset))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/people/:uuid/faces
+func GetPersonFaces(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/people/:uuid/faces", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		person, err := q.FindPersonByUUID(c.Param("uuid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		result, err := q.PersonFaces(person.ID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// PUT /api/v1/people/:uuid
+func UpdatePerson(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/people/:uuid", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.Person
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		m, err := q.FindPersonByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		// Naming a person like another person merges both
+		if target, err := q.FindPersonBySlug(slug.Make(f.PersonName)); err == nil && target.ID != m.ID {
+			if err := m.MergeInto(conf.Db(), &target); err != nil {
+				log.Errorf("person: %s", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrUnexpectedError)
+				return
+			}
+
+			event.Success(fmt.Sprintf("person merged into %s", target.PersonName))
+
+			event.EntitiesDeleted("people", []string{id})
+			PublishPersonEvent(EntityUpdated, target.PersonUUID, c, q)
+
+			c.JSON(http.StatusOK, target)
+			return
+		}
+
+		m.Rename(f.PersonName)
+
+		if err := conf.Db().Save(&m).Error; err != nil {
+			log.Errorf("person: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("person saved")
+
+		PublishPersonEvent(EntityUpdated, id, c, q)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// POST /api/v1/people/:uuid/merge/:target
+//
+// Parameters:
+//   uuid:   string Person UUID
+//   target: string UUID of the person that remains
+func MergePerson(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/people/:uuid/merge/:target", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		person, err := q.FindPersonByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		target, err := q.FindPersonByUUID(c.Param("target"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		if err := person.MergeInto(conf.Db(), &target); err != nil {
+			log.Errorf("person: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success("people merged")
+
+		event.EntitiesDeleted("people", []string{id})
+		PublishPersonEvent(EntityUpdated, target.PersonUUID, c, q)
+
+		c.JSON(http.StatusOK, target)
+	})
+}
+
+// POST /api/v1/people/:uuid/split
+//
+// Parameters:
+//   uuid: string Person UUID
+func SplitPerson(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/people/:uuid/split", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var f form.PersonSplit
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		person, err := q.FindPersonByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPersonNotFound)
+			return
+		}
+
+		result, err := person.Split(conf.Db(), f.Faces)
+
+		if err != nil {
+			log.Errorf("person: %s", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		event.Success(fmt.Sprintf("%d faces moved to a new person", result.FaceCount))
+
+		PublishPersonEvent(EntityUpdated, id, c, q)
+		PublishPersonEvent(EntityCreated, result.PersonUUID, c, q)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPeople(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPeople(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/people?count=10")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPeople(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/people?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
+
+func TestGetPersonFaces(t *testing.T) {
+	t.Run("existing person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPersonFaces(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/people/20/faces")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("not existing person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPersonFaces(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/people/xxx/faces")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestUpdatePerson(t *testing.T) {
+	t.Run("name person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		UpdatePerson(router, ctx)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/people/21", `{"PersonName": "Bert"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Contains(t, result.Body.String(), "bert")
+	})
+	t.Run("not existing person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		UpdatePerson(router, ctx)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/people/xxx", `{"PersonName": "Bert"}`)
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestMergePerson(t *testing.T) {
+	t.Run("merge person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		MergePerson(router, ctx)
+		result := PerformRequest(app, "POST", "/api/v1/people/22/merge/21")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("merge with itself", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		MergePerson(router, ctx)
+		result := PerformRequest(app, "POST", "/api/v1/people/21/merge/21")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("not existing target", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		MergePerson(router, ctx)
+		result := PerformRequest(app, "POST", "/api/v1/people/21/merge/xxx")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
+
+func TestSplitPerson(t *testing.T) {
+	t.Run("split person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		SplitPerson(router, ctx)
+		result := PerformRequestWithBody(app, "POST", "/api/v1/people/20/split", `{"faces": [2]}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("no faces", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		SplitPerson(router, ctx)
+		result := PerformRequestWithBody(app, "POST", "/api/v1/people/20/split", `{"faces": []}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("not existing person", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		SplitPerson(router, ctx)
+		result := PerformRequestWithBody(app, "POST", "/api/v1/people/xxx/split", `{"faces": [2]}`)
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+}
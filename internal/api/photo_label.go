@@ -61,6 +61,10 @@ func AddPhotoLabel(router *gin.RouterGroup, conf *config.Config) {
 
 		db.Save(&lm)
 
+		if err := m.IndexTerms(db); err != nil {
+			log.Errorf("label: %s", err)
+		}
+
 		p, err := q.PreloadPhotoByUUID(c.Param("uuid"))
 
 		if err != nil {
@@ -102,6 +106,10 @@ func RemovePhotoLabel(router *gin.RouterGroup, conf *config.Config) {
 		db := conf.Db()
 		db.Where("photo_id = ? AND label_id = ?", m.ID, labelId).Delete(&entity.PhotoLabel{})
 
+		if err := m.IndexTerms(db); err != nil {
+			log.Errorf("label: %s", err)
+		}
+
 		p, err := q.PreloadPhotoByUUID(c.Param("uuid"))
 
 		if err != nil {
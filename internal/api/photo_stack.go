@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// POST /api/v1/photos/:uuid/files/:file_uuid/primary
+//
+// Parameters:
+//   uuid: string PhotoUUID as returned by the API
+//   file_uuid: string FileUUID of a JPEG file in the photo stack
+func PhotoFilePrimary(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/photos/:uuid/files/:file_uuid/primary", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		m, err := q.FindPhotoByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		if _, err := m.StackFile(conf.Db(), c.Param("file_uuid")); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		if err := m.SetPrimaryFile(conf.Db(), c.Param("file_uuid")); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, id, c, q)
+
+		p, err := q.PreloadPhotoByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, p)
+	})
+}
+
+// POST /api/v1/photos/:uuid/files/:file_uuid/unstack
+//
+// Parameters:
+//   uuid: string PhotoUUID as returned by the API
+//   file_uuid: string FileUUID of the file that should become a separate photo
+func PhotoFileUnstack(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/photos/:uuid/files/:file_uuid/unstack", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		id := c.Param("uuid")
+		q := query.New(conf.OriginalsPath(), conf.Db())
+		m, err := q.FindPhotoByUUID(id)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		if _, err := m.StackFile(conf.Db(), c.Param("file_uuid")); err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrFileNotFound)
+			return
+		}
+
+		unstacked, err := m.Unstack(conf.Db(), c.Param("file_uuid"))
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		PublishPhotoEvent(EntityUpdated, id, c, q)
+		PublishPhotoEvent(EntityCreated, unstacked.PhotoUUID, c, q)
+
+		event.Publish("count.photos", event.Data{
+			"count": 1,
+		})
+
+		event.Success("file unstacked")
+
+		p, err := q.PreloadPhotoByUUID(unstacked.PhotoUUID)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, p)
+	})
+}
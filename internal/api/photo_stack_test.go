@@ -0,0 +1,166 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// createStack adds a photo with a RAW file, two JPEGs and a sidecar of the RAW file to the test database.
+func createStack(t *testing.T, db *gorm.DB, name string) (photo entity.Photo, files []entity.File) {
+	photo = entity.Photo{PhotoPath: "stack", PhotoName: name, PhotoTitle: "Stack " + name}
+
+	if err := db.Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	files = []entity.File{
+		{FileName: "stack/" + name + ".cr2", FileType: "raw", FilePrimary: false},
+		{FileName: "stack/" + name + ".jpg", FileType: "jpg", FilePrimary: true},
+		{FileName: "stack/" + name + "_edited.jpg", FileType: "jpg", FilePrimary: false},
+		{FileName: "stack/" + name + ".cr2.xmp", FileType: "xmp", FileSidecar: true},
+	}
+
+	for i := range files {
+		files[i].PhotoID = photo.ID
+		files[i].PhotoUUID = photo.PhotoUUID
+		files[i].FileHash = name + files[i].FileName
+
+		if err := db.Create(&files[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return photo, files
+}
+
+func TestPhotoFilePrimary(t *testing.T) {
+	t.Run("photo not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		PhotoFilePrimary(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/xxx/files/yyy/primary")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+	t.Run("file not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		PhotoFilePrimary(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/654/files/yyy/primary")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+	t.Run("edited version", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		db := conf.Db()
+		photo, files := createStack(t, db, "primary")
+
+		PhotoFilePrimary(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUUID+"/files/"+files[2].FileUUID+"/primary")
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		var primary []entity.File
+		db.Where("photo_id = ? AND file_primary = 1", photo.ID).Find(&primary)
+
+		if assert.Len(t, primary, 1) {
+			assert.Equal(t, files[2].FileUUID, primary[0].FileUUID)
+		}
+	})
+	t.Run("raw file", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		photo, files := createStack(t, conf.Db(), "primaryraw")
+
+		PhotoFilePrimary(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUUID+"/files/"+files[0].FileUUID+"/primary")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
+
+func TestPhotoFileUnstack(t *testing.T) {
+	t.Run("photo not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		PhotoFileUnstack(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/xxx/files/yyy/unstack")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+	t.Run("raw file with sidecar", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		db := conf.Db()
+		photo, files := createStack(t, db, "unstack")
+		album := entity.NewAlbum("Unstack")
+
+		if err := db.Create(album).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		db.Create(entity.NewPhotoAlbum(photo.PhotoUUID, album.AlbumUUID))
+		db.Create(entity.NewPhotoLabel(photo.ID, 1, 20, "manual"))
+		db.Create(entity.NewPhotoKeyword(photo.ID, 1))
+
+		PhotoFileUnstack(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUUID+"/files/"+files[0].FileUUID+"/unstack")
+
+		if !assert.Equal(t, http.StatusOK, result.Code) {
+			t.Fatalf("%s", result.Body.String())
+		}
+
+		var unstacked entity.Photo
+
+		if err := db.Where("photo_path = ? AND photo_name = ? AND photo_single = 1", "stack", "unstack").First(&unstacked).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEqual(t, photo.ID, unstacked.ID)
+
+		var moved []entity.File
+		db.Where("photo_id = ?", unstacked.ID).Order("file_name").Find(&moved)
+
+		if assert.Len(t, moved, 2) {
+			assert.Equal(t, "stack/unstack.cr2", moved[0].FileName)
+			assert.Equal(t, "stack/unstack.cr2.xmp", moved[1].FileName)
+		}
+
+		var count int
+
+		db.Model(&entity.File{}).Where("photo_id = ?", photo.ID).Count(&count)
+		assert.Equal(t, 2, count)
+
+		db.Model(&entity.PhotoAlbum{}).Where("photo_uuid = ? AND album_uuid = ?", unstacked.PhotoUUID, album.AlbumUUID).Count(&count)
+		assert.Equal(t, 1, count)
+
+		db.Model(&entity.PhotoLabel{}).Where("photo_id = ? AND label_id = 1", unstacked.ID).Count(&count)
+		assert.Equal(t, 1, count)
+
+		db.Model(&entity.PhotoKeyword{}).Where("photo_id = ? AND keyword_id = 1", unstacked.ID).Count(&count)
+		assert.Equal(t, 1, count)
+	})
+	t.Run("only file", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		db := conf.Db()
+		photo := entity.Photo{PhotoPath: "stack", PhotoName: "single"}
+
+		if err := db.Create(&photo).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		file := entity.File{PhotoID: photo.ID, PhotoUUID: photo.PhotoUUID, FileName: "stack/single.jpg", FileType: "jpg", FileHash: "stacksingle"}
+
+		if err := db.Create(&file).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		PhotoFileUnstack(router, conf)
+
+		result := PerformRequest(app, "POST", "/api/v1/photos/"+photo.PhotoUUID+"/files/"+file.FileUUID+"/unstack")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
@@ -1,17 +1,19 @@
 package api
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
-	"image"
-	"image/color"
 	"net/http"
 	"os"
 	"path"
-	"time"
+	"path/filepath"
+	"strings"
 
 	"github.com/disintegration/imaging"
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/thumb"
@@ -19,34 +21,46 @@ import (
 )
 
 // GET /api/v1/preview
+//
+// Query:
+//   q:     string Search query
+//   album: string Album UUID
+//   label: string Label slug
+//   size:  string Collage layout, see thumb.Collages (default: opengraph)
+//
+// Previews of search queries are rendered on demand, only album and label previews are cached.
 func GetPreview(router *gin.RouterGroup, conf *config.Config) {
 	router.GET("/preview", func(c *gin.Context) {
-		// TODO: proof of concept - code needs refactoring!
-		t := time.Now().Format("20060102")
-		thumbPath := path.Join(conf.ThumbnailsPath(), "preview", t[0:4], t[4:6])
+		var f form.Preview
 
-		if err := os.MkdirAll(thumbPath, os.ModePerm); err != nil {
-			log.Error(err)
-			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+		if err := c.BindQuery(&f); err != nil {
+			c.Data(http.StatusBadRequest, "image/svg+xml", photoIconSvg)
 			return
 		}
 
-		previewFilename := fmt.Sprintf("%s/%s.jpg", thumbPath, t[6:8])
+		if f.Size == "" {
+			f.Size = thumb.DefaultCollage
+		}
 
-		if fs.FileExists(previewFilename) {
-			c.File(previewFilename)
+		collage, ok := thumb.Collages[f.Size]
+
+		if !ok {
+			log.Errorf("preview: invalid size \"%s\"", f.Size)
+			c.Data(http.StatusBadRequest, "image/svg+xml", photoIconSvg)
 			return
 		}
 
-		var f form.PhotoSearch
+		previewPath := path.Join(conf.ThumbnailsPath(), "preview")
 
-		f.Public = true
-		f.Safe = true
-		f.Count = 12
-		f.Order = "relevance"
+		if err := os.MkdirAll(previewPath, os.ModePerm); err != nil {
+			log.Error(err)
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		}
 
+		// Fetch spare photos, so that missing files can be skipped.
 		q := query.New(conf.OriginalsPath(), conf.Db())
-		p, err := q.Photos(f)
+		photos, err := q.Photos(f.PhotoSearch(collage.Tiles() * 2))
 
 		if err != nil {
 			log.Error(err)
@@ -54,60 +68,144 @@ func GetPreview(router *gin.RouterGroup, conf *config.Config) {
 			return
 		}
 
-		width := 908
-		height := 680
-		x := 0
-		y := 0
+		var fileNames, fileHashes []string
+		selection := sha1.New()
 
-		preview := imaging.New(width, height, color.NRGBA{255, 255, 255, 255})
-		thumbType, _ := thumb.Types["tile_224"]
+		for _, p := range photos {
+			if len(fileNames) >= collage.Tiles() {
+				break
+			}
 
-		for _, f := range p {
-			fileName := path.Join(conf.OriginalsPath(), f.FileName)
+			fileName := path.Join(conf.OriginalsPath(), p.FileName)
 
 			if !fs.FileExists(fileName) {
-				log.Errorf("could not find original for thumbnail: %s", fileName)
-				c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+				log.Errorf("preview: could not find original for %s", fileName)
 
 				// Set missing flag so that the file doesn't show up in search results anymore
-				f.FileMissing = true
-				conf.Db().Save(&f)
-				return
+				conf.Db().Model(&entity.File{}).Where("id = ?", p.FileID).UpdateColumn("file_missing", true)
+				continue
 			}
 
-			thumbnail, err := thumb.FromFile(fileName, f.FileHash, conf.ThumbnailsPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
+			fileNames = append(fileNames, fileName)
+			fileHashes = append(fileHashes, p.FileHash)
+			selection.Write([]byte(fmt.Sprintf("%s:%d;", p.FileHash, p.UpdatedAt.Unix())))
+		}
+
+		if len(fileNames) == 0 {
+			sendPreviewPlaceholder(c, previewPath, f.Size, collage)
+			return
+		}
+
+		// Search queries are arbitrary, so that only album and label previews are cached.
+		cached := f.Query == ""
+
+		// The file name changes whenever the selected photos or their primary files change.
+		prefix := fmt.Sprintf("%s_%s_", previewKey(f.Key()), f.Size)
+		previewFilename := path.Join(previewPath, prefix+hex.EncodeToString(selection.Sum(nil))[:16]+".jpg")
+
+		if cached && fs.FileExists(previewFilename) {
+			c.File(previewFilename)
+			return
+		}
+
+		thumbType := thumb.Types["tile_500"]
+		var tiles []string
+
+		for i, fileName := range fileNames {
+			thumbnail, err := thumb.FromFile(fileName, fileHashes[i], conf.ThumbnailsPath(), thumbType.Width, thumbType.Height, thumbType.Options...)
 
 			if err != nil {
 				log.Error(err)
-				c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+				continue
 			}
 
-			src, err := imaging.Open(thumbnail)
+			tiles = append(tiles, thumbnail)
+		}
+
+		if !cached {
+			img, _, err := thumb.RenderCollage(tiles, collage)
 
 			if err != nil {
 				log.Error(err)
-				c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+				sendPreviewPlaceholder(c, previewPath, f.Size, collage)
+				return
 			}
 
-			preview = imaging.Paste(preview, src, image.Pt(x, y))
-
-			x += 228
+			c.Header("Content-Type", "image/jpeg")
 
-			if x > width {
-				x = 0
-				y += 228
+			if err := imaging.Encode(c.Writer, img, imaging.JPEG, imaging.JPEGQuality(thumb.JpegQuality)); err != nil {
+				log.Error(err)
 			}
+
+			return
 		}
 
-		// Save the resulting image as JPEG.
-		err = imaging.Save(preview, previewFilename)
+		// Remove outdated previews for the same album or label.
+		if outdated, err := filepath.Glob(path.Join(previewPath, prefix+"*.jpg")); err == nil {
+			for _, fileName := range outdated {
+				if err := os.Remove(fileName); err != nil {
+					log.Warn(err)
+				}
+			}
+		}
 
-		if err != nil {
+		if _, err := thumb.CreateCollage(tiles, previewFilename, collage); err != nil {
 			log.Error(err)
-			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			sendPreviewPlaceholder(c, previewPath, f.Size, collage)
 			return
 		}
 
 		c.File(previewFilename)
 	})
 }
+
+// sendPreviewPlaceholder sends a blank JPEG with the size of the collage if there are no photos to show.
+func sendPreviewPlaceholder(c *gin.Context, previewPath, size string, collage thumb.Collage) {
+	fileName := path.Join(previewPath, fmt.Sprintf("placeholder_%s.jpg", size))
+
+	if !fs.FileExists(fileName) {
+		if err := thumb.CreatePlaceholder(fileName, collage); err != nil {
+			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
+			return
+		}
+	}
+
+	c.File(fileName)
+}
+
+// SharePreview returns preview image urls for the client-side route requested,
+// so that album and search pages get their own OpenGraph and Twitter cards.
+func SharePreview(r *http.Request, conf *config.Config) gin.H {
+	var f form.Preview
+
+	values := r.URL.Query()
+
+	if strings.HasPrefix(r.URL.Path, "/albums/") {
+		f.Album = strings.Trim(strings.TrimPrefix(r.URL.Path, "/albums/"), "/")
+	} else {
+		f.Query = values.Get("q")
+		f.Label = values.Get("label")
+	}
+
+	result := gin.H{}
+
+	for _, size := range []string{"opengraph", "twitter"} {
+		f.Size = size
+		collage := thumb.Collages[size]
+
+		result[size] = gin.H{
+			"url":    fmt.Sprintf("%sapi/v1/preview?%s", conf.Url(), f.Values().Encode()),
+			"width":  collage.Width,
+			"height": collage.Height,
+		}
+	}
+
+	return result
+}
+
+// previewKey returns a short hash identifying the preview search.
+func previewKey(s string) string {
+	hash := sha1.Sum([]byte(s))
+
+	return hex.EncodeToString(hash[:])[:16]
+}
@@ -2,8 +2,12 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
+	"path"
+	"path/filepath"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,4 +18,53 @@ func TestGetPreview(t *testing.T) {
 		result := PerformRequest(app, "GET", "/api/v1/preview")
 		assert.Equal(t, http.StatusOK, result.Code)
 	})
+	t.Run("twitter size", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPreview(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/preview?size=twitter")
+		assert.Equal(t, http.StatusOK, result.Code)
+	})
+	t.Run("empty selection", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPreview(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/preview?q=xxxnotfoundxxx")
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Equal(t, "image/jpeg", result.Header().Get("Content-Type"))
+	})
+	t.Run("search not cached", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetPreview(router, conf)
+		result := PerformRequest(app, "GET", "/api/v1/preview?q=cat")
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Equal(t, "image/jpeg", result.Header().Get("Content-Type"))
+
+		cached, err := filepath.Glob(path.Join(conf.ThumbnailsPath(), "preview", previewKey("cat||")+"_*.jpg"))
+
+		assert.Nil(t, err)
+		assert.Empty(t, cached)
+	})
+	t.Run("invalid size", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetPreview(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/preview?size=xxx")
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
+
+func TestSharePreview(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	t.Run("album", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/albums/at9lxuqxpogaaba7", nil)
+		result := SharePreview(r, conf)
+		opengraph := result["opengraph"].(gin.H)
+		assert.Equal(t, conf.Url()+"api/v1/preview?album=at9lxuqxpogaaba7&size=opengraph", opengraph["url"])
+		assert.Equal(t, 630, opengraph["height"])
+	})
+	t.Run("search", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/photos?q=cat", nil)
+		result := SharePreview(r, conf)
+		twitter := result["twitter"].(gin.H)
+		assert.Equal(t, conf.Url()+"api/v1/preview?q=cat&size=twitter", twitter["url"])
+	})
 }
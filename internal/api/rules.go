@@ -0,0 +1,119 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/rules
+//
+// Returns user-defined label rules, see classify.UserRules.
+func GetRules(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/rules", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		c.JSON(http.StatusOK, classify.Rules())
+	})
+}
+
+// GET /api/v1/rules/:label
+//
+// Returns the effective rule for a classifier label and the user-defined override, if any.
+//
+// Parameters:
+//   label: string Classifier label name, e.g. "tabby cat"
+func GetRule(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/rules/:label", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		label := strings.ToLower(c.Param("label"))
+		result := gin.H{"label": label, "rule": classify.FindRule(label)}
+
+		if rule, ok := classify.Rules()[label]; ok {
+			result["user"] = rule
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// PUT /api/v1/rules/:label
+//
+// Parameters:
+//   label: string Classifier label name, e.g. "tabby cat"
+func SaveRule(router *gin.RouterGroup, conf *config.Config) {
+	router.PUT("/rules/:label", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		var rule classify.UserRule
+
+		if err := c.BindJSON(&rule); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		label := c.Param("label")
+
+		if err := classify.SetRule(label, rule); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if err := classify.SaveRules(conf.RulesFile()); err != nil {
+			log.Errorf("rules: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("label rule saved")
+
+		c.JSON(http.StatusOK, classify.FindRule(strings.ToLower(label)))
+	})
+}
+
+// DELETE /api/v1/rules/:label
+//
+// Removes a user-defined rule, so that the built-in rule applies again.
+//
+// Parameters:
+//   label: string Classifier label name, e.g. "tabby cat"
+func DeleteRule(router *gin.RouterGroup, conf *config.Config) {
+	router.DELETE("/rules/:label", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		label := c.Param("label")
+
+		if !classify.DeleteRule(label) {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrRuleNotFound)
+			return
+		}
+
+		if err := classify.SaveRules(conf.RulesFile()); err != nil {
+			log.Errorf("rules: %s", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		event.Success("label rule deleted")
+
+		c.JSON(http.StatusOK, classify.FindRule(strings.ToLower(label)))
+	})
+}
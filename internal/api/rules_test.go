@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRule(t *testing.T) {
+	t.Run("built-in rule", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetRule(router, conf)
+		result := PerformRequest(app, "GET", "/api/v1/rules/tabby%20cat")
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Contains(t, result.Body.String(), "\"Label\":\"cat\"")
+	})
+}
+
+func TestSaveRule(t *testing.T) {
+	defer classify.LoadRules("")
+
+	t.Run("save and delete", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SaveRule(router, conf)
+		DeleteRule(router, conf)
+		GetRules(router, conf)
+
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/rules/kitten", `{"see": "cat"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Contains(t, result.Body.String(), "\"Label\":\"cat\"")
+
+		result = PerformRequest(app, "GET", "/api/v1/rules")
+		assert.Contains(t, result.Body.String(), "kitten")
+
+		result = PerformRequest(app, "DELETE", "/api/v1/rules/kitten")
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		result = PerformRequest(app, "DELETE", "/api/v1/rules/kitten")
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+	t.Run("refers to itself", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		SaveRule(router, conf)
+		result := PerformRequestWithBody(app, "PUT", "/api/v1/rules/kitten", `{"see": "kitten"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -202,6 +204,150 @@ func BatchPhotosStory(router *gin.RouterGroup, conf *config.Config) {
 	})
 }
 
+// POST /api/v1/batch/photos/time
+//
+// Parameters:
+//   photos: []string PhotoUUIDs of the selected photos
+//   offset: string Duration like -1h30m to correct a camera clock
+//   timezone: string Time zone like Asia/Tokyo, the local time is kept
+//   reference: string PhotoUUID of a photo with the correct time, e.g. taken with another camera
+//   target: string Selected photo taken at the same moment as the reference, default is the first
+//   sidecar: bool Write the changed date to XMP sidecar files
+func BatchPhotosTime(router *gin.RouterGroup, conf *config.Config) {
+	router.POST("/batch/photos/time", func(c *gin.Context) {
+		if Unauthorized(c, conf) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, ErrUnauthorized)
+			return
+		}
+
+		start := time.Now()
+
+		var f form.TimeShift
+
+		if err := c.BindJSON(&f); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			log.Error("no photos selected")
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no photos selected")})
+			return
+		}
+
+		if f.Empty() {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst("no offset, time zone or reference photo")})
+			return
+		}
+
+		if f.Sidecar && conf.ReadOnly() {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrReadOnly)
+			return
+		}
+
+		offset, err := f.Duration()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if _, err := time.LoadLocation(f.TimeZone); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(fmt.Sprintf("unknown time zone \"%s\"", f.TimeZone))})
+			return
+		}
+
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		// Clocks of different cameras may be off by different durations in UTC and local time.
+		utcOffset, localOffset := offset, offset
+		var refZone string
+
+		if f.Reference != "" {
+			ref, err := q.FindPhotoByUUID(f.Reference)
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+				return
+			}
+
+			target, err := q.FindPhotoByUUID(f.TargetPhoto())
+
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusNotFound, ErrPhotoNotFound)
+				return
+			}
+
+			utcOffset += ref.TakenAt.Sub(target.TakenAt)
+			localOffset += ref.TakenAtLocal.Sub(target.TakenAtLocal)
+			refZone = ref.TimeZone
+		}
+
+		photos, err := q.PhotoSelection(f.Selection)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("changing time of photos: %#v", f.Photos)
+
+		for i := range photos {
+			p := &photos[i]
+
+			p.ShiftTime(utcOffset, localOffset)
+
+			if p.TimeZone == "" {
+				p.TimeZone = refZone
+			}
+
+			if f.TimeZone != "" {
+				if err := p.SetTimeZone(f.TimeZone); err != nil {
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+					return
+				}
+			}
+		}
+
+		// Either all photos are changed or none
+		tx := conf.Db().Begin()
+
+		for i := range photos {
+			if err := photos[i].SaveTime(tx); err != nil {
+				tx.Rollback()
+				log.Error(err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+				return
+			}
+		}
+
+		if err := tx.Commit().Error; err != nil {
+			log.Error(err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, ErrSaveFailed)
+			return
+		}
+
+		for _, p := range photos {
+			if f.Sidecar {
+				primary, _ := q.FindFileByPhotoUUID(p.PhotoUUID)
+				sidecars, _ := q.FindSidecarFiles(p.ID)
+
+				if xmpName, err := photoprism.WriteXmpDate(conf.OriginalsPath(), p, primary, sidecars); err != nil {
+					log.Errorf("time: %s", err)
+				} else {
+					log.Infof("time: updated date in \"%s\"", xmpName)
+				}
+			}
+
+			PublishPhotoEvent(EntityUpdated, p.PhotoUUID, c, q)
+		}
+
+		elapsed := time.Since(start)
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("time of %d photos changed in %s", len(photos), elapsed)})
+	})
+}
+
 // POST /api/v1/batch/labels/delete
 func BatchLabelsDelete(router *gin.RouterGroup, conf *config.Config) {
 	router.POST("/batch/labels/delete", func(c *gin.Context) {
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchPhotosTime(t *testing.T) {
+	t.Run("no photos selected", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		BatchPhotosTime(router, conf)
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": [], "offset": "1h"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("no time shift", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		BatchPhotosTime(router, conf)
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["654"]}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		BatchPhotosTime(router, conf)
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["654"], "offset": "1 hour"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("unknown time zone", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		BatchPhotosTime(router, conf)
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["654"], "timezone": "Mars/Olympus"}`)
+		assert.Equal(t, http.StatusBadRequest, result.Code)
+	})
+	t.Run("reference not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		BatchPhotosTime(router, conf)
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["654"], "reference": "xxx"}`)
+		assert.Equal(t, http.StatusNotFound, result.Code)
+	})
+	t.Run("shift all photos", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		q := query.New(conf.OriginalsPath(), conf.Db())
+
+		BatchPhotosTime(router, conf)
+
+		before := make(map[string]time.Time)
+
+		for _, id := range []string{"658", "659"} {
+			p, err := q.FindPhotoByUUID(id)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			before[id] = p.TakenAt
+		}
+
+		result := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/time", `{"photos": ["658", "659"], "offset": "1h"}`)
+		assert.Equal(t, http.StatusOK, result.Code)
+
+		for id, taken := range before {
+			p, err := q.FindPhotoByUUID(id)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, time.Hour, p.TakenAt.Sub(taken), id)
+			assert.Equal(t, entity.TakenSrcManual, p.TakenSrc, id)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// MinFreeSpace is the minimum number of free bytes required in storage paths (100 MB).
+const MinFreeSpace = 100 * 1024 * 1024
+
+// StatusCheck represents the result of a single readiness check.
+type StatusCheck struct {
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+	Free    uint64 `json:"free,omitempty"`
+}
+
+// StatusChecks maps check names to results.
+type StatusChecks map[string]StatusCheck
+
+// OK returns true if all checks passed.
+func (s StatusChecks) OK() bool {
+	for _, check := range s {
+		if !check.OK {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GET /api/v1/status
+//
+// Liveness probe, returns 200 as long as the server can handle requests.
+func GetStatus(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/status", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "operational", "version": conf.Version()})
+	})
+}
+
+// GET /api/v1/status/ready
+//
+// Readiness probe, returns 503 if the database, storage paths or models are not usable.
+func GetReadiness(router *gin.RouterGroup, conf *config.Config) {
+	router.GET("/status/ready", func(c *gin.Context) {
+		checks := StatusChecks{
+			"database":   checkDatabase(conf),
+			"originals":  checkPath(conf.OriginalsPath(), !conf.ReadOnly()),
+			"cache":      checkPath(conf.CachePath(), true),
+			"thumbnails": checkPath(conf.ThumbnailsPath(), true),
+			"tensorflow": checkTensorFlow(conf),
+			"worker":     checkWorker(),
+		}
+
+		if checks.OK() {
+			c.JSON(http.StatusOK, gin.H{"status": "ready", "checks": checks})
+		} else {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable", "checks": checks})
+		}
+	})
+}
+
+// checkDatabase pings the database server.
+func checkDatabase(conf *config.Config) StatusCheck {
+	if err := conf.Db().DB().Ping(); err != nil {
+		log.Errorf("status: database %s", err)
+		return StatusCheck{OK: false, Message: err.Error()}
+	}
+
+	return StatusCheck{OK: true}
+}
+
+// checkPath tests if a storage path exists, is writable if required and has enough free space.
+func checkPath(path string, writable bool) StatusCheck {
+	if !fs.PathExists(path) {
+		return StatusCheck{OK: false, Message: "not found"}
+	}
+
+	if writable && !fs.Writable(path) {
+		return StatusCheck{OK: false, Message: "not writable"}
+	}
+
+	free, err := fs.FreeSpace(path)
+
+	if err != nil {
+		return StatusCheck{OK: true, Message: err.Error()}
+	}
+
+	if free < MinFreeSpace {
+		return StatusCheck{OK: false, Message: fmt.Sprintf("less than %d MB free", MinFreeSpace/1024/1024), Free: free}
+	}
+
+	return StatusCheck{OK: true, Free: free}
+}
+
+// checkTensorFlow reports the model load state, models are loaded on demand.
+func checkTensorFlow(conf *config.Config) StatusCheck {
+	if conf.TensorFlowDisabled() {
+		return StatusCheck{OK: true, Message: "disabled"}
+	}
+
+	if tf != nil && tf.Loaded() {
+		return StatusCheck{OK: true, Message: "loaded"}
+	}
+
+	if !fs.PathExists(conf.TensorFlowModelPath()) {
+		return StatusCheck{OK: false, Message: "model not found"}
+	}
+
+	return StatusCheck{OK: true, Message: "not loaded"}
+}
+
+// checkWorker reports the current background worker state.
+func checkWorker() StatusCheck {
+	switch {
+	case mutex.Worker.Canceled():
+		return StatusCheck{OK: true, Message: "canceled"}
+	case mutex.Worker.Busy():
+		return StatusCheck{OK: true, Message: "busy"}
+	default:
+		return StatusCheck{OK: true, Message: "idle"}
+	}
+}
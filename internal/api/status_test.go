@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStatus(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetStatus(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/status")
+		assert.Equal(t, http.StatusOK, result.Code)
+		assert.Contains(t, result.Body.String(), "operational")
+	})
+}
+
+func TestGetReadiness(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, ctx := NewApiTest()
+		GetReadiness(router, ctx)
+		result := PerformRequest(app, "GET", "/api/v1/status/ready")
+		t.Log(result.Body)
+		assert.Contains(t, result.Body.String(), "database")
+		assert.Contains(t, result.Body.String(), "worker")
+	})
+}
+
+func TestStatusChecks_OK(t *testing.T) {
+	t.Run("all ok", func(t *testing.T) {
+		checks := StatusChecks{"foo": {OK: true}, "bar": {OK: true}}
+		assert.True(t, checks.OK())
+	})
+	t.Run("one failed", func(t *testing.T) {
+		checks := StatusChecks{"foo": {OK: true}, "bar": {OK: false}}
+		assert.False(t, checks.OK())
+	})
+}
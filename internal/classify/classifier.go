@@ -0,0 +1,122 @@
+package classify
+
+// Classifier returns raw predictions for image files.
+type Classifier interface {
+	Name() string
+	Init() error
+	Disabled() bool
+	Loaded() bool
+	FilePredictions(filename string) (Predictions, error)
+}
+
+// Classifiers combines the results of multiple classifiers.
+type Classifiers []Classifier
+
+// NewClassifiers returns the built-in NASNet classifier followed by the additional models
+// configured in modelsFile.
+func NewClassifiers(modelsPath, modelsFile string, disabled bool) *Classifiers {
+	result := Classifiers{New(modelsPath, disabled)}
+
+	models, err := LoadModels(modelsFile)
+
+	if err != nil {
+		log.Errorf("classify: %s", err)
+		return &result
+	}
+
+	for _, m := range models {
+		result = append(result, NewModel(modelsPath, m, disabled))
+	}
+
+	return &result
+}
+
+// Name returns the names of all classifiers.
+func (c Classifiers) Name() string {
+	var result string
+
+	for i, classifier := range c {
+		if i > 0 {
+			result += ", "
+		}
+
+		result += classifier.Name()
+	}
+
+	return result
+}
+
+// Init loads all models. Models that can't be loaded are removed, so that the others can still be used.
+// An error is only returned if no model is left.
+func (c *Classifiers) Init() (err error) {
+	var result Classifiers
+
+	for _, classifier := range *c {
+		if e := classifier.Init(); e != nil {
+			log.Errorf("classify: could not load %s model (%s)", classifier.Name(), e)
+			err = e
+			continue
+		}
+
+		result = append(result, classifier)
+	}
+
+	*c = result
+
+	if len(result) > 0 {
+		return nil
+	}
+
+	return err
+}
+
+// Disabled returns true if all classifiers are disabled.
+func (c Classifiers) Disabled() bool {
+	for _, classifier := range c {
+		if !classifier.Disabled() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Loaded returns true if all enabled classifiers have been loaded.
+func (c Classifiers) Loaded() bool {
+	if len(c) == 0 {
+		return false
+	}
+
+	for _, classifier := range c {
+		if !classifier.Disabled() && !classifier.Loaded() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilePredictions returns the combined predictions of all classifiers for a jpeg media file.
+// Errors are only returned if no classifier succeeded.
+func (c Classifiers) FilePredictions(filename string) (result Predictions, err error) {
+	var succeeded bool
+
+	for _, classifier := range c {
+		predictions, e := classifier.FilePredictions(filename)
+
+		if e != nil {
+			log.Errorf("classify: %s failed on %s (%s)", classifier.Name(), filename, e)
+			err = e
+			continue
+		}
+
+		succeeded = true
+		result = append(result, predictions...)
+	}
+
+	if succeeded {
+		return result, nil
+	}
+
+	return result, err
+}
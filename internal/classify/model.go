@@ -0,0 +1,151 @@
+package classify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"gopkg.in/yaml.v2"
+)
+
+// Model describes a TensorFlow SavedModel used for image classification.
+// Pixel values are normalized as (value - Mean) / Std before inference.
+type Model struct {
+	Name      string    `yaml:"name" json:"name"`
+	Path      string    `yaml:"path,omitempty" json:"path,omitempty"`
+	Tags      []string  `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Input     string    `yaml:"input,omitempty" json:"input,omitempty"`
+	Output    string    `yaml:"output,omitempty" json:"output,omitempty"`
+	Size      int       `yaml:"size,omitempty" json:"size,omitempty"`
+	Mean      float32   `yaml:"mean,omitempty" json:"mean,omitempty"`
+	Std       float32   `yaml:"std,omitempty" json:"std,omitempty"`
+	Labels    string    `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Threshold float32   `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	Rules     UserRules `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// Models is a list of additional classification models.
+type Models []Model
+
+// NASNet is the built-in default model.
+var NASNet = Model{
+	Name:      "nasnet",
+	Path:      "nasnet",
+	Tags:      []string{"photoprism"},
+	Input:     "input_1",
+	Output:    "predictions/Softmax",
+	Size:      224,
+	Mean:      127.5,
+	Std:       127.5,
+	Labels:    "labels.txt",
+	Threshold: 0.1,
+}
+
+var modelRules = make(map[string]UserRules)
+var modelRulesMutex = sync.RWMutex{}
+
+// Builtin returns true if the built-in label rules apply to the model.
+func (m Model) Builtin() bool {
+	return m.Name == "" || m.Name == NASNet.Name
+}
+
+// WithDefaults returns a copy of the model with empty values replaced by NASNet defaults.
+func (m Model) WithDefaults() Model {
+	if m.Path == "" {
+		m.Path = m.Name
+	}
+
+	if len(m.Tags) == 0 {
+		m.Tags = NASNet.Tags
+	}
+
+	if m.Input == "" {
+		m.Input = NASNet.Input
+	}
+
+	if m.Output == "" {
+		m.Output = NASNet.Output
+	}
+
+	if m.Size <= 0 {
+		m.Size = NASNet.Size
+	}
+
+	if m.Mean == 0 && m.Std == 0 {
+		m.Mean = NASNet.Mean
+		m.Std = NASNet.Std
+	} else if m.Std == 0 {
+		m.Std = 1
+	}
+
+	if m.Labels == "" {
+		m.Labels = NASNet.Labels
+	}
+
+	if m.Threshold <= 0 {
+		m.Threshold = NASNet.Threshold
+	}
+
+	return m
+}
+
+// Normalize converts a 16 bit color value to the model input range.
+func (m Model) Normalize(value uint32) float32 {
+	return (float32(value>>8) - m.Mean) / m.Std
+}
+
+// LoadModels reads additional classification models from a YAML file and registers
+// their label rules, a missing file is not an error.
+func LoadModels(fileName string) (result Models, err error) {
+	if fs.FileExists(fileName) {
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			return result, err
+		}
+
+		if err := yaml.Unmarshal(data, &result); err != nil {
+			return result, fmt.Errorf("classify: invalid models file %s (%s)", fileName, err)
+		}
+	}
+
+	names := make(map[string]bool)
+	rules := make(map[string]UserRules)
+
+	for i, m := range result {
+		name := strings.ToLower(strings.TrimSpace(m.Name))
+
+		if name == "" {
+			return result, fmt.Errorf("classify: model %d has no name", i+1)
+		}
+
+		if name == NASNet.Name || names[name] {
+			return result, fmt.Errorf("classify: duplicate model name %s", name)
+		}
+
+		for label := range m.Rules {
+			if label != strings.ToLower(label) {
+				return result, fmt.Errorf("classify: label must be lowercase: %s", label)
+			}
+		}
+
+		names[name] = true
+		rules[name] = m.Rules
+
+		result[i] = m.WithDefaults()
+		result[i].Name = name
+	}
+
+	if len(result) > 0 {
+		log.Infof("classify: loaded %d additional models from %s", len(result), fileName)
+	}
+
+	modelRulesMutex.Lock()
+	defer modelRulesMutex.Unlock()
+
+	modelRules = rules
+
+	return result, nil
+}
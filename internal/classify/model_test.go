@@ -0,0 +1,247 @@
+package classify
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+	"github.com/tensorflow/tensorflow/tensorflow/go/op"
+)
+
+// protoField encodes a length-delimited protocol buffer field.
+func protoField(field uint64, data []byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	result := buf[:binary.PutUvarint(buf, field<<3|2)]
+	result = append(result, buf[:binary.PutUvarint(buf, uint64(len(data)))]...)
+
+	return append(result, data...)
+}
+
+// writeFixtureModel creates a tiny SavedModel that predicts the average color of an image.
+func writeFixtureModel(dir string, size int64) error {
+	s := op.NewScope()
+	input := op.Placeholder(s.SubScope("input"), tf.Float, op.PlaceholderShape(tf.MakeShape(1, size, size, 3)))
+	mean := op.Mean(s, input, op.Const(s.SubScope("axes"), []int32{1, 2}))
+	op.Softmax(s.SubScope("output"), op.Mul(s, mean, op.Const(s.SubScope("scale"), float32(10))))
+
+	graph, err := s.Finalize()
+
+	if err != nil {
+		return err
+	}
+
+	var graphDef bytes.Buffer
+
+	if _, err := graph.WriteTo(&graphDef); err != nil {
+		return err
+	}
+
+	metaInfo := protoField(4, []byte("fixture"))
+	metaGraph := append(protoField(1, metaInfo), protoField(2, graphDef.Bytes())...)
+	savedModel := append([]byte{8, 1}, protoField(2, metaGraph)...)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "saved_model.pb"), savedModel, 0644); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, "colors.txt"), []byte("red\ngreen\nblue\n"), 0644)
+}
+
+func writeFixtureImage(fileName string, c color.Color) error {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 64; y++ {
+			img.Set(x, y, c)
+		}
+	}
+
+	f, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return jpeg.Encode(f, img, &jpeg.Options{Quality: 100})
+}
+
+func TestModel_WithDefaults(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		m := Model{Name: "birds"}.WithDefaults()
+
+		assert.Equal(t, "birds", m.Path)
+		assert.Equal(t, NASNet.Input, m.Input)
+		assert.Equal(t, NASNet.Output, m.Output)
+		assert.Equal(t, 224, m.Size)
+		assert.Equal(t, float32(127.5), m.Mean)
+		assert.Equal(t, float32(127.5), m.Std)
+		assert.Equal(t, "labels.txt", m.Labels)
+		assert.Equal(t, float32(0.1), m.Threshold)
+	})
+	t.Run("zero mean", func(t *testing.T) {
+		m := Model{Name: "plants", Size: 299, Std: 255}.WithDefaults()
+
+		assert.Equal(t, 299, m.Size)
+		assert.Equal(t, float32(0), m.Mean)
+		assert.Equal(t, float32(1), m.Normalize(255<<8))
+	})
+}
+
+func TestLoadModels(t *testing.T) {
+	dir, err := ioutil.TempDir("", "models")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+	defer LoadModels("")
+
+	t.Run("missing file", func(t *testing.T) {
+		result, err := LoadModels(filepath.Join(dir, "missing.yml"))
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("valid file", func(t *testing.T) {
+		fileName := filepath.Join(dir, "models.yml")
+		data := "- name: Birds\n  size: 299\n  rules:\n    robin:\n      label: bird\n      priority: 2\n    sparrow:\n      see: bird\n"
+
+		if err := ioutil.WriteFile(fileName, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := LoadModels(fileName)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "birds", result[0].Name)
+		assert.Equal(t, 299, result[0].Size)
+
+		robin := FindModelRule("birds", "robin")
+		assert.Equal(t, "bird", robin.Label)
+		assert.Equal(t, 2, robin.Priority)
+		assert.Equal(t, float32(0.1), robin.Threshold)
+
+		sparrow := FindModelRule("birds", "sparrow")
+		assert.Equal(t, "bird", sparrow.Label)
+		assert.Equal(t, "animal", sparrow.Categories[0])
+
+		assert.Equal(t, "", FindModelRule("birds", "cat").Label)
+		assert.Equal(t, "cat", FindModelRule("nasnet", "cat").Label)
+	})
+	t.Run("duplicate name", func(t *testing.T) {
+		fileName := filepath.Join(dir, "duplicate.yml")
+
+		if err := ioutil.WriteFile(fileName, []byte("- name: nasnet\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := LoadModels(fileName)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTensorFlow_FixtureModel(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	dir, err := ioutil.TempDir("", "fixture")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+	defer LoadModels("")
+
+	if err := writeFixtureModel(dir, 32); err != nil {
+		t.Fatal(err)
+	}
+
+	modelsFile := filepath.Join(dir, "models.yml")
+	data := "- name: colors\n  path: " + dir + "\n  tags: [fixture]\n  input: input/Placeholder\n  output: output/Softmax\n  size: 32\n  std: 255\n  labels: colors.txt\n  rules:\n    red:\n      label: crimson\n      priority: 2\n"
+
+	if err := ioutil.WriteFile(modelsFile, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	imageName := filepath.Join(dir, "red.jpg")
+
+	if err := writeFixtureImage(imageName, color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+
+	classifiers := NewClassifiers(dir, modelsFile, false)
+
+	assert.Len(t, *classifiers, 2)
+	assert.Equal(t, "nasnet, colors", classifiers.Name())
+
+	fixture := (*classifiers)[1]
+
+	if err := fixture.Init(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fixture.Loaded())
+
+	predictions, err := fixture.FilePredictions(imageName)
+
+	assert.Nil(t, err)
+	assert.Len(t, predictions, 1)
+	assert.Equal(t, "colors", predictions[0].Model)
+	assert.Equal(t, "red", predictions[0].Name)
+
+	labels := predictions.Labels()
+
+	assert.Len(t, labels, 1)
+	assert.Equal(t, "crimson", labels[0].Name)
+	assert.Equal(t, 2, labels[0].Priority)
+}
+
+func TestClassifiers_Init(t *testing.T) {
+	t.Run("missing model", func(t *testing.T) {
+		classifiers := Classifiers{NewModel("/nonexistent", Model{Name: "birds"}, false), New("", true)}
+
+		assert.Nil(t, classifiers.Init())
+		assert.Len(t, classifiers, 1)
+		assert.Equal(t, "nasnet", classifiers.Name())
+	})
+	t.Run("no model left", func(t *testing.T) {
+		classifiers := Classifiers{NewModel("/nonexistent", Model{Name: "birds"}, false)}
+
+		assert.Error(t, classifiers.Init())
+		assert.Empty(t, classifiers)
+	})
+}
+
+func TestClassifiers_FilePredictions(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		classifiers := Classifiers{New("", true), NewModel("", Model{Name: "birds"}, true)}
+
+		assert.True(t, classifiers.Disabled())
+		assert.True(t, classifiers.Loaded())
+
+		result, err := classifiers.FilePredictions("missing.jpg")
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		classifiers := Classifiers{}
+
+		assert.False(t, classifiers.Loaded())
+	})
+}
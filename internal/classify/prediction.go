@@ -0,0 +1,51 @@
+package classify
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Prediction represents a raw classifier result before label rules are applied.
+type Prediction struct {
+	Model       string  `json:"model,omitempty"`
+	Name        string  `json:"name"`
+	Probability float32 `json:"p"`
+}
+
+// Predictions is a list of raw classifier results for a single image.
+type Predictions []Prediction
+
+// Labels applies the current label rules and returns the best matching labels.
+func (p Predictions) Labels() Labels {
+	var result Labels
+
+	for _, prediction := range p {
+		labelText := strings.ToLower(prediction.Name)
+
+		rule := FindModelRule(prediction.Model, labelText)
+
+		if prediction.Probability < rule.Threshold {
+			continue
+		}
+
+		if rule.Label != "" {
+			labelText = rule.Label
+		}
+
+		labelText = strings.TrimSpace(labelText)
+
+		uncertainty := 100 - int(math.Round(float64(prediction.Probability*100)))
+
+		result = append(result, Label{Name: labelText, Source: "image", Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
+	}
+
+	// Sort by probability
+	sort.Sort(result)
+
+	if l := len(result); l < 5 {
+		return result[:l]
+	} else {
+		return result[:5]
+	}
+}
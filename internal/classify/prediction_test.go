@@ -0,0 +1,23 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredictions_Labels(t *testing.T) {
+	t.Run("rules applied", func(t *testing.T) {
+		p := Predictions{{Name: "tabby cat", Probability: 0.8}, {Name: "abacus", Probability: 0.9}}
+
+		result := p.Labels()
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "cat", result[0].Name)
+		assert.Equal(t, 20, result[0].Uncertainty)
+		assert.Equal(t, "image", result[0].Source)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Predictions{}.Labels())
+	})
+}
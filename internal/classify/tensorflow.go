@@ -4,34 +4,40 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
+	"fmt"
 	"image"
 	"io/ioutil"
-	"math"
 	"os"
 	"path"
 	"path/filepath"
-	"sort"
 	"strings"
 
 	"github.com/disintegration/imaging"
-	"github.com/photoprism/photoprism/internal/config"
 	tf "github.com/tensorflow/tensorflow/tensorflow/go"
 )
 
 // TensorFlow if a wrapper for their low-level API.
 type TensorFlow struct {
-	conf       *config.Config
 	model      *tf.SavedModel
 	modelsPath string
 	disabled   bool
-	modelName  string
-	modelTags  []string
+	config     Model
 	labels     []string
 }
 
 // New returns new TensorFlow instance with Nasnet model.
 func New(modelsPath string, disabled bool) *TensorFlow {
-	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, modelName: "nasnet", modelTags: []string{"photoprism"}}
+	return NewModel(modelsPath, NASNet, disabled)
+}
+
+// NewModel returns a new TensorFlow instance for a SavedModel in modelsPath.
+func NewModel(modelsPath string, m Model, disabled bool) *TensorFlow {
+	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, config: m.WithDefaults()}
+}
+
+// Name returns the model name.
+func (t *TensorFlow) Name() string {
+	return t.config.Name
 }
 
 func (t *TensorFlow) Init() (err error) {
@@ -42,6 +48,25 @@ func (t *TensorFlow) Init() (err error) {
 	return t.loadModel()
 }
 
+// Disabled returns true if image classification is disabled.
+func (t *TensorFlow) Disabled() bool {
+	return t.disabled
+}
+
+// Loaded returns true if the model has been loaded.
+func (t *TensorFlow) Loaded() bool {
+	return t.model != nil
+}
+
+// ModelPath returns the model path.
+func (t *TensorFlow) ModelPath() string {
+	if filepath.IsAbs(t.config.Path) {
+		return t.config.Path
+	}
+
+	return path.Join(t.modelsPath, t.config.Path)
+}
+
 // File returns matching labels for a jpeg media file.
 func (t *TensorFlow) File(filename string) (result Labels, err error) {
 	if t.disabled {
@@ -57,16 +82,59 @@ func (t *TensorFlow) File(filename string) (result Labels, err error) {
 	return t.Labels(imageBuffer)
 }
 
+// FilePredictions returns raw classifier results for a jpeg media file.
+func (t *TensorFlow) FilePredictions(filename string) (result Predictions, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
+	imageBuffer, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Predictions(imageBuffer)
+}
+
 // Labels returns matching labels for a jpeg media string.
 func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	if t.disabled {
 		return result, nil
 	}
 
+	predictions, err := t.Predictions(img)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result = predictions.Labels()
+
+	if len(result) > 0 {
+		log.Debugf("tensorflow: image classified as %+v", result)
+	}
+
+	return result, nil
+}
+
+// Predictions returns raw classifier results for a jpeg media string, label rules are not applied.
+func (t *TensorFlow) Predictions(img []byte) (result Predictions, err error) {
+	if t.disabled {
+		return result, nil
+	}
+
 	if err := t.loadModel(); err != nil {
 		return nil, err
 	}
 
+	inputOp := t.model.Graph.Operation(t.config.Input)
+	outputOp := t.model.Graph.Operation(t.config.Output)
+
+	if inputOp == nil || outputOp == nil {
+		return nil, fmt.Errorf("model %s has no operation %s or %s", t.config.Name, t.config.Input, t.config.Output)
+	}
+
 	// Make tensor
 	tensor, err := t.makeTensor(img, "jpeg")
 
@@ -78,10 +146,10 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	// Run inference
 	output, err := t.model.Session.Run(
 		map[tf.Output]*tf.Tensor{
-			t.model.Graph.Operation("input_1").Output(0): tensor,
+			inputOp.Output(0): tensor,
 		},
 		[]tf.Output{
-			t.model.Graph.Operation("predictions/Softmax").Output(0),
+			outputOp.Output(0),
 		},
 		nil)
 
@@ -94,20 +162,17 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 		return result, errors.New("result is empty")
 	}
 
-	// Return best labels
-	result = t.bestLabels(output[0].Value().([][]float32)[0])
-
-	if len(result) > 0 {
-		log.Debugf("tensorflow: image classified as %+v", result)
-	}
-
-	return result, nil
+	return t.predictions(output[0].Value().([][]float32)[0]), nil
 }
 
 func (t *TensorFlow) loadLabels(path string) error {
-	modelLabels := path + "/labels.txt"
+	modelLabels := t.config.Labels
 
-	log.Infof("tensorflow: loading classification labels from labels.txt")
+	if !filepath.IsAbs(modelLabels) {
+		modelLabels = filepath.Join(path, modelLabels)
+	}
+
+	log.Infof("tensorflow: loading classification labels from %s", filepath.Base(modelLabels))
 
 	// Load labels
 	f, err := os.Open(modelLabels)
@@ -138,12 +203,12 @@ func (t *TensorFlow) loadModel() error {
 		return nil
 	}
 
-	modelPath := path.Join(t.modelsPath, t.modelName)
+	modelPath := t.ModelPath()
 
 	log.Infof("tensorflow: loading image classification model from \"%s\"", filepath.Base(modelPath))
 
 	// Load model
-	model, err := tf.LoadSavedModel(modelPath, t.modelTags, nil)
+	model, err := tf.LoadSavedModel(modelPath, t.config.Tags, nil)
 
 	if err != nil {
 		return err
@@ -155,45 +220,26 @@ func (t *TensorFlow) loadModel() error {
 }
 
 func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
-	// Make a list of label/probability pairs
-	var result Labels
+	return t.predictions(probabilities).Labels()
+}
+
+// predictions returns the raw classifier results with a probability of at least the model threshold.
+func (t *TensorFlow) predictions(probabilities []float32) Predictions {
+	var result Predictions
 
 	for i, p := range probabilities {
 		if i >= len(t.labels) {
 			break
 		}
 
-		if p < 0.1 {
-			continue
-		}
-
-		labelText := strings.ToLower(t.labels[i])
-
-		rule := rules.Find(labelText)
-
-		if p < rule.Threshold {
+		if p < t.config.Threshold {
 			continue
 		}
 
-		if rule.Label != "" {
-			labelText = rule.Label
-		}
-
-		labelText = strings.TrimSpace(labelText)
-
-		uncertainty := 100 - int(math.Round(float64(p*100)))
-
-		result = append(result, Label{Name: labelText, Source: "image", Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
+		result = append(result, Prediction{Model: t.Name(), Name: strings.ToLower(t.labels[i]), Probability: p})
 	}
 
-	// Sort by probability
-	sort.Sort(result)
-
-	if l := len(result); l < 5 {
-		return result[:l]
-	} else {
-		return result[:5]
-	}
+	return result
 }
 
 func (t *TensorFlow) makeTensor(image []byte, imageFormat string) (*tf.Tensor, error) {
@@ -203,14 +249,14 @@ func (t *TensorFlow) makeTensor(image []byte, imageFormat string) (*tf.Tensor, e
 		return nil, err
 	}
 
-	width, height := 224, 224
+	width, height := t.config.Size, t.config.Size
 
 	img = imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
 
-	return imageToTensorTF(img, width, height)
+	return imageToTensorTF(img, width, height, t.config.Normalize)
 }
 
-func imageToTensorTF(img image.Image, imageHeight, imageWidth int) (*tf.Tensor, error) {
+func imageToTensorTF(img image.Image, imageHeight, imageWidth int, convertTF func(uint32) float32) (*tf.Tensor, error) {
 	var tfImage [1][][][3]float32
 
 	for j := 0; j < imageHeight; j++ {
@@ -228,7 +274,3 @@ func imageToTensorTF(img image.Image, imageHeight, imageWidth int) (*tf.Tensor,
 
 	return tf.NewTensor(tfImage)
 }
-
-func convertTF(value uint32) float32 {
-	return (float32(value>>8) - float32(127.5)) / float32(127.5)
-}
@@ -205,7 +205,7 @@ func TestTensorFlow_MakeTensor(t *testing.T) {
 	})
 }
 
-func Test_ConvertTF(t *testing.T) {
-	result := convertTF(uint32(98765432))
+func TestModel_Normalize(t *testing.T) {
+	result := NASNet.Normalize(uint32(98765432))
 	assert.Equal(t, float32(3024.898), result)
 }
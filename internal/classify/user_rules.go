@@ -0,0 +1,204 @@
+package classify
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"gopkg.in/yaml.v2"
+)
+
+// UserRule overrides a built-in label rule, empty fields keep the built-in values.
+type UserRule struct {
+	See        string   `yaml:"see,omitempty" json:"see,omitempty"`
+	Label      *string  `yaml:"label,omitempty" json:"label,omitempty"`
+	Threshold  *float32 `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+	Priority   *int     `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Categories []string `yaml:"categories,omitempty" json:"categories,omitempty"`
+}
+
+// UserRules maps lowercase classifier label names to user rules.
+type UserRules map[string]UserRule
+
+var userRules = make(UserRules)
+var userRulesMutex = sync.RWMutex{}
+
+// LoadRules reads user rules from a YAML file in the same format as rules.yml, a missing file is not an error.
+func LoadRules(fileName string) error {
+	result := make(UserRules)
+
+	if fs.FileExists(fileName) {
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		if err := yaml.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("classify: invalid rules file %s (%s)", fileName, err)
+		}
+
+		for label := range result {
+			if label != strings.ToLower(label) {
+				return fmt.Errorf("classify: label must be lowercase: %s", label)
+			}
+		}
+
+		log.Infof("classify: loaded %d label rules from %s", len(result), fileName)
+	}
+
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
+
+	userRules = result
+
+	return nil
+}
+
+// SaveRules writes all user rules to a YAML file.
+func SaveRules(fileName string) error {
+	userRulesMutex.RLock()
+	data, err := yaml.Marshal(userRules)
+	userRulesMutex.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, data, 0644)
+}
+
+// Rules returns a copy of all user rules.
+func Rules() UserRules {
+	userRulesMutex.RLock()
+	defer userRulesMutex.RUnlock()
+
+	result := make(UserRules, len(userRules))
+
+	for label, rule := range userRules {
+		result[label] = rule
+	}
+
+	return result
+}
+
+// SetRule adds or replaces a user rule.
+func SetRule(label string, rule UserRule) error {
+	label = strings.ToLower(strings.TrimSpace(label))
+
+	if label == "" {
+		return fmt.Errorf("classify: empty label")
+	}
+
+	if rule.See != "" && strings.ToLower(rule.See) == label {
+		return fmt.Errorf("classify: %s can't refer to itself", label)
+	}
+
+	rule.See = strings.ToLower(strings.TrimSpace(rule.See))
+
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
+
+	userRules[label] = rule
+
+	return nil
+}
+
+// DeleteRule removes a user rule, so that the built-in rule applies again.
+func DeleteRule(label string) bool {
+	label = strings.ToLower(strings.TrimSpace(label))
+
+	userRulesMutex.Lock()
+	defer userRulesMutex.Unlock()
+
+	if _, ok := userRules[label]; !ok {
+		return false
+	}
+
+	delete(userRules, label)
+
+	return true
+}
+
+// FindRule returns the effective rule for a classifier label, user rules are merged over the built-in rules.
+func FindRule(label string) LabelRule {
+	return FindModelRule("", label)
+}
+
+// FindModelRule returns the effective rule for a label predicted by the named model.
+// Additional models start with the rules from their configuration instead of the built-in rules.
+func FindModelRule(model, label string) LabelRule {
+	userRulesMutex.RLock()
+	defer userRulesMutex.RUnlock()
+
+	if (Model{Name: model}).Builtin() {
+		return findRule(label, 0)
+	}
+
+	return findModelRule(model, label)
+}
+
+// findRule resolves "see" references up to a limited depth to prevent loops.
+func findRule(label string, depth int) LabelRule {
+	rule := rules.Find(label)
+
+	userRule, ok := userRules[label]
+
+	if !ok {
+		return rule
+	}
+
+	if userRule.See != "" && depth < 8 {
+		return findRule(userRule.See, depth+1)
+	}
+
+	return mergeRule(rule, userRule)
+}
+
+// findModelRule returns the rule for a label of an additional model, "see" refers to built-in labels.
+func findModelRule(model, label string) LabelRule {
+	modelRulesMutex.RLock()
+	modelRule := modelRules[model][label]
+	modelRulesMutex.RUnlock()
+
+	if modelRule.See != "" {
+		return findRule(modelRule.See, 0)
+	}
+
+	rule := mergeRule(LabelRule{Threshold: 0.1}, modelRule)
+
+	userRule, ok := userRules[label]
+
+	if !ok {
+		return rule
+	}
+
+	if userRule.See != "" {
+		return findRule(userRule.See, 0)
+	}
+
+	return mergeRule(rule, userRule)
+}
+
+// mergeRule returns a copy of rule with all values set in userRule replaced.
+func mergeRule(rule LabelRule, userRule UserRule) LabelRule {
+	if userRule.Label != nil {
+		rule.Label = *userRule.Label
+	}
+
+	if userRule.Threshold != nil {
+		rule.Threshold = *userRule.Threshold
+	}
+
+	if userRule.Priority != nil {
+		rule.Priority = *userRule.Priority
+	}
+
+	if userRule.Categories != nil {
+		rule.Categories = userRule.Categories
+	}
+
+	return rule
+}
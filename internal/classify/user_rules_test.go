@@ -0,0 +1,84 @@
+package classify
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRule(t *testing.T) {
+	defer LoadRules("")
+
+	t.Run("built-in rule", func(t *testing.T) {
+		result := FindRule("cat")
+		assert.Equal(t, "cat", result.Label)
+		assert.Equal(t, 5, result.Priority)
+	})
+	t.Run("override priority", func(t *testing.T) {
+		priority := 7
+		assert.Nil(t, SetRule("Cat", UserRule{Priority: &priority}))
+
+		result := FindRule("cat")
+		assert.Equal(t, "cat", result.Label)
+		assert.Equal(t, 7, result.Priority)
+		assert.Equal(t, "animal", result.Categories[0])
+	})
+	t.Run("see other rule", func(t *testing.T) {
+		assert.Nil(t, SetRule("kitten", UserRule{See: "cat"}))
+
+		result := FindRule("kitten")
+		assert.Equal(t, "cat", result.Label)
+		assert.Equal(t, 7, result.Priority)
+	})
+	t.Run("see loop", func(t *testing.T) {
+		assert.Error(t, SetRule("loop", UserRule{See: "loop"}))
+		assert.Nil(t, SetRule("foo", UserRule{See: "bar"}))
+		assert.Nil(t, SetRule("bar", UserRule{See: "foo"}))
+
+		result := FindRule("foo")
+		assert.Equal(t, float32(0.1), result.Threshold)
+	})
+	t.Run("delete rule", func(t *testing.T) {
+		assert.True(t, DeleteRule("cat"))
+		assert.False(t, DeleteRule("cat"))
+		assert.Equal(t, 5, FindRule("cat").Priority)
+	})
+}
+
+func TestLoadRules(t *testing.T) {
+	defer LoadRules("")
+
+	dir, err := ioutil.TempDir("", "rules")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "rules.yml")
+
+	t.Run("missing file", func(t *testing.T) {
+		assert.Nil(t, LoadRules(fileName))
+		assert.Empty(t, Rules())
+	})
+	t.Run("save and load", func(t *testing.T) {
+		threshold := float32(0.9)
+		assert.Nil(t, SetRule("tabby cat", UserRule{Threshold: &threshold, Categories: []string{"pet"}}))
+		assert.Nil(t, SaveRules(fileName))
+		assert.Nil(t, LoadRules(""))
+		assert.Empty(t, Rules())
+		assert.Nil(t, LoadRules(fileName))
+
+		result := FindRule("tabby cat")
+		assert.Equal(t, float32(0.9), result.Threshold)
+		assert.Equal(t, []string{"pet"}, result.Categories)
+	})
+	t.Run("uppercase label", func(t *testing.T) {
+		assert.Nil(t, ioutil.WriteFile(fileName, []byte("Cat:\n  priority: 1\n"), 0644))
+		assert.Error(t, LoadRules(fileName))
+	})
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/urfave/cli"
+)
+
+// Runs image classification again for selected photos
+var ClassifyCommand = cli.Command{
+	Name:      "classify",
+	Usage:     "Runs image classification again for selected photos",
+	ArgsUsage: "[search query]",
+	Flags:     classifyFlags,
+	Action:    classifyAction,
+}
+
+var classifyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "label, l",
+		Usage: "label `SLUG`",
+	},
+	cli.StringFlag{
+		Name:  "album, a",
+		Usage: "album `UUID`",
+	},
+	cli.IntFlag{
+		Name:  "camera, c",
+		Usage: "camera `ID`",
+	},
+	cli.StringFlag{
+		Name:  "after",
+		Usage: "taken after `DATE` (YYYY-MM-DD)",
+	},
+	cli.StringFlag{
+		Name:  "before",
+		Usage: "taken before `DATE` (YYYY-MM-DD)",
+	},
+}
+
+func classifyAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.MigrateDb()
+
+	loadRules(conf)
+
+	opt := form.ClassifyOptions{
+		Query:  strings.Join(ctx.Args(), " "),
+		Label:  ctx.String("label"),
+		Album:  ctx.String("album"),
+		Camera: ctx.Int("camera"),
+	}
+
+	if s := ctx.String("after"); s != "" {
+		after, err := time.Parse("2006-01-02", s)
+
+		if err != nil {
+			return err
+		}
+
+		opt.After = after
+	}
+
+	if s := ctx.String("before"); s != "" {
+		before, err := time.Parse("2006-01-02", s)
+
+		if err != nil {
+			return err
+		}
+
+		opt.Before = before
+	}
+
+	tf := classify.NewClassifiers(conf.ResourcesPath(), conf.ModelsFile(), conf.TensorFlowDisabled())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
+	ind := photoprism.NewIndex(conf, tf, nd, fd)
+
+	count, err := photoprism.NewClassify(conf, ind).Start(opt)
+
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("classified %d photos in %s", count, elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
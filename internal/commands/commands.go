@@ -11,6 +11,8 @@ import (
 	"os"
 	"syscall"
 
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/sevlyar/go-daemon"
@@ -18,6 +20,13 @@ import (
 
 var log = event.Log
 
+// loadRules loads the user label rules for commands that classify images.
+func loadRules(conf *config.Config) {
+	if err := classify.LoadRules(conf.RulesFile()); err != nil {
+		log.Error(err)
+	}
+}
+
 func childAlreadyRunning(filePath string) (pid int, running bool) {
 	if !fs.FileExists(filePath) {
 		return pid, false
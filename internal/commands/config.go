@@ -38,6 +38,8 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("pid-filename          %s\n", conf.PIDFilename())
 	fmt.Printf("config-file           %s\n", conf.ConfigFile())
 	fmt.Printf("config-path           %s\n", conf.ConfigPath())
+	fmt.Printf("rules-file            %s\n", conf.RulesFile())
+	fmt.Printf("models-file           %s\n", conf.ModelsFile())
 
 	fmt.Printf("database-driver       %s\n", conf.DatabaseDriver())
 	fmt.Printf("database-dsn          %s\n", conf.DatabaseDsn())
@@ -68,10 +70,17 @@ func configAction(ctx *cli.Context) error {
 
 	fmt.Printf("sips-bin              %s\n", conf.SipsBin())
 	fmt.Printf("darktable-bin         %s\n", conf.DarktableBin())
+	fmt.Printf("rawtherapee-bin       %s\n", conf.RawTherapeeBin())
+	fmt.Printf("imagemagick-bin       %s\n", conf.ImageMagickBin())
+	fmt.Printf("raw-preview           %s\n", conf.RawPreview())
+	fmt.Printf("convert-timeout       %s\n", conf.ConvertTimeout())
+	fmt.Printf("convert-retries       %d\n", conf.ConvertRetries())
 	fmt.Printf("exiftool-bin          %s\n", conf.ExifToolBin())
+	fmt.Printf("exiftool-timeout      %s\n", conf.ExifToolTimeout())
 	fmt.Printf("heifconvert-bin       %s\n", conf.HeifConvertBin())
 
 	fmt.Printf("detect-nsfw           %t\n", conf.DetectNSFW())
+	fmt.Printf("detect-faces          %t\n", conf.DetectFaces())
 	fmt.Printf("upload-nsfw           %t\n", conf.UploadNSFW())
 	fmt.Printf("geocoding-api         %s\n", conf.GeoCodingApi())
 	fmt.Printf("thumb-quality         %d\n", conf.ThumbQuality())
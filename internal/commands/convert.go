@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"context"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/urfave/cli"
 )
@@ -12,9 +16,17 @@ import (
 var ConvertCommand = cli.Command{
 	Name:   "convert",
 	Usage:  "Converts originals in other formats to JPEG",
+	Flags:  convertFlags,
 	Action: convertAction,
 }
 
+var convertFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "failed, f",
+		Usage: "retry failed conversions and index the new jpeg files",
+	},
+}
+
 func convertAction(ctx *cli.Context) error {
 	start := time.Now()
 
@@ -28,15 +40,57 @@ func convertAction(ctx *cli.Context) error {
 		return err
 	}
 
-	log.Infof("converting RAW images in %s to JPEG", conf.OriginalsPath())
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	conf.MigrateDb()
 
 	convert := photoprism.NewConvert(conf)
 
-	convert.Start(conf.OriginalsPath())
+	if ctx.Bool("failed") {
+		log.Infof("retrying failed conversions in %s", conf.OriginalsPath())
+
+		files, err := convert.RetryFailed()
+
+		if err != nil {
+			return err
+		}
+
+		loadRules(conf)
 
-	elapsed := time.Since(start)
+		tf := classify.NewClassifiers(conf.ResourcesPath(), conf.ModelsFile(), conf.TensorFlowDisabled())
+		nd := nsfw.New(conf.NSFWModelPath())
+		fd := face.New(conf.FacesModelPath())
+		ind := photoprism.NewIndex(conf, tf, nd, fd)
 
-	log.Infof("image conversion completed in %s", elapsed)
+		for _, f := range files {
+			jpg, err := f.Jpeg()
+
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+
+			res := ind.MediaFile(jpg, photoprism.IndexOptionsNone(), "")
+
+			log.Infof("convert: %s jpeg file \"%s\"", res, jpg.RelativeName(conf.OriginalsPath()))
+		}
+
+		log.Infof("converted %d files in %s", len(files), time.Since(start))
+	} else {
+		log.Infof("converting RAW images in %s to JPEG", conf.OriginalsPath())
+
+		convert.Start(conf.OriginalsPath())
+
+		elapsed := time.Since(start)
+
+		log.Infof("image conversion completed in %s", elapsed)
+	}
 
 	return nil
 }
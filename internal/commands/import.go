@@ -9,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/urfave/cli"
@@ -43,6 +44,8 @@ func importAction(ctx *cli.Context) error {
 
 	conf.MigrateDb()
 
+	loadRules(conf)
+
 	sourcePath := strings.TrimSpace(ctx.Args().First())
 
 	if sourcePath == "" {
@@ -63,10 +66,11 @@ func importAction(ctx *cli.Context) error {
 
 	log.Infof("moving media files from %s to %s", sourcePath, conf.OriginalsPath())
 
-	tensorFlow := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
+	tensorFlow := classify.NewClassifiers(conf.ResourcesPath(), conf.ModelsFile(), conf.TensorFlowDisabled())
 	nsfwDetector := nsfw.New(conf.NSFWModelPath())
+	faceDetector := face.New(conf.FacesModelPath())
 
-	ind := photoprism.NewIndex(conf, tensorFlow, nsfwDetector)
+	ind := photoprism.NewIndex(conf, tensorFlow, nsfwDetector, faceDetector)
 
 	convert := photoprism.NewConvert(conf)
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/urfave/cli"
@@ -42,15 +43,19 @@ func indexAction(ctx *cli.Context) error {
 	}
 
 	conf.MigrateDb()
+
+	loadRules(conf)
+
 	log.Infof("indexing photos in %s", conf.OriginalsPath())
 
 	if conf.ReadOnly() {
 		log.Infof("read-only mode enabled")
 	}
 
-	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
+	tf := classify.NewClassifiers(conf.ResourcesPath(), conf.ModelsFile(), conf.TensorFlowDisabled())
 	nd := nsfw.New(conf.NSFWModelPath())
-	ind := photoprism.NewIndex(conf, tf, nd)
+	fd := face.New(conf.FacesModelPath())
+	ind := photoprism.NewIndex(conf, tf, nd, fd)
 
 	var opt photoprism.IndexOptions
 
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/urfave/cli"
+)
+
+// Reapplies label rules to stored classification results
+var ReclassifyCommand = cli.Command{
+	Name:   "reclassify",
+	Usage:  "Reapplies label rules without running image classification again",
+	Action: reclassifyAction,
+}
+
+func reclassifyAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+
+	cctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(cctx); err != nil {
+		return err
+	}
+
+	conf.MigrateDb()
+
+	if err := classify.LoadRules(conf.RulesFile()); err != nil {
+		return err
+	}
+
+	log.Infof("applying label rules from \"%s\"", conf.RulesFile())
+
+	count, err := photoprism.NewReclassify(conf).Start()
+
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	elapsed := time.Since(start)
+
+	log.Infof("reclassified %d photos in %s", count, elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
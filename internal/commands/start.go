@@ -77,6 +77,8 @@ func startAction(ctx *cli.Context) error {
 
 	conf.MigrateDb()
 
+	loadRules(conf)
+
 	dctx := new(daemon.Context)
 	dctx.LogFileName = conf.LogFilename()
 	dctx.PidFileName = conf.PIDFilename()
@@ -147,6 +147,12 @@ func (c *Config) DetectNSFW() bool {
 	return c.config.DetectNSFW
 }
 
+// DetectFaces returns true if faces should be detected and clustered into people.
+// The face models run with TensorFlow, so this is always false if TensorFlow is disabled.
+func (c *Config) DetectFaces() bool {
+	return c.config.DetectFaces && !c.TensorFlowDisabled()
+}
+
 // UploadNSFW returns true if NSFW photos can be uploaded.
 func (c *Config) UploadNSFW() bool {
 	return c.config.UploadNSFW
@@ -263,6 +269,47 @@ func (c *Config) ThumbFilter() thumb.ResampleFilter {
 	}
 }
 
+// RawPreview returns when JPEG previews embedded in RAW files are used instead of a RAW converter:
+// as fallback if no converter is installed, in preference to a converter, or never (fallback, prefer or none).
+func (c *Config) RawPreview() string {
+	switch c.config.RawPreview {
+	case "prefer":
+		return "prefer"
+	case "none":
+		return "none"
+	}
+	return "fallback"
+}
+
+// ConvertTimeout returns the time after which converter commands are killed, 10 minutes by default.
+func (c *Config) ConvertTimeout() time.Duration {
+	if c.config.ConvertTimeout <= 0 {
+		return 10 * time.Minute
+	}
+
+	return time.Duration(c.config.ConvertTimeout) * time.Second
+}
+
+// ConvertRetries returns how often a failed converter command is retried (0-10).
+func (c *Config) ConvertRetries() int {
+	if c.config.ConvertRetries < 0 {
+		return 0
+	} else if c.config.ConvertRetries > 10 {
+		return 10
+	}
+
+	return c.config.ConvertRetries
+}
+
+// ExifToolTimeout returns the time after which exiftool is killed, 1 minute by default.
+func (c *Config) ExifToolTimeout() time.Duration {
+	if c.config.ExifToolTimeout <= 0 {
+		return time.Minute
+	}
+
+	return time.Duration(c.config.ExifToolTimeout) * time.Second
+}
+
 // GeoCodingApi returns the preferred geo coding api (none, osm or places).
 func (c *Config) GeoCodingApi() string {
 	switch c.config.GeoCodingApi {
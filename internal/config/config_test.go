@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
@@ -210,6 +211,87 @@ func TestConfig_DarktableBin(t *testing.T) {
 	assert.Equal(t, "/usr/bin/darktable-cli", bin)
 }
 
+func TestConfig_RawPreview(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, "fallback", c.RawPreview())
+
+	c.config.RawPreview = "prefer"
+	assert.Equal(t, "prefer", c.RawPreview())
+
+	c.config.RawPreview = "none"
+	assert.Equal(t, "none", c.RawPreview())
+}
+
+func TestConfig_DetectFaces(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	c.config.DetectFaces = true
+	c.config.DisableTensorFlow = false
+	assert.True(t, c.DetectFaces())
+
+	c.config.DisableTensorFlow = true
+	assert.False(t, c.DetectFaces())
+}
+
+func TestConfig_RulesFile(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, c.ConfigPath()+"/rules.yml", c.RulesFile())
+
+	c.config.RulesFile = "/tmp/rules.yml"
+	assert.Equal(t, "/tmp/rules.yml", c.RulesFile())
+}
+
+func TestConfig_ModelsFile(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, c.ConfigPath()+"/models.yml", c.ModelsFile())
+
+	c.config.ModelsFile = "/tmp/models.yml"
+	assert.Equal(t, "/tmp/models.yml", c.ModelsFile())
+}
+
+func TestConfig_ConvertTimeout(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 10*time.Minute, c.ConvertTimeout())
+
+	c.config.ConvertTimeout = 30
+	assert.Equal(t, 30*time.Second, c.ConvertTimeout())
+}
+
+func TestConfig_ExifToolTimeout(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, time.Minute, c.ExifToolTimeout())
+
+	c.config.ExifToolTimeout = 5
+	assert.Equal(t, 5*time.Second, c.ExifToolTimeout())
+}
+
+func TestConfig_ConvertRetries(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Equal(t, 0, c.ConvertRetries())
+
+	c.config.ConvertRetries = 3
+	assert.Equal(t, 3, c.ConvertRetries())
+
+	c.config.ConvertRetries = 100
+	assert.Equal(t, 10, c.ConvertRetries())
+
+	c.config.ConvertRetries = -1
+	assert.Equal(t, 0, c.ConvertRetries())
+}
+
 func TestConfig_HeifConvertBin(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -218,6 +300,33 @@ func TestConfig_HeifConvertBin(t *testing.T) {
 	assert.Equal(t, "/usr/bin/heif-convert", bin)
 }
 
+func TestConfig_Converters(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	assert.Empty(t, c.Converters())
+
+	c.config.Converters = []ConverterParams{{Name: "sh", Types: []string{"raw"}, Args: []string{"{src}"}}}
+
+	result := c.Converters()
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "sh", result[0].Name)
+		assert.NotEmpty(t, result[0].Bin)
+	}
+}
+
+func TestConfig_ImageMagickBin(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	c.config.ImageMagickBin = "sh"
+	assert.True(t, strings.HasSuffix(c.ImageMagickBin(), "sh"))
+
+	c.config.ImageMagickBin = "imagemagick-not-installed"
+	assert.Equal(t, "", c.ImageMagickBin())
+}
+
 func TestConfig_ExifToolBin(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -297,6 +406,14 @@ func TestConfig_NSFWModelPath(t *testing.T) {
 	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/resources/nsfw", result)
 }
 
+func TestConfig_FacesModelPath(t *testing.T) {
+	ctx := CliTestContext()
+	c := NewConfig(ctx)
+
+	result := c.FacesModelPath()
+	assert.Equal(t, "/go/src/github.com/photoprism/photoprism/assets/resources/faces", result)
+}
+
 func TestConfig_ExamplesPath(t *testing.T) {
 	ctx := CliTestContext()
 	c := NewConfig(ctx)
@@ -0,0 +1,32 @@
+package config
+
+// ConverterParams describes an external command that converts images to JPEG, custom converters
+// can be defined in the config file and replace built-in converters with the same name:
+//
+//	converters:
+//	  - name: darktable
+//	    bin: /opt/darktable/bin/darktable-cli
+//	    types: [raw]
+//	    args: ["{src}", "{xmp}", "{dest}", "--hq", "true"]
+//	    priority: 100
+//	    limit: 1
+//	    timeout: 300
+type ConverterParams struct {
+	Name     string   `yaml:"name"`
+	Bin      string   `yaml:"bin"`
+	Types    []string `yaml:"types"`    // File types, e.g. raw or heif
+	Args     []string `yaml:"args"`     // "{src}", "{dest}" and "{xmp}" are replaced with file names, "{xmp}" is omitted if there is none
+	Priority int      `yaml:"priority"` // Converters with a higher priority are used first
+	Limit    int      `yaml:"limit"`    // Maximum number of commands running at the same time, 0 means unlimited
+	Timeout  int      `yaml:"timeout"`  // Timeout in seconds, 0 means the default
+}
+
+// Converters returns the custom converters defined in the config file, the binary is empty if it is not installed.
+func (c *Config) Converters() (result []ConverterParams) {
+	for _, conv := range c.config.Converters {
+		conv.Bin = findExecutable(conv.Bin, conv.Name)
+		result = append(result, conv)
+	}
+
+	return result
+}
@@ -74,13 +74,20 @@ func (c *Config) MigrateDb() {
 		&entity.PhotoAlbum{},
 		&entity.Label{},
 		&entity.Category{},
+		&entity.LabelAlias{},
 		&entity.PhotoLabel{},
+		&entity.Classification{},
+		&entity.Face{},
+		&entity.Person{},
 		&entity.Keyword{},
 		&entity.PhotoKeyword{},
+		&entity.PhotoTerm{},
 	)
 
 	entity.CreateUnknownPlace(db)
 	entity.CreateUnknownCountry(db)
+	entity.MigrateTakenSrc(db)
+	entity.MigrateTerms(db)
 }
 
 // connectToDatabase establishes a database connection.
@@ -168,9 +175,14 @@ func (c *Config) DropTables() {
 		&entity.PhotoAlbum{},
 		&entity.Label{},
 		&entity.Category{},
+		&entity.LabelAlias{},
 		&entity.PhotoLabel{},
+		&entity.Classification{},
+		&entity.Face{},
+		&entity.Person{},
 		&entity.Keyword{},
 		&entity.PhotoKeyword{},
+		&entity.PhotoTerm{},
 	)
 }
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -94,6 +95,24 @@ func (c *Config) SettingsFile() string {
 	return c.ConfigPath() + "/settings.yml"
 }
 
+// RulesFile returns the file name of user-defined label rules.
+func (c *Config) RulesFile() string {
+	if c.config.RulesFile != "" {
+		return c.config.RulesFile
+	}
+
+	return c.ConfigPath() + "/rules.yml"
+}
+
+// ModelsFile returns the file name of additional image classification models.
+func (c *Config) ModelsFile() string {
+	if c.config.ModelsFile != "" {
+		return c.config.ModelsFile
+	}
+
+	return c.ConfigPath() + "/models.yml"
+}
+
 // ConfigPath returns the config path.
 func (c *Config) ConfigPath() string {
 	if c.config.ConfigPath == "" {
@@ -146,6 +165,25 @@ func (c *Config) DarktableBin() string {
 	return findExecutable(c.config.DarktableBin, "darktable-cli")
 }
 
+// RawTherapeeBin returns the rawtherapee-cli binary file name.
+func (c *Config) RawTherapeeBin() string {
+	return findExecutable(c.config.RawTherapeeBin, "rawtherapee-cli")
+}
+
+// ImageMagickBin returns the ImageMagick binary file name. "magick" is preferred, as "convert" is
+// deprecated by ImageMagick 7 and a different command on Windows.
+func (c *Config) ImageMagickBin() string {
+	if c.config.ImageMagickBin != "" {
+		return findExecutable(c.config.ImageMagickBin, "")
+	}
+
+	if bin := findExecutable("", "magick"); bin != "" || runtime.GOOS == "windows" {
+		return bin
+	}
+
+	return findExecutable("", "convert")
+}
+
 // HeifConvertBin returns the heif-convert binary file name.
 func (c *Config) HeifConvertBin() string {
 	return findExecutable(c.config.HeifConvertBin, "heif-convert")
@@ -190,6 +228,11 @@ func (c *Config) TensorFlowModelPath() string {
 	return c.ResourcesPath() + "/nasnet"
 }
 
+// FacesModelPath returns the face detection tensorflow model path.
+func (c *Config) FacesModelPath() string {
+	return c.ResourcesPath() + "/faces"
+}
+
 // NSFWModelPath returns the NSFW tensorflow model path.
 func (c *Config) NSFWModelPath() string {
 	return c.ResourcesPath() + "/nsfw"
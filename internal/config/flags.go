@@ -103,6 +103,16 @@ var GlobalFlags = []cli.Flag{
 		Value:  "~/.config/photoprism",
 		EnvVar: "PHOTOPRISM_CONFIG_PATH",
 	},
+	cli.StringFlag{
+		Name:   "rules-file",
+		Usage:  "user label rules `FILENAME`, rules.yml in the config path by default",
+		EnvVar: "PHOTOPRISM_RULES_FILE",
+	},
+	cli.StringFlag{
+		Name:   "models-file",
+		Usage:  "image classification models `FILENAME`, models.yml in the config path by default",
+		EnvVar: "PHOTOPRISM_MODELS_FILE",
+	},
 	cli.StringFlag{
 		Name:   "resources-path",
 		Usage:  "resources `PATH`",
@@ -162,12 +172,46 @@ var GlobalFlags = []cli.Flag{
 		Value:  "darktable-cli",
 		EnvVar: "PHOTOPRISM_DARKTABLE_BIN",
 	},
+	cli.StringFlag{
+		Name:   "rawtherapee-bin",
+		Usage:  "rawtherapee cli binary `FILENAME`",
+		Value:  "rawtherapee-cli",
+		EnvVar: "PHOTOPRISM_RAWTHERAPEE_BIN",
+	},
+	cli.StringFlag{
+		Name:   "imagemagick-bin",
+		Usage:  "imagemagick binary `FILENAME`, magick or convert by default",
+		EnvVar: "PHOTOPRISM_IMAGEMAGICK_BIN",
+	},
+	cli.StringFlag{
+		Name:   "raw-preview",
+		Usage:  "use jpeg previews embedded in raw files (fallback, prefer or none)",
+		Value:  "fallback",
+		EnvVar: "PHOTOPRISM_RAW_PREVIEW",
+	},
+	cli.IntFlag{
+		Name:   "convert-timeout",
+		Usage:  "converter command timeout in seconds",
+		Value:  600,
+		EnvVar: "PHOTOPRISM_CONVERT_TIMEOUT",
+	},
+	cli.IntFlag{
+		Name:   "convert-retries",
+		Usage:  "number of retries if a converter command fails (0-10)",
+		EnvVar: "PHOTOPRISM_CONVERT_RETRIES",
+	},
 	cli.StringFlag{
 		Name:   "exiftool-bin",
 		Usage:  "exiftool cli binary `FILENAME`",
 		Value:  "exiftool",
 		EnvVar: "PHOTOPRISM_EXIFTOOL_BIN",
 	},
+	cli.IntFlag{
+		Name:   "exiftool-timeout",
+		Usage:  "exiftool command timeout in seconds",
+		Value:  60,
+		EnvVar: "PHOTOPRISM_EXIFTOOL_TIMEOUT",
+	},
 	cli.StringFlag{
 		Name:   "heifconvert-bin",
 		Usage:  "heif conversion cli binary `FILENAME`",
@@ -214,6 +258,11 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "flag photos that may be offensive",
 		EnvVar: "PHOTOPRISM_DETECT_NSFW",
 	},
+	cli.BoolFlag{
+		Name:   "detect-faces",
+		Usage:  "detect faces and cluster them into people, requires TensorFlow and the face models in resources/faces",
+		EnvVar: "PHOTOPRISM_DETECT_FACES",
+	},
 	cli.BoolFlag{
 		Name:   "upload-nsfw",
 		Usage:  "allow uploads that may contain offensive content",
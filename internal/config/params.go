@@ -45,6 +45,8 @@ type Params struct {
 	LogLevel           string `yaml:"log-level" flag:"log-level"`
 	ConfigFile         string
 	ConfigPath         string `yaml:"config-path" flag:"config-path"`
+	RulesFile          string `yaml:"rules-file" flag:"rules-file"`
+	ModelsFile         string `yaml:"models-file" flag:"models-file"`
 	AssetsPath         string `yaml:"assets-path" flag:"assets-path"`
 	ResourcesPath      string `yaml:"resources-path" flag:"resources-path"`
 	CachePath          string `yaml:"cache-path" flag:"cache-path"`
@@ -63,19 +65,29 @@ type Params struct {
 	DatabaseDsn        string `yaml:"database-dsn" flag:"database-dsn"`
 	SipsBin            string `yaml:"sips-bin" flag:"sips-bin"`
 	DarktableBin       string `yaml:"darktable-bin" flag:"darktable-bin"`
+	RawPreview         string `yaml:"raw-preview" flag:"raw-preview"`
+	RawTherapeeBin     string `yaml:"rawtherapee-bin" flag:"rawtherapee-bin"`
+	ImageMagickBin     string `yaml:"imagemagick-bin" flag:"imagemagick-bin"`
+	ConvertTimeout     int    `yaml:"convert-timeout" flag:"convert-timeout"`
+	ConvertRetries     int    `yaml:"convert-retries" flag:"convert-retries"`
 	ExifToolBin        string `yaml:"exiftool-bin" flag:"exiftool-bin"`
+	ExifToolTimeout    int    `yaml:"exiftool-timeout" flag:"exiftool-timeout"`
 	HeifConvertBin     string `yaml:"heifconvert-bin" flag:"heifconvert-bin"`
 	PIDFilename        string `yaml:"pid-filename" flag:"pid-filename"`
 	LogFilename        string `yaml:"log-filename" flag:"log-filename"`
 	DetachServer       bool   `yaml:"detach-server" flag:"detach-server"`
 	DetectNSFW         bool   `yaml:"detect-nsfw" flag:"detect-nsfw"`
 	UploadNSFW         bool   `yaml:"upload-nsfw" flag:"upload-nsfw"`
+	DetectFaces        bool   `yaml:"detect-faces" flag:"detect-faces"`
 	DisableTensorFlow  bool   `yaml:"tf-disabled" flag:"tf-disabled"`
 	GeoCodingApi       string `yaml:"geocoding-api" flag:"geocoding-api"`
 	ThumbQuality       int    `yaml:"thumb-quality" flag:"thumb-quality"`
 	ThumbSize          int    `yaml:"thumb-size" flag:"thumb-size"`
 	ThumbLimit         int    `yaml:"thumb-limit" flag:"thumb-limit"`
 	ThumbFilter        string `yaml:"thumb-filter" flag:"thumb-filter"`
+
+	// Custom converters can only be defined in the config file.
+	Converters []ConverterParams `yaml:"converters"`
 }
 
 // NewParams() creates a new configuration entity by using two methods:
@@ -105,6 +117,8 @@ func NewParams(ctx *cli.Context) *Params {
 
 func (c *Params) expandFilenames() {
 	c.ConfigPath = fs.Abs(c.ConfigPath)
+	c.RulesFile = fs.Abs(c.RulesFile)
+	c.ModelsFile = fs.Abs(c.ModelsFile)
 	c.ResourcesPath = fs.Abs(c.ResourcesPath)
 	c.AssetsPath = fs.Abs(c.AssetsPath)
 	c.CachePath = fs.Abs(c.CachePath)
@@ -45,6 +45,7 @@ func NewTestParams() *Params {
 		OriginalsPath:  testDataPath + "/originals",
 		ImportPath:     testDataPath + "/import",
 		ExportPath:     testDataPath + "/export",
+		RulesFile:      testDataPath + "/rules.yml",
 		DatabaseDriver: "mysql",
 		DatabaseDsn:    "photoprism:photoprism@tcp(photoprism-db:4001)/photoprism?parseTime=true",
 	}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"database/sql"
+	"fmt"
 	"strings"
 	"time"
 
@@ -10,10 +11,23 @@ import (
 	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
+// Album sort orders
+const (
+	AlbumOrderOldest = "oldest"
+	AlbumOrderNewest = "newest"
+	AlbumOrderTitle  = "title"
+	AlbumOrderAdded  = "added"
+	AlbumOrderManual = "manual"
+)
+
+// AlbumOrders contains all valid album sort orders.
+var AlbumOrders = []string{AlbumOrderOldest, AlbumOrderNewest, AlbumOrderTitle, AlbumOrderAdded, AlbumOrderManual}
+
 // Photo album
 type Album struct {
 	ID               uint   `gorm:"primary_key"`
 	CoverUUID        string `gorm:"type:varbinary(36);"`
+	ParentUUID       string `gorm:"type:varbinary(36);index;"`
 	AlbumUUID        string `gorm:"type:varbinary(36);unique_index;"`
 	AlbumSlug        string `gorm:"type:varbinary(128);index;"`
 	AlbumName        string `gorm:"type:varchar(128);"`
@@ -62,3 +76,22 @@ func (m *Album) Rename(albumName string) {
 	m.AlbumName = strings.TrimSpace(albumName)
 	m.AlbumSlug = slug.Make(m.AlbumName)
 }
+
+// SetOrder changes the album sort order, an empty string resets it to the default.
+func (m *Album) SetOrder(order string) error {
+	order = strings.ToLower(strings.TrimSpace(order))
+
+	if order == "" {
+		m.AlbumOrder = ""
+		return nil
+	}
+
+	for _, o := range AlbumOrders {
+		if o == order {
+			m.AlbumOrder = order
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid album order: %s", order)
+}
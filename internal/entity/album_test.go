@@ -44,3 +44,22 @@ func TestRename(t *testing.T) {
 		assert.Equal(t, "january-0001", album.AlbumSlug)
 	})
 }
+
+func TestAlbum_SetOrder(t *testing.T) {
+	t.Run("valid order", func(t *testing.T) {
+		album := NewAlbum("Order")
+		assert.Nil(t, album.SetOrder("Manual"))
+		assert.Equal(t, AlbumOrderManual, album.AlbumOrder)
+	})
+	t.Run("empty order", func(t *testing.T) {
+		album := NewAlbum("Order")
+		album.AlbumOrder = AlbumOrderTitle
+		assert.Nil(t, album.SetOrder(""))
+		assert.Equal(t, "", album.AlbumOrder)
+	})
+	t.Run("invalid order", func(t *testing.T) {
+		album := NewAlbum("Order")
+		assert.Error(t, album.SetOrder("random"))
+		assert.Equal(t, "", album.AlbumOrder)
+	})
+}
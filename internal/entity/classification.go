@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// Raw image classifier results of a photo, so that label rules can be reapplied without running inference again
+type Classification struct {
+	PhotoID     uint   `gorm:"primary_key;auto_increment:false"`
+	Predictions string `gorm:"type:text;"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (Classification) TableName() string {
+	return "photos_classifications"
+}
+
+func NewClassification(photoID uint, predictions string) *Classification {
+	result := &Classification{
+		PhotoID:     photoID,
+		Predictions: predictions,
+	}
+
+	return result
+}
+
+// Save creates or updates the classifier results of a photo.
+func (m *Classification) Save(db *gorm.DB) error {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	return db.Save(m).Error
+}
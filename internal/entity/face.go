@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// Faces found in an image file, box coordinates are relative to the image size
+type Face struct {
+	ID        uint    `gorm:"primary_key"`
+	FileID    uint    `gorm:"index;"`
+	PersonID  uint    `gorm:"index;"`
+	FaceX     float32 `gorm:"type:FLOAT;"`
+	FaceY     float32 `gorm:"type:FLOAT;"`
+	FaceW     float32 `gorm:"type:FLOAT;"`
+	FaceH     float32 `gorm:"type:FLOAT;"`
+	FaceScore float32 `gorm:"type:FLOAT;"`
+	Embedding string  `gorm:"type:text;"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (Face) TableName() string {
+	return "files_faces"
+}
+
+func NewFace(fileID uint, x, y, w, h, score float32, embedding string) *Face {
+	result := &Face{
+		FileID:    fileID,
+		FaceX:     x,
+		FaceY:     y,
+		FaceW:     w,
+		FaceH:     h,
+		FaceScore: score,
+		Embedding: embedding,
+	}
+
+	return result
+}
+
+// Save creates or updates the face.
+func (m *Face) Save(db *gorm.DB) error {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	return db.Save(m).Error
+}
+
+// DeleteFileFaces removes all faces of a file and returns the affected person ids.
+func DeleteFileFaces(db *gorm.DB, fileID uint) (personIDs []uint, err error) {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	if err := db.Model(&Face{}).Where("file_id = ? AND person_id > 0", fileID).Pluck("DISTINCT person_id", &personIDs).Error; err != nil {
+		return personIDs, err
+	}
+
+	return personIDs, db.Where("file_id = ?", fileID).Delete(&Face{}).Error
+}
@@ -17,6 +17,8 @@ type File struct {
 	PhotoID         uint   `gorm:"index;"`
 	PhotoUUID       string `gorm:"type:varbinary(36);index;"`
 	FileUUID        string `gorm:"type:varbinary(36);unique_index;"`
+	FileUniqueID    string `gorm:"type:varbinary(64);index;"`
+	FileBurstID     string `gorm:"type:varbinary(64);index;"`
 	FileName        string `gorm:"type:varbinary(600);unique_index"`
 	OriginalName    string `gorm:"type:varbinary(600);"`
 	FileHash        string `gorm:"type:varbinary(128);index"`
@@ -24,7 +26,10 @@ type File struct {
 	FileSize        int64
 	FileType        string `gorm:"type:varbinary(32)"`
 	FileMime        string `gorm:"type:varbinary(64)"`
+	FileSoftware    string `gorm:"type:varbinary(128)"`
+	FileAdjustments string `gorm:"type:varbinary(255)"`
 	FilePrimary     bool
+	FileEdited      bool
 	FileSidecar     bool
 	FileVideo       bool
 	FileMissing     bool
@@ -38,6 +43,7 @@ type File struct {
 	FileColors      string `gorm:"type:binary(9);"`
 	FileLuminance   string `gorm:"type:binary(9);"`
 	FileChroma      uint
+	FileSharpness   uint
 	FileNotes       string `gorm:"type:text"`
 	FileError       string `gorm:"type:varbinary(512)"`
 	CreatedAt       time.Time
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 type Label struct {
 	ID               uint   `gorm:"primary_key"`
 	LabelUUID        string `gorm:"type:varbinary(36);unique_index;"`
+	CoverUUID        string `gorm:"type:varbinary(36);"`
 	LabelSlug        string `gorm:"type:varbinary(128);index;"`
 	LabelName        string `gorm:"type:varchar(128);"`
 	LabelPriority    int
@@ -59,6 +61,12 @@ func (m *Label) FirstOrCreate(db *gorm.DB) *Label {
 	mutex.Db.Lock()
 	defer mutex.Db.Unlock()
 
+	// Use the label that was merged with this one, if any
+	if label, err := FindLabelAlias(db, m.LabelSlug); err == nil {
+		*m = label
+		return m
+	}
+
 	if err := db.FirstOrCreate(m, "label_slug = ?", m.LabelSlug).Error; err != nil {
 		log.Errorf("label: %s", err)
 	}
@@ -79,3 +87,132 @@ func (m *Label) Rename(name string) {
 
 	m.LabelName = name
 }
+
+// MergeInto moves all photos, categories and aliases to the target label and deletes this label.
+// Photos that already have both labels keep the lowest uncertainty.
+func (m *Label) MergeInto(db *gorm.DB, target *Label) error {
+	if m.ID == target.ID {
+		return errors.New("label: can't merge label with itself")
+	}
+
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	tx := db.Begin()
+
+	// Read photo labels in the transaction, so that labels added while merging aren't lost.
+	var photoLabels []PhotoLabel
+
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("label_id = ?", m.ID).Find(&photoLabels).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, pl := range photoLabels {
+		var existing PhotoLabel
+
+		if err := tx.Where("photo_id = ? AND label_id = ?", pl.PhotoID, target.ID).First(&existing).Error; err != nil {
+			// Photo doesn't have the target label yet
+			if err := tx.Model(&PhotoLabel{}).Where("photo_id = ? AND label_id = ?", pl.PhotoID, m.ID).UpdateColumn("label_id", target.ID).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			continue
+		}
+
+		if pl.LabelUncertainty < existing.LabelUncertainty {
+			if err := tx.Model(&PhotoLabel{}).Where("photo_id = ? AND label_id = ?", pl.PhotoID, target.ID).
+				Updates(map[string]interface{}{"label_uncertainty": pl.LabelUncertainty, "label_source": pl.LabelSource}).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		if err := tx.Where("photo_id = ? AND label_id = ?", pl.PhotoID, m.ID).Delete(&PhotoLabel{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Keep categories and category members of the merged label
+	var categories []Category
+
+	if err := tx.Where("label_id = ? OR category_id = ?", m.ID, m.ID).Find(&categories).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, c := range categories {
+		moved := Category{LabelID: c.LabelID, CategoryID: c.CategoryID}
+
+		if moved.LabelID == m.ID {
+			moved.LabelID = target.ID
+		}
+
+		if moved.CategoryID == m.ID {
+			moved.CategoryID = target.ID
+		}
+
+		if moved.LabelID != moved.CategoryID {
+			if err := tx.FirstOrCreate(&moved, "label_id = ? AND category_id = ?", moved.LabelID, moved.CategoryID).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	if err := tx.Where("label_id = ? OR category_id = ?", m.ID, m.ID).Delete(&Category{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Future classifier output for this label and its aliases maps to the target
+	if err := tx.Model(&LabelAlias{}).Where("label_id = ?", m.ID).UpdateColumn("label_id", target.ID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Where(LabelAlias{AliasSlug: m.LabelSlug}).Assign(LabelAlias{LabelID: target.ID}).FirstOrCreate(&LabelAlias{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if target.CoverUUID == "" && m.CoverUUID != "" {
+		target.CoverUUID = m.CoverUUID
+
+		if err := tx.Model(target).UpdateColumn("cover_uuid", m.CoverUUID).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Delete(m).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// AddCategory adds a category to the label.
+func (m *Label) AddCategory(db *gorm.DB, category *Label) error {
+	if m.ID == category.ID {
+		return errors.New("label: can't be its own category")
+	}
+
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	c := Category{LabelID: m.ID, CategoryID: category.ID}
+
+	return db.FirstOrCreate(&c, "label_id = ? AND category_id = ?", c.LabelID, c.CategoryID).Error
+}
+
+// RemoveCategory removes a category from the label.
+func (m *Label) RemoveCategory(db *gorm.DB, category *Label) error {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	return db.Where("label_id = ? AND category_id = ?", m.ID, category.ID).Delete(&Category{}).Error
+}
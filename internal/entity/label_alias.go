@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// Label aliases map the slug of a merged label to the label it was merged into,
+// so that future classifier output uses the remaining label
+type LabelAlias struct {
+	ID        uint   `gorm:"primary_key"`
+	AliasSlug string `gorm:"type:varbinary(128);unique_index;"`
+	LabelID   uint   `gorm:"index;"`
+	Label     *Label
+	CreatedAt time.Time
+}
+
+func (LabelAlias) TableName() string {
+	return "labels_aliases"
+}
+
+func NewLabelAlias(aliasName string, labelID uint) *LabelAlias {
+	result := &LabelAlias{
+		AliasSlug: slug.Make(aliasName),
+		LabelID:   labelID,
+	}
+
+	return result
+}
+
+// Save creates the alias or points an existing alias to the new label.
+func (m *LabelAlias) Save(db *gorm.DB) error {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	return db.Where(LabelAlias{AliasSlug: m.AliasSlug}).Assign(LabelAlias{LabelID: m.LabelID}).FirstOrCreate(m).Error
+}
+
+// FindLabelAlias returns the label an alias slug points to.
+func FindLabelAlias(db *gorm.DB, aliasSlug string) (label Label, err error) {
+	err = db.Joins("JOIN labels_aliases ON labels_aliases.label_id = labels.id").
+		Where("labels_aliases.alias_slug = ?", aliasSlug).
+		First(&label).Error
+
+	return label, err
+}
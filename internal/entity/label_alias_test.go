@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabelAlias(t *testing.T) {
+	alias := NewLabelAlias("Kitten Cat", 5)
+
+	assert.Equal(t, "kitten-cat", alias.AliasSlug)
+	assert.Equal(t, uint(5), alias.LabelID)
+}
+
+func TestLabelAlias_TableName(t *testing.T) {
+	assert.Equal(t, "labels_aliases", LabelAlias{}.TableName())
+}
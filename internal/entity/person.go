@@ -0,0 +1,204 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// People are clusters of similar faces, they remain unnamed until a user names them
+type Person struct {
+	ID         uint   `gorm:"primary_key"`
+	PersonUUID string `gorm:"type:varbinary(36);unique_index;"`
+	PersonSlug string `gorm:"type:varbinary(128);index;"`
+	PersonName string `gorm:"type:varchar(128);"`
+	FaceCount  int
+	Embedding  string `gorm:"type:text;"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  *time.Time `sql:"index"`
+}
+
+func (Person) TableName() string {
+	return "people"
+}
+
+func (m *Person) BeforeCreate(scope *gorm.Scope) error {
+	if err := scope.SetColumn("PersonUUID", rnd.PPID('h')); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewPerson(embedding string) *Person {
+	result := &Person{
+		FaceCount: 1,
+		Embedding: embedding,
+	}
+
+	return result
+}
+
+// Rename changes the person name, an empty name makes the person unnamed again.
+func (m *Person) Rename(name string) {
+	name = txt.Clip(strings.TrimSpace(name), 128)
+
+	m.PersonName = name
+	m.PersonSlug = slug.Make(name)
+}
+
+// Refresh updates the face count and the mean embedding of all faces assigned to the person.
+func (m *Person) Refresh(db *gorm.DB) error {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	return m.refresh(db)
+}
+
+// refresh updates the person like Refresh, the caller must hold the database mutex.
+func (m *Person) refresh(db *gorm.DB) error {
+	var faces []Face
+
+	if err := db.Where("person_id = ?", m.ID).Find(&faces).Error; err != nil {
+		return err
+	}
+
+	var embeddings [][]float32
+
+	for _, f := range faces {
+		var e []float32
+
+		if err := json.Unmarshal([]byte(f.Embedding), &e); err != nil || len(e) == 0 {
+			continue
+		}
+
+		if len(embeddings) > 0 && len(e) != len(embeddings[0]) {
+			continue
+		}
+
+		embeddings = append(embeddings, e)
+	}
+
+	m.FaceCount = len(faces)
+	m.Embedding = ""
+
+	if len(embeddings) > 0 {
+		mean := make([]float32, len(embeddings[0]))
+
+		for _, e := range embeddings {
+			for i, v := range e {
+				mean[i] += v / float32(len(embeddings))
+			}
+		}
+
+		if data, err := json.Marshal(mean); err == nil {
+			m.Embedding = string(data)
+		}
+	}
+
+	if m.FaceCount == 0 {
+		return db.Delete(m).Error
+	}
+
+	return db.Model(m).Updates(map[string]interface{}{"face_count": m.FaceCount, "embedding": m.Embedding}).Error
+}
+
+// MergeInto assigns all faces to the target person and deletes this person.
+func (m *Person) MergeInto(db *gorm.DB, target *Person) error {
+	if m.ID == target.ID {
+		return errors.New("person: can't merge person with itself")
+	}
+
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	tx := db.Begin()
+
+	if err := tx.Model(&Face{}).Where("person_id = ?", m.ID).UpdateColumn("person_id", target.ID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if target.PersonName == "" && m.PersonName != "" {
+		target.Rename(m.PersonName)
+
+		if err := tx.Model(target).Updates(map[string]interface{}{"person_name": target.PersonName, "person_slug": target.PersonSlug}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := target.refresh(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := m.refresh(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Split moves the given faces to a new unnamed person and returns it.
+func (m *Person) Split(db *gorm.DB, faceIDs []uint) (*Person, error) {
+	if len(faceIDs) == 0 {
+		return nil, errors.New("person: no faces selected")
+	}
+
+	var count int
+
+	if err := db.Model(&Face{}).Where("person_id = ? AND id IN (?)", m.ID, faceIDs).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, errors.New("person: faces not found")
+	}
+
+	if count == m.FaceCount {
+		return nil, errors.New("person: can't split all faces")
+	}
+
+	result := NewPerson("")
+
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	tx := db.Begin()
+
+	if err := tx.Create(result).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Model(&Face{}).Where("person_id = ? AND id IN (?)", m.ID, faceIDs).UpdateColumn("person_id", result.ID).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := result.refresh(tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := m.refresh(tx); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPerson(t *testing.T) {
+	person := NewPerson("[0.5,0.25]")
+
+	assert.Equal(t, 1, person.FaceCount)
+	assert.Equal(t, "[0.5,0.25]", person.Embedding)
+	assert.Equal(t, "", person.PersonName)
+}
+
+func TestPerson_Rename(t *testing.T) {
+	t.Run("name", func(t *testing.T) {
+		person := NewPerson("")
+		person.Rename(" Jens Mander ")
+
+		assert.Equal(t, "Jens Mander", person.PersonName)
+		assert.Equal(t, "jens-mander", person.PersonSlug)
+	})
+	t.Run("unnamed", func(t *testing.T) {
+		person := NewPerson("")
+		person.Rename("Jens Mander")
+		person.Rename("")
+
+		assert.Equal(t, "", person.PersonName)
+		assert.Equal(t, "", person.PersonSlug)
+	})
+}
+
+func TestPerson_TableName(t *testing.T) {
+	assert.Equal(t, "people", Person{}.TableName())
+}
+
+func TestNewFace(t *testing.T) {
+	f := NewFace(3, 0.1, 0.2, 0.3, 0.4, 0.9, "[1]")
+
+	assert.Equal(t, uint(3), f.FileID)
+	assert.Equal(t, uint(0), f.PersonID)
+	assert.Equal(t, float32(0.3), f.FaceW)
+	assert.Equal(t, "files_faces", Face{}.TableName())
+}
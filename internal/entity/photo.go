@@ -11,10 +11,20 @@ import (
 	"github.com/ulule/deepcopier"
 )
 
+// Sources of the date a photo was taken, from the most to the least reliable.
+const (
+	TakenSrcManual = "manual" // Changed by the user
+	TakenSrcMeta   = "meta"   // Exif or other embedded meta data
+	TakenSrcName   = "name"   // File name, e.g. IMG_20190612_101500.jpg
+	TakenSrcPath   = "path"   // Folder name, e.g. /2008/Summer/
+	TakenSrcFile   = "file"   // File system time, usually the date of copying
+)
+
 // Photo represents a photo that can have multiple image or sidecar files.
 type Photo struct {
 	ID                uint      `gorm:"primary_key"`
 	TakenAt           time.Time `gorm:"type:datetime;index:idx_photos_taken_uuid;" json:"TakenAt"`
+	TakenSrc          string    `gorm:"type:varbinary(8);" json:"TakenSrc"`
 	PhotoUUID         string    `gorm:"type:varbinary(36);unique_index;index:idx_photos_taken_uuid;"`
 	PhotoPath         string    `gorm:"type:varbinary(512);index;"`
 	PhotoName         string    `gorm:"type:varbinary(256);"`
@@ -27,13 +37,22 @@ type Photo struct {
 	PhotoPrivate      bool      `json:"PhotoPrivate"`
 	PhotoNSFW         bool      `json:"PhotoNSFW"`
 	PhotoStory        bool      `json:"PhotoStory"`
+	PhotoSingle       bool      `json:"PhotoSingle"`
 	PhotoLat          float64   `gorm:"index;" json:"PhotoLat"`
 	PhotoLng          float64   `gorm:"index;" json:"PhotoLng"`
 	PhotoAltitude     int       `json:"PhotoAltitude"`
+	PhotoDirection    float64   `json:"PhotoDirection"`
 	PhotoFocalLength  int       `json:"PhotoFocalLength"`
 	PhotoIso          int       `json:"PhotoIso"`
 	PhotoFNumber      float64   `json:"PhotoFNumber"`
 	PhotoExposure     string    `gorm:"type:varbinary(64);" json:"PhotoExposure"`
+	PhotoBias         float64   `json:"PhotoBias"`
+	PhotoProgram      string    `gorm:"type:varbinary(16);" json:"PhotoProgram"`
+	PhotoMetering     string    `gorm:"type:varbinary(16);" json:"PhotoMetering"`
+	PhotoWhiteBalance string    `gorm:"type:varbinary(16);" json:"PhotoWhiteBalance"`
+	PhotoFlash        bool      `json:"PhotoFlash"`
+	PhotoFlashMode    string    `gorm:"type:varbinary(16);" json:"PhotoFlashMode"`
+	PhotoDistance     float64   `json:"PhotoDistance"`
 	CameraID          uint      `gorm:"index:idx_photos_camera_lens;" json:"CameraID"`
 	LensID            uint      `gorm:"index:idx_photos_camera_lens;" json:"LensID"`
 	AccountID         uint      `json:"AccountID"`
@@ -56,8 +75,10 @@ type Photo struct {
 	Account           *Account  `json:"-"`
 	Files             []File
 	Labels            []PhotoLabel
-	Keywords          []Keyword `json:"-"`
-	Albums            []Album   `json:"-"`
+	Keywords          []Keyword  `json:"-"`
+	Albums            []Album    `json:"-"`
+	GPSTime           *time.Time `gorm:"type:datetime;" json:"GPSTime"`
+	GPSSpeed          float64    `json:"GPSSpeed"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         *time.Time `sql:"index"`
@@ -65,11 +86,22 @@ type Photo struct {
 
 // SavePhoto updates a model using form data and persists it in the database.
 func SavePhoto(model Photo, form form.Photo, db *gorm.DB) error {
+	dateChanged := !form.TakenAt.Equal(model.TakenAt) || !form.TakenAtLocal.Equal(model.TakenAtLocal)
+
 	if err := deepcopier.Copy(&model).From(form); err != nil {
 		return err
 	}
 
-	return db.Save(&model).Error
+	if dateChanged {
+		model.TakenSrc = TakenSrcManual
+		model.ModifiedDate = true
+	}
+
+	if err := db.Save(&model).Error; err != nil {
+		return err
+	}
+
+	return model.IndexTerms(db)
 }
 
 func (m *Photo) BeforeCreate(scope *gorm.Scope) error {
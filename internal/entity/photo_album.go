@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -9,19 +10,39 @@ import (
 
 // Photos can be added to multiple albums
 type PhotoAlbum struct {
-	PhotoUUID string `gorm:"type:varbinary(36);primary_key;auto_increment:false"`
-	AlbumUUID string `gorm:"type:varbinary(36);primary_key;auto_increment:false;index"`
-	Order     int
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Photo     *Photo
-	Album     *Album
+	PhotoUUID     string `gorm:"type:varbinary(36);primary_key;auto_increment:false"`
+	AlbumUUID     string `gorm:"type:varbinary(36);primary_key;auto_increment:false;index"`
+	PhotoPosition int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Photo         *Photo
+	Album         *Album
 }
 
 func (PhotoAlbum) TableName() string {
 	return "photos_albums"
 }
 
+// BeforeCreate appends new photos to the end of the manual album order.
+func (m *PhotoAlbum) BeforeCreate(scope *gorm.Scope) error {
+	if m.PhotoPosition > 0 {
+		return nil
+	}
+
+	var result struct {
+		Position int
+	}
+
+	if err := scope.NewDB().Table("photos_albums").
+		Select("COALESCE(MAX(photo_position), 0) AS position").
+		Where("album_uuid = ?", m.AlbumUUID).
+		Scan(&result).Error; err != nil {
+		return err
+	}
+
+	return scope.SetColumn("PhotoPosition", result.Position+1)
+}
+
 func NewPhotoAlbum(photoUUID, albumUUID string) *PhotoAlbum {
 	result := &PhotoAlbum{
 		PhotoUUID: photoUUID,
@@ -41,3 +62,53 @@ func (m *PhotoAlbum) FirstOrCreate(db *gorm.DB) *PhotoAlbum {
 
 	return m
 }
+
+// MovePhotoInAlbum moves a photo to the given index of the manual album order
+// and renumbers the positions of all other photos in the album.
+func MovePhotoInAlbum(db *gorm.DB, albumUUID, photoUUID string, index int) error {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	var entries []PhotoAlbum
+
+	if err := db.Where("album_uuid = ?", albumUUID).Order("photo_position, created_at").Find(&entries).Error; err != nil {
+		return err
+	}
+
+	from := -1
+
+	for i, entry := range entries {
+		if entry.PhotoUUID == photoUUID {
+			from = i
+			break
+		}
+	}
+
+	if from < 0 {
+		return fmt.Errorf("photo %s not found in album %s", photoUUID, albumUUID)
+	}
+
+	moved := entries[from]
+	entries = append(entries[:from], entries[from+1:]...)
+
+	if index < 0 {
+		index = 0
+	} else if index > len(entries) {
+		index = len(entries)
+	}
+
+	entries = append(entries[:index], append([]PhotoAlbum{moved}, entries[index:]...)...)
+
+	tx := db.Begin()
+
+	for i, entry := range entries {
+		if err := tx.Model(&PhotoAlbum{}).
+			Where("album_uuid = ? AND photo_uuid = ?", albumUUID, entry.PhotoUUID).
+			UpdateColumn("photo_position", i+1).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
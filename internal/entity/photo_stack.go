@@ -0,0 +1,216 @@
+package entity
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// StackFile returns a file of the photo stack by file uuid.
+func (m *Photo) StackFile(db *gorm.DB, fileUUID string) (file File, err error) {
+	if err := db.Where("photo_id = ? AND file_uuid = ?", m.ID, fileUUID).First(&file).Error; err != nil {
+		return file, errors.New("photo: file not found")
+	}
+
+	return file, nil
+}
+
+// SetPrimaryFile changes the file used for thumbnails and downloads, it must be a JPEG.
+func (m *Photo) SetPrimaryFile(db *gorm.DB, fileUUID string) error {
+	file, err := m.StackFile(db, fileUUID)
+
+	if err != nil {
+		return err
+	}
+
+	if file.FileType != "jpg" || file.FileMissing {
+		return errors.New("photo: primary file must be an existing jpeg")
+	}
+
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	tx := db.Begin()
+
+	if err := tx.Model(&File{}).Where("photo_id = ? AND id <> ?", m.ID, file.ID).UpdateColumn("file_primary", false).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Model(&file).UpdateColumn("file_primary", true).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Unstack moves a file to a new photo, which keeps the details of this photo and won't be stacked again.
+func (m *Photo) Unstack(db *gorm.DB, fileUUID string) (*Photo, error) {
+	file, err := m.StackFile(db, fileUUID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if file.FileSidecar {
+		return nil, errors.New("photo: can't unstack sidecar files")
+	}
+
+	var count int
+
+	if err := db.Model(&File{}).Where("photo_id = ? AND file_sidecar = 0 AND deleted_at IS NULL", m.ID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	if count < 2 {
+		return nil, errors.New("photo: can't unstack the only file")
+	}
+
+	result := *m
+	result.ID = 0
+	result.PhotoUUID = ""
+	result.PhotoPath = filepath.Dir(file.FileName)
+	result.PhotoName = stackName(file.FileName)
+	result.PhotoSingle = true
+	result.Camera = nil
+	result.Lens = nil
+	result.Location = nil
+	result.Place = nil
+	result.Account = nil
+	result.Files = nil
+	result.Labels = nil
+	result.Keywords = nil
+	result.Albums = nil
+	result.DeletedAt = nil
+
+	if result.PhotoPath == "." {
+		result.PhotoPath = ""
+	}
+
+	mutex.Db.Lock()
+
+	tx := db.Begin()
+
+	if err := unstackFile(tx, m, &result, file); err != nil {
+		tx.Rollback()
+		mutex.Db.Unlock()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		mutex.Db.Unlock()
+		return nil, err
+	}
+
+	mutex.Db.Unlock()
+
+	if err := result.IndexTerms(db); err != nil {
+		return &result, err
+	}
+
+	return &result, m.IndexTerms(db)
+}
+
+// unstackFile creates the new photo of an unstacked file in a transaction and moves the file and its sidecars.
+// Labels, keywords and album membership are copied, so that the new photo can still be found the same way.
+func unstackFile(tx *gorm.DB, m, result *Photo, file File) error {
+	if err := tx.Create(result).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("INSERT INTO photos_labels (photo_id, label_id, label_uncertainty, label_source) "+
+		"SELECT ?, label_id, label_uncertainty, label_source FROM photos_labels WHERE photo_id = ?", result.ID, m.ID).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec("INSERT INTO photos_keywords (photo_id, keyword_id) "+
+		"SELECT ?, keyword_id FROM photos_keywords WHERE photo_id = ?", result.ID, m.ID).Error; err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	if err := tx.Exec("INSERT INTO photos_albums (photo_uuid, album_uuid, photo_position, created_at, updated_at) "+
+		"SELECT ?, album_uuid, photo_position, ?, ? FROM photos_albums WHERE photo_uuid = ?", result.PhotoUUID, now, now, m.PhotoUUID).Error; err != nil {
+		return err
+	}
+
+	var sidecars, others []File
+
+	if err := tx.Where("photo_id = ? AND file_sidecar = 1", m.ID).Find(&sidecars).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("photo_id = ? AND file_sidecar = 0 AND id <> ?", m.ID, file.ID).Find(&others).Error; err != nil {
+		return err
+	}
+
+	move := []uint{file.ID}
+
+	for _, sidecar := range sidecars {
+		if stackSidecar(sidecar, file, others) {
+			move = append(move, sidecar.ID)
+		}
+	}
+
+	if err := tx.Model(&File{}).Where("id IN (?)", move).Updates(map[string]interface{}{
+		"photo_id":   result.ID,
+		"photo_uuid": result.PhotoUUID,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&file).UpdateColumn("file_primary", file.FileType == "jpg").Error; err != nil {
+		return err
+	}
+
+	if file.FilePrimary {
+		var next File
+
+		if err := tx.Where("photo_id = ? AND file_type = 'jpg' AND file_missing = 0 AND deleted_at IS NULL", m.ID).First(&next).Error; err == nil {
+			if err := tx.Model(&next).UpdateColumn("file_primary", true).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// stackSidecar returns true if a sidecar belongs to file rather than to the other files of the stack,
+// example: IMG_1234.CR2.xmp, or IMG_1234.xmp if no other file is named IMG_1234.
+func stackSidecar(sidecar, file File, others []File) bool {
+	if strings.HasPrefix(sidecar.FileName, file.FileName+".") {
+		return true
+	}
+
+	dir, name := filepath.Dir(sidecar.FileName), stackName(sidecar.FileName)
+
+	if filepath.Dir(file.FileName) != dir || stackName(file.FileName) != name {
+		return false
+	}
+
+	for _, other := range others {
+		if filepath.Dir(other.FileName) == dir && stackName(other.FileName) == name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// stackName returns the photo name of a file, which is the file name without extensions.
+func stackName(fileName string) string {
+	name := filepath.Base(fileName)
+
+	if end := strings.Index(name, "."); end != -1 {
+		name = name[:end]
+	}
+
+	return name
+}
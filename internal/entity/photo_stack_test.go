@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackName(t *testing.T) {
+	assert.Equal(t, "IMG_1234", stackName("2019/05/IMG_1234.JPG"))
+	assert.Equal(t, "20190510_120000_ABCDEF12", stackName("2019/05/20190510_120000_ABCDEF12.edited_1.jpg"))
+	assert.Equal(t, "foo", stackName("foo"))
+}
+
+func TestStackSidecar(t *testing.T) {
+	raw := File{ID: 1, FileName: "2019/05/IMG_1234.CR2"}
+	jpeg := File{ID: 2, FileName: "2019/05/IMG_1234.jpg"}
+	edit := File{ID: 3, FileName: "2019/05/IMG_1234_edited.jpg"}
+
+	t.Run("extension", func(t *testing.T) {
+		assert.True(t, stackSidecar(File{FileName: "2019/05/IMG_1234.CR2.xmp"}, raw, []File{jpeg}))
+		assert.False(t, stackSidecar(File{FileName: "2019/05/IMG_1234.CR2.xmp"}, jpeg, []File{raw}))
+	})
+	t.Run("shared name", func(t *testing.T) {
+		assert.False(t, stackSidecar(File{FileName: "2019/05/IMG_1234.xmp"}, jpeg, []File{raw}))
+		assert.True(t, stackSidecar(File{FileName: "2019/05/IMG_1234.xmp"}, jpeg, []File{edit}))
+	})
+	t.Run("other name", func(t *testing.T) {
+		assert.False(t, stackSidecar(File{FileName: "2019/05/IMG_1234.xmp"}, edit, []File{raw, jpeg}))
+		assert.True(t, stackSidecar(File{FileName: "2019/05/IMG_1234_edited.xmp"}, edit, []File{raw, jpeg}))
+	})
+}
@@ -0,0 +1,236 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// PhotoTerm represents a word of a photo's searchable text, ordered by position within each field.
+type PhotoTerm struct {
+	ID       uint   `gorm:"primary_key"`
+	PhotoID  uint   `gorm:"index;"`
+	Field    string `gorm:"type:varbinary(16);"`
+	Term     string `gorm:"type:varbinary(64);index;"`
+	Position int
+	Weight   int
+}
+
+func (PhotoTerm) TableName() string {
+	return "photos_terms"
+}
+
+// termBatchSize is the number of terms inserted with a single statement.
+const termBatchSize = 100
+
+// SearchText returns the searchable text of a photo by field.
+func (m *Photo) SearchText(db *gorm.DB) map[string][]string {
+	result := map[string][]string{
+		search.FieldTitle:       {m.PhotoTitle},
+		search.FieldDescription: {m.PhotoDescription},
+		search.FieldNotes:       {m.PhotoNotes},
+		search.FieldFile:        {m.PhotoPath, m.PhotoName},
+	}
+
+	var keywords []string
+	db.Table("keywords").
+		Joins("JOIN photos_keywords ON photos_keywords.keyword_id = keywords.id").
+		Where("photos_keywords.photo_id = ? AND keywords.skip = ?", m.ID, false).
+		Order("keywords.keyword").
+		Pluck("keywords.keyword", &keywords)
+	result[search.FieldKeywords] = keywords
+
+	var labels []string
+	db.Table("labels").
+		Joins("JOIN photos_labels ON photos_labels.label_id = labels.id").
+		Where("photos_labels.photo_id = ? AND photos_labels.label_uncertainty < 100 AND labels.deleted_at IS NULL", m.ID).
+		Order("photos_labels.label_uncertainty, labels.label_name").
+		Pluck("labels.label_name", &labels)
+
+	var categories []string
+	db.Table("labels").
+		Joins("JOIN categories ON categories.category_id = labels.id").
+		Joins("JOIN photos_labels ON photos_labels.label_id = categories.label_id").
+		Where("photos_labels.photo_id = ? AND photos_labels.label_uncertainty < 100 AND labels.deleted_at IS NULL", m.ID).
+		Order("labels.label_name").
+		Pluck("DISTINCT labels.label_name", &categories)
+	result[search.FieldLabels] = append(labels, categories...)
+
+	if m.PlaceID != "" && m.PlaceID != UnknownPlace.ID {
+		place := Place{}
+
+		if err := db.Where("id = ?", m.PlaceID).First(&place).Error; err == nil {
+			result[search.FieldPlace] = append(result[search.FieldPlace], place.LocLabel)
+		}
+	}
+
+	if m.LocationID != "" {
+		location := Location{}
+
+		if err := db.Where("id = ?", m.LocationID).First(&location).Error; err == nil {
+			result[search.FieldPlace] = append(result[search.FieldPlace], location.LocName, location.LocCategory)
+		}
+	}
+
+	if m.CameraID > 0 {
+		camera := Camera{}
+
+		if err := db.Where("id = ?", m.CameraID).First(&camera).Error; err == nil && camera.CameraSlug != "unknown" {
+			result[search.FieldCamera] = []string{camera.CameraMake, camera.CameraModel}
+		}
+	}
+
+	var fileNames []string
+	db.Table("files").
+		Where("photo_id = ? AND deleted_at IS NULL", m.ID).
+		Order("file_primary DESC, file_name").
+		Pluck("original_name", &fileNames)
+	result[search.FieldFile] = append(result[search.FieldFile], fileNames...)
+
+	return result
+}
+
+// IndexTerms updates the full-text search index of a photo.
+func (m *Photo) IndexTerms(db *gorm.DB) error {
+	if m.ID == 0 {
+		return fmt.Errorf("photo: can't index terms without id")
+	}
+
+	text := m.SearchText(db)
+
+	var placeholders []string
+	var values []interface{}
+
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	tx := db.Begin()
+
+	if err := tx.Where("photo_id = ?", m.ID).Delete(&PhotoTerm{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	insert := func() error {
+		if len(placeholders) == 0 {
+			return nil
+		}
+
+		sql := "INSERT INTO photos_terms (photo_id, field, term, position, weight) VALUES " + strings.Join(placeholders, ",")
+		err := tx.Exec(sql, values...).Error
+
+		placeholders = placeholders[:0]
+		values = values[:0]
+
+		return err
+	}
+
+	for field, weight := range search.Weights {
+		position := 0
+
+		for _, s := range text[field] {
+			for _, w := range search.Words(s) {
+				position++
+				placeholders = append(placeholders, "(?, ?, ?, ?, ?)")
+				values = append(values, m.ID, field, w, position, weight)
+
+				if len(placeholders) < termBatchSize {
+					continue
+				}
+
+				if err := insert(); err != nil {
+					tx.Rollback()
+					return err
+				}
+			}
+
+			// Phrases must not match across values
+			position++
+		}
+	}
+
+	if err := insert(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// IndexPhotoTerms updates the full-text search index of the photo with the given id.
+func IndexPhotoTerms(db *gorm.DB, photoID uint) error {
+	var photo Photo
+
+	if err := db.Where("id = ?", photoID).First(&photo).Error; err != nil {
+		return err
+	}
+
+	return photo.IndexTerms(db)
+}
+
+// IndexLabelTerms updates the full-text search index of all photos with a label, e.g. after it was renamed.
+func IndexLabelTerms(db *gorm.DB, labelID uint) error {
+	var photos []Photo
+
+	if err := db.Where("id IN (SELECT photo_id FROM photos_labels WHERE label_id = ?)", labelID).Find(&photos).Error; err != nil {
+		return err
+	}
+
+	for _, p := range photos {
+		if err := p.IndexTerms(db); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IndexMissingTerms adds photos to the full-text search index that have not been indexed yet,
+// archived photos are included so that they can be found when searching the archive.
+func IndexMissingTerms(db *gorm.DB) (count int, err error) {
+	var photos []Photo
+
+	if err := db.Unscoped().Where("id NOT IN (SELECT DISTINCT photo_id FROM photos_terms)").Find(&photos).Error; err != nil {
+		return 0, err
+	}
+
+	for _, p := range photos {
+		if err := p.IndexTerms(db); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// PurgeTerms removes the search terms of photos that don't exist anymore.
+func PurgeTerms(db *gorm.DB) (count int64, err error) {
+	mutex.Db.Lock()
+	defer mutex.Db.Unlock()
+
+	res := db.Where("photo_id NOT IN (SELECT id FROM photos)").Delete(&PhotoTerm{})
+
+	return res.RowsAffected, res.Error
+}
+
+// MigrateTerms updates the full-text search index of libraries indexed before it was added,
+// so that photos can be found without indexing them again.
+func MigrateTerms(db *gorm.DB) {
+	if count, err := PurgeTerms(db); err != nil {
+		log.Errorf("photo: %s", err)
+		return
+	} else if count > 0 {
+		log.Infof("photo: removed %d search terms of deleted photos", count)
+	}
+
+	if count, err := IndexMissingTerms(db); err != nil {
+		log.Errorf("photo: %s", err)
+	} else if count > 0 {
+		log.Infof("photo: added %d photos to the search index", count)
+	}
+}
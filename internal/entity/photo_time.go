@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ShiftTime moves the time when the photo was taken, UTC and local time may be shifted by different durations
+// if the time zone changes as well.
+func (m *Photo) ShiftTime(utc, local time.Duration) {
+	m.TakenAt = m.TakenAt.Add(utc)
+	m.TakenAtLocal = m.TakenAtLocal.Add(local)
+}
+
+// SetTimeZone changes the time zone and keeps the local time, so that the UTC time changes instead.
+func (m *Photo) SetTimeZone(zone string) error {
+	loc, err := time.LoadLocation(zone)
+
+	if err != nil || zone == "" {
+		return fmt.Errorf("photo: unknown time zone \"%s\"", zone)
+	}
+
+	l := m.TakenAtLocal
+
+	m.TakenAt = time.Date(l.Year(), l.Month(), l.Day(), l.Hour(), l.Minute(), l.Second(), l.Nanosecond(), loc).UTC()
+	m.TimeZone = zone
+
+	return nil
+}
+
+// SaveTime persists a manually changed date, it won't be overwritten with meta data when indexing again.
+func (m *Photo) SaveTime(db *gorm.DB) error {
+	m.PhotoYear = m.TakenAt.Year()
+	m.PhotoMonth = int(m.TakenAt.Month())
+	m.TakenSrc = TakenSrcManual
+	m.ModifiedDate = true
+
+	return db.Model(m).UpdateColumns(map[string]interface{}{
+		"taken_at":       m.TakenAt,
+		"taken_at_local": m.TakenAtLocal,
+		"time_zone":      m.TimeZone,
+		"taken_src":      m.TakenSrc,
+		"photo_year":     m.PhotoYear,
+		"photo_month":    m.PhotoMonth,
+		"modified_date":  m.ModifiedDate,
+	}).Error
+}
+
+// MigrateTakenSrc sets the date source of photos indexed before it was stored. Dates of photos
+// with camera details were read from meta data, others from the file system time.
+// Indexing again with updated Exif data replaces these estimates.
+func MigrateTakenSrc(db *gorm.DB) {
+	photos := db.Unscoped().Model(&Photo{}).Where("taken_src = '' OR taken_src IS NULL")
+
+	if err := photos.Where("modified_date = 1").UpdateColumn("taken_src", TakenSrcManual).Error; err != nil {
+		log.Errorf("photo: %s", err)
+		return
+	}
+
+	if err := photos.Where("photo_iso > 0 OR photo_exposure <> '' OR camera_id IN (SELECT id FROM cameras WHERE camera_slug <> 'unknown')").
+		UpdateColumn("taken_src", TakenSrcMeta).Error; err != nil {
+		log.Errorf("photo: %s", err)
+		return
+	}
+
+	if err := photos.UpdateColumn("taken_src", TakenSrcFile).Error; err != nil {
+		log.Errorf("photo: %s", err)
+	}
+}
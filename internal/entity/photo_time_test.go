@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_ShiftTime(t *testing.T) {
+	taken := time.Date(2019, 6, 12, 10, 15, 0, 0, time.UTC)
+	m := Photo{TakenAt: taken, TakenAtLocal: taken}
+
+	m.ShiftTime(-7*time.Hour, 2*time.Hour)
+
+	assert.Equal(t, "2019-06-12 03:15:00", m.TakenAt.Format("2006-01-02 15:04:05"))
+	assert.Equal(t, "2019-06-12 12:15:00", m.TakenAtLocal.Format("2006-01-02 15:04:05"))
+}
+
+func TestPhoto_SetTimeZone(t *testing.T) {
+	t.Run("Asia/Tokyo", func(t *testing.T) {
+		taken := time.Date(2019, 6, 12, 10, 15, 0, 0, time.UTC)
+		m := Photo{TakenAt: taken, TakenAtLocal: taken}
+
+		if err := m.SetTimeZone("Asia/Tokyo"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Asia/Tokyo", m.TimeZone)
+		assert.Equal(t, "2019-06-12 01:15:00", m.TakenAt.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, "2019-06-12 10:15:00", m.TakenAtLocal.Format("2006-01-02 15:04:05"))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		m := Photo{TimeZone: "Europe/Berlin"}
+
+		assert.EqualError(t, m.SetTimeZone("Mars/Olympus"), "photo: unknown time zone \"Mars/Olympus\"")
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+	})
+}
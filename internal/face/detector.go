@@ -0,0 +1,257 @@
+package face
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"path/filepath"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	tf "github.com/tensorflow/tensorflow/tensorflow/go"
+)
+
+// EmbeddingSize is the width and height of face crops passed to the embedding model.
+const EmbeddingSize = 160
+
+// Detector uses TensorFlow to find faces and compute their embeddings. Models run on the CPU
+// and are expected in the "detector" (SSD face detector) and "facenet" subdirectories of modelPath.
+type Detector struct {
+	detector  *tf.SavedModel
+	net       *tf.SavedModel
+	modelPath string
+	modelTags []string
+	mutex     sync.Mutex
+}
+
+// New returns a new detector instance.
+func New(modelPath string) *Detector {
+	return &Detector{modelPath: modelPath, modelTags: []string{"serve"}}
+}
+
+// File returns the faces found in a jpeg media file.
+func (t *Detector) File(filename string) (result Faces, err error) {
+	if fs.MimeType(filename) != "image/jpeg" {
+		return result, fmt.Errorf("face: \"%s\" is not a jpeg file", filename)
+	}
+
+	img, err := imaging.Open(filename, imaging.AutoOrientation(true))
+
+	if err != nil {
+		return result, err
+	}
+
+	return t.Faces(img)
+}
+
+// Faces returns the faces found in an image including their embeddings.
+func (t *Detector) Faces(img image.Image) (result Faces, err error) {
+	if err := t.loadModels(); err != nil {
+		return result, err
+	}
+
+	areas, scores, err := t.detect(img)
+
+	if err != nil {
+		return result, err
+	}
+
+	for i, area := range areas {
+		if scores[i] < MinScore || area.W < MinSize || area.H < MinSize {
+			continue
+		}
+
+		embedding, err := t.embedding(img, area)
+
+		if err != nil {
+			log.Errorf("face: %s", err)
+			continue
+		}
+
+		result = append(result, Face{Score: scores[i], Area: area, Embedding: embedding})
+	}
+
+	if len(result) > 0 {
+		log.Debugf("face: found %d faces", len(result))
+	}
+
+	return result, nil
+}
+
+// detect returns face boxes and their scores.
+func (t *Detector) detect(img image.Image) (areas []Area, scores []float32, err error) {
+	tensor, err := imageToUint8Tensor(img)
+
+	if err != nil {
+		log.Error(err)
+		return areas, scores, errors.New("face: invalid image")
+	}
+
+	output, err := t.detector.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			t.detector.Graph.Operation("image_tensor").Output(0): tensor,
+		},
+		[]tf.Output{
+			t.detector.Graph.Operation("detection_boxes").Output(0),
+			t.detector.Graph.Operation("detection_scores").Output(0),
+		},
+		nil)
+
+	if err != nil {
+		log.Error(err)
+		return areas, scores, errors.New("face: could not run detection")
+	}
+
+	if len(output) < 2 {
+		return areas, scores, errors.New("face: detection result is empty")
+	}
+
+	boxes := output[0].Value().([][][]float32)[0]
+	scores = output[1].Value().([][]float32)[0]
+
+	for _, box := range boxes {
+		// Boxes are returned as ymin, xmin, ymax, xmax
+		areas = append(areas, Area{X: box[1], Y: box[0], W: box[3] - box[1], H: box[2] - box[0]})
+	}
+
+	return areas, scores, nil
+}
+
+// embedding returns the embedding of a single face.
+func (t *Detector) embedding(img image.Image, area Area) (Embedding, error) {
+	bounds := img.Bounds()
+	width, height := float32(bounds.Dx()), float32(bounds.Dy())
+
+	// Add a margin of 20% around the face
+	x0 := int((area.X - area.W*0.2) * width)
+	y0 := int((area.Y - area.H*0.2) * height)
+	x1 := int((area.X + area.W*1.2) * width)
+	y1 := int((area.Y + area.H*1.2) * height)
+
+	crop := imaging.Crop(img, image.Rect(x0, y0, x1, y1))
+	crop = imaging.Fill(crop, EmbeddingSize, EmbeddingSize, imaging.Center, imaging.Lanczos)
+
+	tensor, err := tf.NewTensor(prewhiten(crop))
+
+	if err != nil {
+		return nil, err
+	}
+
+	phaseTrain, err := tf.NewTensor(false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := t.net.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			t.net.Graph.Operation("input").Output(0):       tensor,
+			t.net.Graph.Operation("phase_train").Output(0): phaseTrain,
+		},
+		[]tf.Output{
+			t.net.Graph.Operation("embeddings").Output(0),
+		},
+		nil)
+
+	if err != nil {
+		log.Error(err)
+		return nil, errors.New("face: could not compute embedding")
+	}
+
+	if len(output) < 1 {
+		return nil, errors.New("face: embedding is empty")
+	}
+
+	return output[0].Value().([][]float32)[0], nil
+}
+
+func (t *Detector) loadModels() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.detector != nil && t.net != nil {
+		// Already loaded
+		return nil
+	}
+
+	detectorPath := filepath.Join(t.modelPath, "detector")
+	netPath := filepath.Join(t.modelPath, "facenet")
+
+	log.Infof("tensorflow: loading face detection models from \"%s\"", filepath.Base(t.modelPath))
+
+	detector, err := tf.LoadSavedModel(detectorPath, t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	net, err := tf.LoadSavedModel(netPath, t.modelTags, nil)
+
+	if err != nil {
+		return err
+	}
+
+	t.detector = detector
+	t.net = net
+
+	return nil
+}
+
+// imageToUint8Tensor returns the image pixels as batch of one, which is the input of the detection model.
+func imageToUint8Tensor(img image.Image) (*tf.Tensor, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	pixels := make([][][3]uint8, height)
+
+	for y := 0; y < height; y++ {
+		pixels[y] = make([][3]uint8, width)
+
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y][x] = [3]uint8{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8)}
+		}
+	}
+
+	return tf.NewTensor([][][][3]uint8{pixels})
+}
+
+// prewhiten normalizes pixel values to zero mean and unit variance as expected by FaceNet.
+func prewhiten(img image.Image) [][][][3]float32 {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	pixels := make([][][3]float32, height)
+
+	var sum, sumSquares float64
+
+	for y := 0; y < height; y++ {
+		pixels[y] = make([][3]float32, width)
+
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y][x] = [3]float32{float32(r >> 8), float32(g >> 8), float32(b >> 8)}
+
+			for _, v := range pixels[y][x] {
+				sum += float64(v)
+				sumSquares += float64(v) * float64(v)
+			}
+		}
+	}
+
+	n := float64(width * height * 3)
+	mean := sum / n
+	std := math.Max(math.Sqrt(sumSquares/n-mean*mean), 1/math.Sqrt(n))
+
+	for y := range pixels {
+		for x := range pixels[y] {
+			for c := range pixels[y][x] {
+				pixels[y][x][c] = float32((float64(pixels[y][x][c]) - mean) / std)
+			}
+		}
+	}
+
+	return [][][][3]float32{pixels}
+}
@@ -0,0 +1,59 @@
+package face
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var modelPath, _ = filepath.Abs("../../assets/resources/faces")
+
+func TestDetector_File(t *testing.T) {
+	if _, err := os.Stat(modelPath); err != nil {
+		t.Skip("face models not installed")
+	}
+
+	detector := New(modelPath)
+
+	t.Run("not a jpeg", func(t *testing.T) {
+		_, err := detector.File("../../assets/resources/examples/Random.docx")
+
+		assert.Error(t, err)
+	})
+	t.Run("no faces", func(t *testing.T) {
+		result, err := detector.File("../../assets/resources/examples/elephants.jpg")
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestPrewhiten(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 60), G: uint8(y * 60), B: 128, A: 255})
+		}
+	}
+
+	result := prewhiten(img)
+
+	var sum float64
+
+	for _, row := range result[0] {
+		for _, pixel := range row {
+			for _, v := range pixel {
+				sum += float64(v)
+			}
+		}
+	}
+
+	assert.Len(t, result[0], 4)
+	assert.True(t, math.Abs(sum) < 0.001)
+}
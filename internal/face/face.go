@@ -0,0 +1,110 @@
+/*
+This package detects faces and computes face embeddings, so that faces can be clustered into people.
+
+The models are not bundled with PhotoPrism and run with TensorFlow, so face detection is not available
+if TensorFlow is disabled. Two SavedModels tagged "serve" are expected in the faces resources directory
+(see Config.FacesModelPath):
+
+	faces/detector: SSD face detector exported with the TensorFlow Object Detection API,
+	                operations "image_tensor", "detection_boxes" and "detection_scores"
+	faces/facenet:  FaceNet embedding model with 160x160 input,
+	                operations "input", "phase_train" and "embeddings"
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package face
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// MinScore is the minimum detection score of a face.
+const MinScore = 0.75
+
+// MinSize is the minimum width and height of a face relative to the image size.
+const MinSize = 0.04
+
+// ClusterDistance is the maximum embedding distance of faces that belong to the same person.
+const ClusterDistance = 0.9
+
+// Area represents a face box, coordinates are relative to the image size.
+type Area struct {
+	X float32
+	Y float32
+	W float32
+	H float32
+}
+
+// Face represents a detected face.
+type Face struct {
+	Score     float32
+	Area      Area
+	Embedding Embedding
+}
+
+// Faces is a list of detected faces.
+type Faces []Face
+
+// Embedding is a face feature vector, faces of the same person have a small distance.
+type Embedding []float32
+
+// Distance returns the euclidean distance between two embeddings.
+func (e Embedding) Distance(other Embedding) float64 {
+	if len(e) == 0 || len(e) != len(other) {
+		return math.MaxFloat64
+	}
+
+	var sum float64
+
+	for i := range e {
+		d := float64(e[i] - other[i])
+		sum += d * d
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Add returns the weighted mean of the embedding, representing count faces, and another embedding.
+func (e Embedding) Add(other Embedding, count int) Embedding {
+	if len(e) != len(other) || count < 1 {
+		return other
+	}
+
+	result := make(Embedding, len(e))
+
+	for i := range e {
+		result[i] = (e[i]*float32(count) + other[i]) / float32(count+1)
+	}
+
+	return result
+}
+
+// JSON returns the embedding as JSON string.
+func (e Embedding) JSON() string {
+	result, err := json.Marshal(e)
+
+	if err != nil {
+		log.Errorf("face: %s", err)
+		return ""
+	}
+
+	return string(result)
+}
+
+// ParseEmbedding returns the embedding encoded in a JSON string.
+func ParseEmbedding(s string) (result Embedding, err error) {
+	if s == "" {
+		return result, nil
+	}
+
+	err = json.Unmarshal([]byte(s), &result)
+
+	return result, err
+}
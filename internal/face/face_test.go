@@ -0,0 +1,56 @@
+package face
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedding_Distance(t *testing.T) {
+	t.Run("same", func(t *testing.T) {
+		e := Embedding{0.5, 0.5, 0.5}
+		assert.Equal(t, float64(0), e.Distance(e))
+	})
+	t.Run("different", func(t *testing.T) {
+		e := Embedding{0, 0, 0}
+		assert.Equal(t, float64(5), e.Distance(Embedding{3, 4, 0}))
+	})
+	t.Run("size mismatch", func(t *testing.T) {
+		e := Embedding{0, 0, 0}
+		assert.Equal(t, math.MaxFloat64, e.Distance(Embedding{1, 2}))
+	})
+}
+
+func TestEmbedding_Add(t *testing.T) {
+	t.Run("mean", func(t *testing.T) {
+		e := Embedding{1, 1}
+		assert.Equal(t, Embedding{2, 1}, e.Add(Embedding{5, 1}, 3))
+	})
+	t.Run("empty", func(t *testing.T) {
+		var e Embedding
+		assert.Equal(t, Embedding{5, 1}, e.Add(Embedding{5, 1}, 0))
+	})
+}
+
+func TestParseEmbedding(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		e := Embedding{0.25, -0.5}
+
+		result, err := ParseEmbedding(e.JSON())
+
+		assert.Nil(t, err)
+		assert.Equal(t, e, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		result, err := ParseEmbedding("")
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseEmbedding("foo")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,7 @@
+package form
+
+// AlbumPosition represents a drag-and-drop move of a photo within an album.
+type AlbumPosition struct {
+	Photo string `json:"photo" binding:"required"`
+	Index int    `json:"index"`
+}
@@ -2,14 +2,16 @@ package form
 
 // AlbumSearch represents search form fields for "/api/v1/albums".
 type AlbumSearch struct {
-	Query     string `form:"q"`
-	ID        string `form:"id"`
-	Slug      string `form:"slug"`
-	Name      string `form:"name"`
-	Favorites bool   `form:"favorites"`
-	Count     int    `form:"count" binding:"required"`
-	Offset    int    `form:"offset"`
-	Order     string `form:"order"`
+	Query       string `form:"q"`
+	ID          string `form:"id"`
+	Slug        string `form:"slug"`
+	Name        string `form:"name"`
+	Parent      string `form:"parent"`
+	Descendants bool   `form:"descendants"`
+	Favorites   bool   `form:"favorites"`
+	Count       int    `form:"count" binding:"required"`
+	Offset      int    `form:"offset"`
+	Order       string `form:"order"`
 }
 
 func (f *AlbumSearch) GetQuery() string {
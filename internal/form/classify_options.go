@@ -0,0 +1,30 @@
+package form
+
+import (
+	"time"
+)
+
+// ClassifyOptions represents the photos selected for image classification.
+type ClassifyOptions struct {
+	Query  string    `json:"q"`
+	Label  string    `json:"label"`
+	Album  string    `json:"album"`
+	Camera int       `json:"camera"`
+	After  time.Time `json:"after"`
+	Before time.Time `json:"before"`
+}
+
+// PhotoSearch returns search form values for selecting the photos, oldest first.
+func (f ClassifyOptions) PhotoSearch(count, offset int) PhotoSearch {
+	return PhotoSearch{
+		Query:  f.Query,
+		Label:  f.Label,
+		Album:  f.Album,
+		Camera: f.Camera,
+		After:  f.After,
+		Before: f.Before,
+		Count:  count,
+		Offset: offset,
+		Order:  "oldest",
+	}
+}
@@ -0,0 +1,24 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyOptions_PhotoSearch(t *testing.T) {
+	after := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f := ClassifyOptions{Label: "cat", Camera: 2, After: after}
+
+	result := f.PhotoSearch(500, 1000)
+
+	assert.Equal(t, "cat", result.Label)
+	assert.Equal(t, 2, result.Camera)
+	assert.Equal(t, after, result.After)
+	assert.True(t, result.Before.IsZero())
+	assert.Equal(t, 500, result.Count)
+	assert.Equal(t, 1000, result.Offset)
+	assert.Equal(t, "oldest", result.Order)
+}
@@ -0,0 +1,259 @@
+package form
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Filter represents a search filter with operators, for example -label:cat, country:de|at or iso:400-1600.
+// A filter matches if one of its values or ranges matches, negated filters match otherwise.
+type Filter struct {
+	Name   string        // Form field name, e.g. "Country"
+	Values []interface{} // Alternative values
+	Ranges []Range       // Alternative ranges
+	Negate bool
+}
+
+// Range represents a value range, nil means unbounded. Min and Max of numbers are inclusive,
+// Max of dates is exclusive, so that taken:2019-06..2019-08 includes all of August.
+type Range struct {
+	Min interface{}
+	Max interface{}
+}
+
+// Filters is a list of search filters that must all match.
+type Filters []Filter
+
+// FilterSearchForm is implemented by search forms that support filter operators.
+type FilterSearchForm interface {
+	SearchForm
+	FilterField(name string) bool
+	AddFilter(f Filter)
+}
+
+var periodRegexp = regexp.MustCompile(`^\d{4}(-\d{1,2})?$`)
+
+// HasOperator returns true if a filter value contains alternatives or a range.
+func HasOperator(field reflect.Value, value string) bool {
+	if strings.Contains(value, "|") || strings.Contains(value, "..") {
+		return true
+	}
+
+	switch field.Interface().(type) {
+	case int, int64, uint, uint64, float64:
+		return len(value) > 1 && strings.Contains(value[1:], "-")
+	}
+
+	return false
+}
+
+// IsPeriod returns true if a date filter value is a year or month, e.g. 2019 or 2019-06.
+func IsPeriod(field reflect.Value, value string) bool {
+	if _, ok := field.Interface().(time.Time); !ok {
+		return false
+	}
+
+	return periodRegexp.MatchString(value)
+}
+
+// NewFilter parses a filter value for the given form field.
+func NewFilter(field reflect.Value, name, value string, negate bool) (result Filter, err error) {
+	result = Filter{Name: name, Negate: negate}
+	key := strings.ToLower(name)
+
+	for _, s := range strings.Split(value, "|") {
+		if s == "" {
+			return result, fmt.Errorf("%s: empty value", key)
+		}
+
+		switch field.Interface().(type) {
+		case time.Time:
+			r, err := parsePeriodRange(key, s)
+
+			if err != nil {
+				return result, err
+			}
+
+			result.Ranges = append(result.Ranges, r)
+		case int, int64, uint, uint64, float64:
+			minValue, maxValue, isRange := splitRange(s)
+
+			if !isRange {
+				v, err := parseNumber(field, key, s)
+
+				if err != nil {
+					return result, err
+				}
+
+				result.Values = append(result.Values, v)
+				continue
+			}
+
+			r, err := parseNumberRange(field, key, minValue, maxValue)
+
+			if err != nil {
+				return result, err
+			}
+
+			result.Ranges = append(result.Ranges, r)
+		case string:
+			result.Values = append(result.Values, s)
+		case bool:
+			if strings.Contains(value, "|") {
+				return result, fmt.Errorf("%s: alternative values are not supported", key)
+			}
+
+			result.Values = append(result.Values, txt.Bool(s))
+		default:
+			return result, fmt.Errorf("unsupported type: %s", name)
+		}
+	}
+
+	return result, nil
+}
+
+// splitRange splits a number range like 400-1600, 1.4..2.8 or -30--20.
+func splitRange(s string) (minValue, maxValue string, ok bool) {
+	if i := strings.Index(s, ".."); i >= 0 {
+		return s[:i], s[i+2:], true
+	}
+
+	if len(s) < 2 {
+		return "", "", false
+	}
+
+	if i := strings.Index(s[1:], "-"); i >= 0 {
+		return s[:i+1], s[i+2:], true
+	}
+
+	return "", "", false
+}
+
+// parseNumber returns a number with the type of the form field.
+func parseNumber(field reflect.Value, key, s string) (interface{}, error) {
+	switch field.Interface().(type) {
+	case float64:
+		if v, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("%s: \"%s\" is not a number", key, s)
+		} else {
+			return v, nil
+		}
+	default:
+		if v, err := strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("%s: \"%s\" is not a whole number", key, s)
+		} else {
+			return v, nil
+		}
+	}
+}
+
+// parseNumberRange returns a number range, one of both bounds may be empty.
+func parseNumberRange(field reflect.Value, key, minValue, maxValue string) (result Range, err error) {
+	if minValue == "" && maxValue == "" {
+		return result, fmt.Errorf("%s: range needs at least one bound", key)
+	}
+
+	if minValue != "" {
+		if result.Min, err = parseNumber(field, key, minValue); err != nil {
+			return result, err
+		}
+	}
+
+	if maxValue != "" {
+		if result.Max, err = parseNumber(field, key, maxValue); err != nil {
+			return result, err
+		}
+	}
+
+	if result.Min != nil && result.Max != nil {
+		minFloat, _ := strconv.ParseFloat(minValue, 64)
+		maxFloat, _ := strconv.ParseFloat(maxValue, 64)
+
+		if minFloat > maxFloat {
+			return result, fmt.Errorf("%s: %s is greater than %s", key, minValue, maxValue)
+		}
+	}
+
+	return result, nil
+}
+
+// parsePeriodRange returns the time range of a date or date range like 2019, 2019-06..2019-08 or 2019-06-15..
+func parsePeriodRange(key, s string) (result Range, err error) {
+	minValue, maxValue := s, s
+
+	if i := strings.Index(s, ".."); i >= 0 {
+		minValue, maxValue = s[:i], s[i+2:]
+
+		if minValue == "" && maxValue == "" {
+			return result, fmt.Errorf("%s: range needs at least one bound", key)
+		}
+	}
+
+	if minValue != "" {
+		start, _, err := parsePeriod(key, minValue)
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Min = start
+	}
+
+	if maxValue != "" {
+		_, end, err := parsePeriod(key, maxValue)
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Max = end
+	}
+
+	if result.Min != nil && result.Max != nil && !result.Min.(time.Time).Before(result.Max.(time.Time)) {
+		return result, fmt.Errorf("%s: %s is after %s", key, minValue, maxValue)
+	}
+
+	return result, nil
+}
+
+// parsePeriod returns the start and exclusive end of a year, month, day or point in time.
+func parsePeriod(key, s string) (start, end time.Time, err error) {
+	if periodRegexp.MatchString(s) {
+		parts := strings.Split(s, "-")
+		year, _ := strconv.Atoi(parts[0])
+
+		if len(parts) == 1 {
+			start = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(1, 0, 0), nil
+		}
+
+		month, _ := strconv.Atoi(parts[1])
+
+		if month < 1 || month > 12 {
+			return start, end, fmt.Errorf("%s: \"%s\" is not a valid month", key, s)
+		}
+
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+
+		return start, start.AddDate(0, 1, 0), nil
+	}
+
+	start, err = dateparse.ParseAny(s)
+
+	if err != nil {
+		return start, end, fmt.Errorf("%s: \"%s\" is not a date", key, s)
+	}
+
+	if start.Hour() == 0 && start.Minute() == 0 && start.Second() == 0 {
+		return start, start.AddDate(0, 0, 1), nil
+	}
+
+	return start, start.Add(time.Second), nil
+}
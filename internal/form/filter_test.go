@@ -0,0 +1,104 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryString_Filters(t *testing.T) {
+	t.Run("ranges", func(t *testing.T) {
+		form := &PhotoSearch{Query: "iso:400-1600 f:1.4..2.8 lat:-30--20.5 taken:2019-06..2019-08"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, form.Filters, 4)
+		assert.Equal(t, Range{Min: 400, Max: 1600}, form.Filters[0].Ranges[0])
+		assert.Equal(t, Range{Min: 1.4, Max: 2.8}, form.Filters[1].Ranges[0])
+		assert.Equal(t, Range{Min: -30.0, Max: -20.5}, form.Filters[2].Ranges[0])
+		assert.Equal(t, time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), form.Filters[3].Ranges[0].Min)
+		assert.Equal(t, time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC), form.Filters[3].Ranges[0].Max)
+	})
+	t.Run("open range", func(t *testing.T) {
+		form := &PhotoSearch{Query: "iso:..800 year:2015.."}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Range{Max: 800}, form.Filters[0].Ranges[0])
+		assert.Equal(t, Range{Min: 2015}, form.Filters[1].Ranges[0])
+	})
+	t.Run("period", func(t *testing.T) {
+		form := &PhotoSearch{Query: "taken:2019"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Taken", form.Filters[0].Name)
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), form.Filters[0].Ranges[0].Max)
+		assert.True(t, form.Taken.IsZero())
+	})
+	t.Run("alternatives and negation", func(t *testing.T) {
+		form := &PhotoSearch{Query: "country:de|at -label:cat golden gate -favorites:true"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Filter{Name: "Country", Values: []interface{}{"de", "at"}}, form.Filters[0])
+		assert.Equal(t, Filter{Name: "Label", Values: []interface{}{"cat"}, Negate: true}, form.Filters[1])
+		assert.Equal(t, Filter{Name: "Favorites", Values: []interface{}{true}, Negate: true}, form.Filters[2])
+		assert.Equal(t, "golden gate", form.Query)
+		assert.Equal(t, "", form.Label)
+	})
+	t.Run("plain values", func(t *testing.T) {
+		form := &PhotoSearch{Query: "iso:200 f:2.8 taken:2019-06-15 lat:-21.34"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, form.Filters)
+		assert.Equal(t, 200, form.Iso)
+		assert.Equal(t, 2.8, form.F)
+		assert.Equal(t, time.Date(2019, 6, 15, 0, 0, 0, 0, time.UTC), form.Taken)
+		assert.Equal(t, -21.34, form.Lat)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		tests := map[string]string{
+			"iso:400-abc":               "iso: \"abc\" is not a whole number",
+			"iso:1600-400":              "iso: 1600 is greater than 400",
+			"f:..":                      "f: range needs at least one bound",
+			"country:de|":               "country: empty value",
+			"taken:2019-13":             "taken: \"2019-13\" is not a valid month",
+			"taken:2019-08..2019-06":    "taken: 2019-08 is after 2019-06",
+			"taken:yesterday..tomorrow": "taken: \"yesterday\" is not a date",
+			"favorites:true|false":      "favorites: alternative values are not supported",
+			"-order:newest":             "order: operators are not supported",
+			"dist:10-20":                "dist: operators are not supported",
+		}
+
+		for q, expected := range tests {
+			form := &PhotoSearch{Query: q}
+
+			err := form.ParseQueryString()
+
+			assert.EqualError(t, err, expected, q)
+		}
+	})
+}
@@ -0,0 +1,6 @@
+package form
+
+// FolderSearch represents search form fields for "/api/v1/folders".
+type FolderSearch struct {
+	Path string `form:"path"`
+}
@@ -0,0 +1,11 @@
+package form
+
+// Person represents a person edit form.
+type Person struct {
+	PersonName string `json:"PersonName"`
+}
+
+// PersonSplit represents the faces that are moved to a new person.
+type PersonSplit struct {
+	Faces []uint `json:"faces" binding:"required"`
+}
@@ -0,0 +1,28 @@
+package form
+
+// PersonSearch represents search form fields for "/api/v1/people".
+type PersonSearch struct {
+	Query  string `form:"q"`
+	ID     string `form:"id"`
+	Slug   string `form:"slug"`
+	Named  bool   `form:"named"`
+	Count  int    `form:"count" binding:"required"`
+	Offset int    `form:"offset"`
+	Order  string `form:"order"`
+}
+
+func (f *PersonSearch) GetQuery() string {
+	return f.Query
+}
+
+func (f *PersonSearch) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *PersonSearch) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewPersonSearch(query string) PersonSearch {
+	return PersonSearch{Query: query}
+}
@@ -0,0 +1,35 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersonSearchForm(t *testing.T) {
+	form := &PersonSearch{}
+
+	assert.IsType(t, new(PersonSearch), form)
+}
+
+func TestParseQueryStringPerson(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		form := &PersonSearch{Query: "slug:jens-mander named:true count:10 query:\"jens\""}
+
+		err := form.ParseQueryString()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "jens-mander", form.Slug)
+		assert.Equal(t, true, form.Named)
+		assert.Equal(t, 10, form.Count)
+		assert.Equal(t, "jens", form.Query)
+	})
+	t.Run("person filter in photo search", func(t *testing.T) {
+		form := &PhotoSearch{Query: "person:jens-mander"}
+
+		err := form.ParseQueryString()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "jens-mander", form.Person)
+	})
+}
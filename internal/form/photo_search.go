@@ -1,7 +1,10 @@
 package form
 
 import (
+	"strings"
 	"time"
+
+	"github.com/photoprism/photoprism/internal/search"
 )
 
 // PhotoSearch represents search form fields for "/api/v1/photos".
@@ -19,6 +22,8 @@ type PhotoSearch struct {
 	Lat         float64   `form:"lat"`
 	Lng         float64   `form:"lng"`
 	Dist        uint      `form:"dist"`
+	Bbox        string    `form:"bbox"`
+	Polygon     string    `form:"polygon"`
 	Fmin        float64   `form:"fmin"`
 	Fmax        float64   `form:"fmax"`
 	Chroma      uint      `form:"chroma"`
@@ -27,12 +32,27 @@ type PhotoSearch struct {
 	Location    bool      `form:"location"`
 	Album       string    `form:"album"`
 	Label       string    `form:"label"`
+	Person      string    `form:"person"`
 	Country     string    `form:"country"`
 	Year        uint      `form:"year"`
 	Month       uint      `form:"month"`
 	Color       string    `form:"color"`
 	Camera      int       `form:"camera"`
 	Lens        int       `form:"lens"`
+	Iso         int       `form:"iso"`
+	F           float64   `form:"f"`
+	Bias        float64   `form:"bias"`      // Exposure bias in EV, e.g. -0.33
+	Program     string    `form:"program"`   // Exposure program, e.g. manual or aperture
+	Metering    string    `form:"metering"`  // Metering mode, e.g. spot or pattern
+	Balance     string    `form:"balance"`   // White balance, auto or manual
+	Flash       string    `form:"flash"`     // Flash fired, yes or no
+	Flashmode   string    `form:"flashmode"` // Flash mode, on, off or auto
+	Distance    float64   `form:"distance"`  // Subject distance in meters
+	Direction   float64   `form:"direction"` // GPS image direction in degrees
+	Speed       float64   `form:"speed"`     // GPS speed in km/h
+	Software    string    `form:"software"`
+	Taken       time.Time `form:"taken" time_format:"2006-01-02"`
+	Dated       string    `form:"dated"` // Source of the date taken, e.g. meta, name, path or file
 	Before      time.Time `form:"before" time_format:"2006-01-02"`
 	After       time.Time `form:"after" time_format:"2006-01-02"`
 	Favorites   bool      `form:"favorites"`
@@ -43,6 +63,18 @@ type PhotoSearch struct {
 	Count       int       `form:"count" binding:"required"`
 	Offset      int       `form:"offset"`
 	Order       string    `form:"order"`
+	Filters     Filters   `form:"-"`
+}
+
+// filterFields contains the names of fields that support filter operators like -label:cat or iso:400-1600.
+var filterFields = map[string]bool{
+	"Title": true, "Description": true, "Notes": true, "Artist": true, "Hash": true,
+	"Duplicate": true, "Lat": true, "Lng": true, "Chroma": true, "Portrait": true,
+	"Album": true, "Label": true, "Person": true, "Country": true, "Year": true, "Month": true,
+	"Color": true, "Camera": true, "Lens": true, "Iso": true, "F": true, "Taken": true, "Dated": true,
+	"Bias": true, "Program": true, "Metering": true, "Balance": true, "Flash": true, "Flashmode": true,
+	"Distance": true, "Direction": true, "Speed": true, "Software": true,
+	"Favorites": true, "Story": true, "Nsfw": true,
 }
 
 func (f *PhotoSearch) GetQuery() string {
@@ -53,6 +85,21 @@ func (f *PhotoSearch) SetQuery(q string) {
 	f.Query = q
 }
 
+// TextField returns true if name is a full-text search field that is not a form field, optionally negated.
+func (f *PhotoSearch) TextField(name string) bool {
+	return search.Field(strings.TrimPrefix(name, "-")) != ""
+}
+
+// FilterField returns true if the field supports filter operators.
+func (f *PhotoSearch) FilterField(name string) bool {
+	return filterFields[name]
+}
+
+// AddFilter adds a filter with operators.
+func (f *PhotoSearch) AddFilter(filter Filter) {
+	f.Filters = append(f.Filters, filter)
+}
+
 func (f *PhotoSearch) ParseQueryString() error {
 	return ParseQueryString(f)
 }
@@ -142,4 +142,83 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Could not find format for \"cat\"", err.Error())
 	})
+	t.Run("full-text query", func(t *testing.T) {
+		form := &PhotoSearch{Query: "Golden Gate OR \"Bay Bridge\" -fog year:2019 place:\"san francisco\" -keywords:night"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Golden Gate OR \"Bay Bridge\" -fog place:\"san francisco\" -keywords:night", form.Query)
+		assert.Equal(t, uint(2019), form.Year)
+	})
+	t.Run("date source", func(t *testing.T) {
+		form := &PhotoSearch{Query: "dated:meta"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "meta", form.Dated)
+	})
+	t.Run("alternative date sources", func(t *testing.T) {
+		form := &PhotoSearch{Query: "dated:name|path"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", form.Dated)
+		assert.Equal(t, Filter{Name: "Dated", Values: []interface{}{"name", "path"}}, form.Filters[0])
+	})
+	t.Run("flash no", func(t *testing.T) {
+		form := &PhotoSearch{Query: "flash:no speed:30"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "no", form.Flash)
+		assert.Equal(t, 30.0, form.Speed)
+		assert.Empty(t, form.Filters)
+	})
+	t.Run("exposure settings", func(t *testing.T) {
+		form := &PhotoSearch{Query: "bias:-0.7 program:aperture metering:spot balance:manual flash:yes flashmode:auto software:lightroom"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, -0.7, form.Bias)
+		assert.Equal(t, "aperture", form.Program)
+		assert.Equal(t, "spot", form.Metering)
+		assert.Equal(t, "manual", form.Balance)
+		assert.Equal(t, "yes", form.Flash)
+		assert.Equal(t, "auto", form.Flashmode)
+		assert.Equal(t, "lightroom", form.Software)
+		assert.Empty(t, form.Filters)
+	})
+	t.Run("subject distance and direction ranges", func(t *testing.T) {
+		form := &PhotoSearch{Query: "distance:..2.5 direction:90..180 bias:-2..-1"}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Filter{Name: "Distance", Ranges: []Range{{Max: 2.5}}}, form.Filters[0])
+		assert.Equal(t, Filter{Name: "Direction", Ranges: []Range{{Min: 90.0, Max: 180.0}}}, form.Filters[1])
+		assert.Equal(t, Filter{Name: "Bias", Ranges: []Range{{Min: -2.0, Max: -1.0}}}, form.Filters[2])
+	})
 }
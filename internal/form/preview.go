@@ -0,0 +1,55 @@
+package form
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Preview represents share preview form fields for "/api/v1/preview".
+type Preview struct {
+	Query string `form:"q"`
+	Album string `form:"album"`
+	Label string `form:"label"`
+	Size  string `form:"size"`
+}
+
+// PhotoSearch returns search form values for selecting public, safe preview photos.
+func (f Preview) PhotoSearch(count int) PhotoSearch {
+	return PhotoSearch{
+		Query:  f.Query,
+		Album:  f.Album,
+		Label:  f.Label,
+		Public: true,
+		Safe:   true,
+		Count:  count,
+		Order:  "relevance",
+	}
+}
+
+// Key returns a string identifying the selected photos, independent of the preview size.
+func (f Preview) Key() string {
+	return strings.Join([]string{f.Query, f.Album, f.Label}, "|")
+}
+
+// Values returns the form as url query values, empty fields are omitted.
+func (f Preview) Values() url.Values {
+	v := url.Values{}
+
+	if f.Query != "" {
+		v.Set("q", f.Query)
+	}
+
+	if f.Album != "" {
+		v.Set("album", f.Album)
+	}
+
+	if f.Label != "" {
+		v.Set("label", f.Label)
+	}
+
+	if f.Size != "" {
+		v.Set("size", f.Size)
+	}
+
+	return v
+}
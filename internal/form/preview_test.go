@@ -0,0 +1,33 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreview_PhotoSearch(t *testing.T) {
+	f := Preview{Album: "at9lxuqxpogaaba8", Size: "twitter"}
+
+	result := f.PhotoSearch(8)
+
+	assert.Equal(t, "at9lxuqxpogaaba8", result.Album)
+	assert.Equal(t, 8, result.Count)
+	assert.True(t, result.Public)
+	assert.True(t, result.Safe)
+}
+
+func TestPreview_Key(t *testing.T) {
+	a := Preview{Label: "cat", Size: "twitter"}
+	b := Preview{Label: "cat", Size: "opengraph"}
+	c := Preview{Query: "cat"}
+
+	assert.Equal(t, a.Key(), b.Key())
+	assert.NotEqual(t, a.Key(), c.Key())
+}
+
+func TestPreview_Values(t *testing.T) {
+	f := Preview{Query: "red car", Size: "opengraph"}
+
+	assert.Equal(t, "q=red+car&size=opengraph", f.Values().Encode())
+}
@@ -19,10 +19,20 @@ type SearchForm interface {
 	SetQuery(q string)
 }
 
+// TextSearchForm is implemented by search forms that support full-text queries with multiple terms,
+// phrases, operators and field-qualified terms that are not form fields.
+type TextSearchForm interface {
+	SearchForm
+	TextField(name string) bool
+}
+
 func ParseQueryString(f SearchForm) (result error) {
-	var key, value []rune
+	var key, raw, value []rune
+	var text []string
 	var escaped, isKeyValue bool
 
+	textForm, fullText := f.(TextSearchForm)
+
 	q := f.GetQuery()
 
 	f.SetQuery("")
@@ -34,11 +44,22 @@ func ParseQueryString(f SearchForm) (result error) {
 	for _, char := range q {
 		if unicode.IsSpace(char) && !escaped {
 			if isKeyValue {
-				fieldName := strings.Title(string(key))
+				negate := strings.HasPrefix(string(key), "-")
+				fieldName := strings.Title(strings.TrimPrefix(string(key), "-"))
 				field := formValues.FieldByName(fieldName)
 				stringValue := string(value)
+				filterForm, filters := f.(FilterSearchForm)
+				filterField := filters && filterForm.FilterField(fieldName)
 
-				if field.CanSet() {
+				if filters && field.CanSet() && (negate || HasOperator(field, stringValue) || filterField && IsPeriod(field, stringValue)) {
+					if !filterField {
+						result = fmt.Errorf("%s: operators are not supported", strings.ToLower(fieldName))
+					} else if filter, err := NewFilter(field, fieldName, stringValue, negate); err != nil {
+						result = err
+					} else {
+						filterForm.AddFilter(filter)
+					}
+				} else if field.CanSet() {
 					switch field.Interface().(type) {
 					case time.Time:
 						if timeValue, err := dateparse.ParseAny(stringValue); err != nil {
@@ -71,9 +92,15 @@ func ParseQueryString(f SearchForm) (result error) {
 					default:
 						result = fmt.Errorf("unsupported type: %s", fieldName)
 					}
+				} else if fullText && textForm.TextField(string(key)) {
+					text = append(text, textTerm(string(key), stringValue))
 				} else {
 					result = fmt.Errorf("unknown filter: %s", fieldName)
 				}
+			} else if fullText {
+				if len(raw) > 0 {
+					text = append(text, string(raw))
+				}
 			} else {
 				f.SetQuery(string(key))
 			}
@@ -81,21 +108,37 @@ func ParseQueryString(f SearchForm) (result error) {
 			escaped = false
 			isKeyValue = false
 			key = key[:0]
+			raw = raw[:0]
 			value = value[:0]
 		} else if char == ':' {
 			isKeyValue = true
 		} else if char == '"' {
 			escaped = !escaped
+			raw = append(raw, char)
 		} else if isKeyValue {
 			value = append(value, unicode.ToLower(char))
 		} else {
 			key = append(key, unicode.ToLower(char))
+			raw = append(raw, char)
 		}
 	}
 
+	if len(text) > 0 {
+		f.SetQuery(strings.TrimSpace(f.GetQuery() + " " + strings.Join(text, " ")))
+	}
+
 	if result != nil {
 		log.Errorf("error while parsing search form: %s", result)
 	}
 
 	return result
 }
+
+// textTerm returns a field-qualified full-text term, values containing spaces are quoted.
+func textTerm(key, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		value = "\"" + value + "\""
+	}
+
+	return key + ":" + value
+}
@@ -0,0 +1,46 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeShift represents a batch operation that corrects the time when selected photos were taken.
+type TimeShift struct {
+	Selection
+	Offset    string `json:"offset"`    // Duration like "-1h30m" to correct a camera clock
+	TimeZone  string `json:"timezone"`  // Time zone like "Asia/Tokyo", the local time is kept
+	Reference string `json:"reference"` // Photo with the correct time to sync the selection with
+	Target    string `json:"target"`    // Selected photo taken at the same moment as the reference, default is the first
+	Sidecar   bool   `json:"sidecar"`   // Write the changed date to XMP sidecar files
+}
+
+// Empty returns true if no change was requested.
+func (f TimeShift) Empty() bool {
+	return f.Offset == "" && f.TimeZone == "" && f.Reference == ""
+}
+
+// Duration returns the offset as duration, leading plus signs are allowed.
+func (f TimeShift) Duration() (time.Duration, error) {
+	if f.Offset == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(strings.TrimPrefix(strings.TrimSpace(f.Offset), "+"))
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset \"%s\", use a duration like -1h30m", f.Offset)
+	}
+
+	return d, nil
+}
+
+// TargetPhoto returns the uuid of the photo that is synced with the reference photo.
+func (f TimeShift) TargetPhoto() string {
+	if f.Target != "" || len(f.Photos) == 0 {
+		return f.Target
+	}
+
+	return f.Photos[0]
+}
@@ -0,0 +1,46 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShift_Duration(t *testing.T) {
+	t.Run("negative", func(t *testing.T) {
+		d, err := TimeShift{Offset: "-1h30m"}.Duration()
+
+		assert.Nil(t, err)
+		assert.Equal(t, -90*time.Minute, d)
+	})
+	t.Run("plus sign", func(t *testing.T) {
+		d, err := TimeShift{Offset: "+9h"}.Duration()
+
+		assert.Nil(t, err)
+		assert.Equal(t, 9*time.Hour, d)
+	})
+	t.Run("empty", func(t *testing.T) {
+		d, err := TimeShift{}.Duration()
+
+		assert.Nil(t, err)
+		assert.Equal(t, time.Duration(0), d)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := TimeShift{Offset: "9 hours"}.Duration()
+
+		assert.EqualError(t, err, "invalid offset \"9 hours\", use a duration like -1h30m")
+	})
+}
+
+func TestTimeShift_Empty(t *testing.T) {
+	assert.True(t, TimeShift{Selection: Selection{Photos: []string{"p1"}}}.Empty())
+	assert.False(t, TimeShift{TimeZone: "Europe/Berlin"}.Empty())
+	assert.False(t, TimeShift{Reference: "p2"}.Empty())
+}
+
+func TestTimeShift_TargetPhoto(t *testing.T) {
+	assert.Equal(t, "p1", TimeShift{Selection: Selection{Photos: []string{"p1", "p3"}}}.TargetPhoto())
+	assert.Equal(t, "p3", TimeShift{Selection: Selection{Photos: []string{"p1", "p3"}}, Target: "p3"}.TargetPhoto())
+	assert.Equal(t, "", TimeShift{}.TargetPhoto())
+}
@@ -0,0 +1,171 @@
+package meta
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+)
+
+// Adjustments represents the edits of a photo stored in an Apple Photos AAE sidecar file, e.g. IMG_1234.AAE.
+type Adjustments struct {
+	Editor     string    // Bundle id of the app that edited the photo, e.g. com.apple.camera
+	Format     string    // Format of the adjustment data, e.g. com.apple.photo
+	Version    string    // Version of the adjustment data format
+	Timestamp  time.Time // Time of the last edit
+	Names      []string  // Enabled adjustments, e.g. SmartTone or Crop
+	Effect     string    // Name of a filter effect, e.g. 3DNoir
+	Width      int       // Width of the original image
+	Height     int       // Height of the original image
+	Crop       bool
+	CropX      int
+	CropY      int
+	CropWidth  int
+	CropHeight int
+}
+
+// aaeJson represents the adjustment data of photos edited with Apple Photos.
+type aaeJson struct {
+	Metadata struct {
+		Width  int `json:"masterWidth"`
+		Height int `json:"masterHeight"`
+	} `json:"metadata"`
+	Adjustments []struct {
+		Identifier string `json:"identifier"`
+		Enabled    bool   `json:"enabled"`
+		Settings   struct {
+			EffectName string  `json:"effectName"`
+			X          float64 `json:"xOrigin"`
+			Y          float64 `json:"yOrigin"`
+			Width      float64 `json:"width"`
+			Height     float64 `json:"height"`
+		} `json:"settings"`
+	} `json:"adjustments"`
+}
+
+// AAE parses an Apple Photos AAE sidecar file and returns the adjustments. The adjustment data of apps
+// other than Apple Photos is opaque, so only the editor, format and timestamp are returned in this case.
+func AAE(filename string) (result Adjustments, err error) {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer f.Close()
+
+	values, err := plistDict(f)
+
+	if err != nil {
+		return result, fmt.Errorf("meta: invalid aae file (%s)", err)
+	}
+
+	if values["adjustmentFormatIdentifier"] == "" {
+		return result, fmt.Errorf("meta: %s is not an aae file", filename)
+	}
+
+	result.Editor = values["adjustmentEditorBundleID"]
+	result.Format = values["adjustmentFormatIdentifier"]
+	result.Version = values["adjustmentFormatVersion"]
+
+	if t, err := time.Parse(time.RFC3339, values["adjustmentTimestamp"]); err == nil {
+		result.Timestamp = t
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(values["adjustmentData"]), ""))
+
+	if err != nil || len(data) == 0 {
+		return result, nil
+	}
+
+	// Recent versions compress the adjustment data with deflate.
+	if inflated, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(data))); err == nil {
+		data = inflated
+	}
+
+	var adj aaeJson
+
+	if err := json.Unmarshal(data, &adj); err != nil {
+		return result, nil
+	}
+
+	result.Width = adj.Metadata.Width
+	result.Height = adj.Metadata.Height
+
+	for _, a := range adj.Adjustments {
+		if !a.Enabled || a.Identifier == "" {
+			continue
+		}
+
+		result.Names = append(result.Names, a.Identifier)
+
+		switch a.Identifier {
+		case "Effect":
+			result.Effect = a.Settings.EffectName
+		case "Crop":
+			result.Crop = true
+			result.CropX = int(a.Settings.X)
+			result.CropY = int(a.Settings.Y)
+			result.CropWidth = int(a.Settings.Width)
+			result.CropHeight = int(a.Settings.Height)
+		}
+	}
+
+	return result, nil
+}
+
+// plistDict returns the values of the top level dictionary in an XML property list as text.
+// Booleans are returned as "true" or "false", nested dictionaries and arrays are not supported.
+func plistDict(r io.Reader) (map[string]string, error) {
+	d := xml.NewDecoder(r)
+	values := make(map[string]string)
+	inside := false
+	key := ""
+
+	for {
+		token, err := d.Token()
+
+		if err == io.EOF {
+			return values, nil
+		} else if err != nil {
+			return values, err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		switch {
+		case !inside:
+			inside = start.Name.Local == "dict"
+		case start.Name.Local == "key":
+			if err := d.DecodeElement(&key, &start); err != nil {
+				return values, err
+			}
+		default:
+			var value struct {
+				Text string `xml:",chardata"`
+			}
+
+			if err := d.DecodeElement(&value, &start); err != nil {
+				return values, err
+			}
+
+			switch start.Name.Local {
+			case "true", "false":
+				values[key] = start.Name.Local
+			default:
+				values[key] = strings.TrimSpace(value.Text)
+			}
+		}
+	}
+}
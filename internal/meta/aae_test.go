@@ -0,0 +1,43 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAAE(t *testing.T) {
+	t.Run("IMG_4120.AAE", func(t *testing.T) {
+		adj, err := AAE("testdata/IMG_4120.AAE")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "com.apple.camera", adj.Editor)
+		assert.Equal(t, "com.apple.photo", adj.Format)
+		assert.Equal(t, "1.4", adj.Version)
+		assert.Equal(t, "2019-06-09 10:59:22", adj.Timestamp.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, []string{"Effect", "SmartTone", "SmartColor", "Crop"}, adj.Names)
+		assert.Equal(t, "3DNoir", adj.Effect)
+		assert.Equal(t, 4032, adj.Width)
+		assert.Equal(t, 3024, adj.Height)
+		assert.True(t, adj.Crop)
+		assert.Equal(t, 781, adj.CropX)
+		assert.Equal(t, 0, adj.CropY)
+		assert.Equal(t, 3024, adj.CropWidth)
+		assert.Equal(t, 3024, adj.CropHeight)
+	})
+
+	t.Run("not an aae file", func(t *testing.T) {
+		_, err := AAE("testdata/photoshop.xmp")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		_, err := AAE("testdata/missing.aae")
+
+		assert.Error(t, err)
+	})
+}
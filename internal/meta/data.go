@@ -1,12 +1,15 @@
 package meta
 
 import (
+	"reflect"
 	"time"
 )
 
 // Data represents image meta data.
 type Data struct {
 	UniqueID     string
+	BurstID      string
+	Bracket      bool
 	TakenAt      time.Time
 	TakenAtLocal time.Time
 	TimeZone     string
@@ -14,21 +17,60 @@ type Data struct {
 	Artist       string
 	Description  string
 	Copyright    string
+	Favorite     bool
 	CameraMake   string
 	CameraModel  string
 	LensMake     string
 	LensModel    string
+	Software     string
 	Flash        bool
+	FlashMode    string
 	FocalLength  int
 	Exposure     string
+	ExposureBias float64
+	Program      string
+	Metering     string
+	WhiteBalance string
+	Distance     float64 // Subject distance in meters
 	Aperture     float64
 	FNumber      float64
 	Iso          int
 	Lat          float64
 	Lng          float64
 	Altitude     int
+	Direction    float64 // GPS image direction in degrees
+	GPSSpeed     float64 // GPS speed in km/h
+	GPSTime      time.Time
 	Width        int
 	Height       int
 	Orientation  int
 	All          map[string]string
 }
+
+// Merge fills empty fields with values from other meta data, so that the receiver takes precedence.
+func (data *Data) Merge(other Data) {
+	dst := reflect.ValueOf(data).Elem()
+	src := reflect.ValueOf(other)
+
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Field(i)
+
+		if field.Kind() == reflect.Map {
+			continue
+		}
+
+		if reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+			field.Set(src.Field(i))
+		}
+	}
+
+	if data.All == nil {
+		data.All = make(map[string]string, len(other.All))
+	}
+
+	for key, value := range other.All {
+		if _, ok := data.All[key]; !ok {
+			data.All[key] = value
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_Merge(t *testing.T) {
+	data := Data{CameraMake: "Apple", Iso: 20, All: map[string]string{"Make": "Apple"}}
+	native := Data{CameraMake: "Canon", CameraModel: "EOS 6D", Iso: 100, Flash: true, All: map[string]string{"Make": "Canon", "Model": "EOS 6D"}}
+
+	data.Merge(native)
+
+	assert.Equal(t, "Apple", data.CameraMake)
+	assert.Equal(t, "EOS 6D", data.CameraModel)
+	assert.Equal(t, 20, data.Iso)
+	assert.Equal(t, true, data.Flash)
+	assert.Equal(t, map[string]string{"Make": "Apple", "Model": "EOS 6D"}, data.All)
+}
@@ -24,51 +24,10 @@ func Exif(filename string) (data Data, err error) {
 		}
 	}()
 
-	// Extract raw EXIF block.
+	rawExif, err := RawExif(filename)
 
-	var rawExif []byte
-
-	fileExtension := path.Ext(filename)
-	fileExtension = strings.ToLower(fileExtension)
-
-	if fileExtension == ".jpg" || fileExtension == ".jpeg" {
-		jmp := jpegstructure.NewJpegMediaParser()
-
-		sl, err := jmp.ParseFile(filename)
-
-		if err != nil {
-			return data, err
-		}
-
-		_, rawExif, err = sl.Exif()
-
-		if err != nil {
-			return data, err
-		}
-	} else if fileExtension == ".png" {
-		pmp := pngstructure.NewPngMediaParser()
-
-		cs, err := pmp.ParseFile(filename)
-
-		if err != nil {
-			return data, err
-		}
-
-		_, rawExif, err = cs.Exif()
-
-		if err != nil {
-			return data, err
-		}
-	} else {
-		// Fallback to an optimistic, brute-force search.
-
-		var err error
-
-		rawExif, err = exif.SearchFileAndExtractExif(filename)
-
-		if err != nil {
-			return data, err
-		}
+	if err != nil {
+		return data, err
 	}
 
 	// Enumerate tags in EXIF block.
@@ -191,10 +150,66 @@ func Exif(filename string) (data Data, err error) {
 		}
 	}
 
+	if value, ok := tags["ExposureBiasValue"]; ok {
+		if f, ok := rational(value); ok {
+			data.ExposureBias = math.Round(f*100) / 100
+		}
+	}
+
+	if value, ok := tags["ExposureProgram"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Program = ExposureProgram(i)
+		}
+	}
+
+	if value, ok := tags["MeteringMode"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Metering = MeteringMode(i)
+		}
+	}
+
+	if value, ok := tags["WhiteBalance"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.WhiteBalance = WhiteBalance(i)
+		}
+	}
+
+	if value, ok := tags["SubjectDistance"]; ok {
+		// 0xFFFFFFFF means infinity.
+		if f, ok := rational(value); ok && f < math.MaxUint32 {
+			data.Distance = math.Round(f*100) / 100
+		}
+	}
+
+	if value, ok := tags["GPSImgDirection"]; ok {
+		if f, ok := rational(value); ok {
+			data.Direction = math.Round(f*100) / 100
+		}
+	}
+
+	if value, ok := tags["GPSSpeed"]; ok {
+		if f, ok := rational(value); ok {
+			data.GPSSpeed = GPSSpeed(f, tags["GPSSpeedRef"])
+		}
+	}
+
+	if value, ok := tags["Software"]; ok {
+		data.Software = strings.Replace(value, "\"", "", -1)
+	}
+
 	if value, ok := tags["ImageUniqueID"]; ok {
 		data.UniqueID = value
 	}
 
+	if value, ok := tags["BurstUUID"]; ok {
+		data.BurstID = value
+	}
+
+	if value, ok := tags["ExposureMode"]; ok {
+		// 2 = auto bracket
+		data.Bracket = value == "2"
+	}
+
 	if value, ok := tags["ImageWidth"]; ok {
 		if i, err := strconv.Atoi(value); err == nil {
 			data.Width = i
@@ -226,6 +241,7 @@ func Exif(filename string) (data Data, err error) {
 			data.Lat = gi.Latitude.Decimal()
 			data.Lng = gi.Longitude.Decimal()
 			data.Altitude = gi.Altitude
+			data.GPSTime = gi.Timestamp
 		}
 	}
 
@@ -258,8 +274,9 @@ func Exif(filename string) (data Data, err error) {
 	}
 
 	if value, ok := tags["Flash"]; ok {
-		if i, err := strconv.Atoi(value); err == nil && i&1 == 1 {
-			data.Flash = true
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Flash = i&1 == 1
+			data.FlashMode = FlashMode(i)
 		}
 	}
 
@@ -271,3 +288,60 @@ func Exif(filename string) (data Data, err error) {
 
 	return data, nil
 }
+
+// RawExif returns the raw Exif block of an image file.
+func RawExif(filename string) (rawExif []byte, err error) {
+	fileExtension := strings.ToLower(path.Ext(filename))
+
+	if fileExtension == ".jpg" || fileExtension == ".jpeg" {
+		jmp := jpegstructure.NewJpegMediaParser()
+
+		sl, err := jmp.ParseFile(filename)
+
+		if err != nil {
+			return rawExif, err
+		}
+
+		_, rawExif, err = sl.Exif()
+
+		return rawExif, err
+	} else if fileExtension == ".png" {
+		pmp := pngstructure.NewPngMediaParser()
+
+		cs, err := pmp.ParseFile(filename)
+
+		if err != nil {
+			return rawExif, err
+		}
+
+		_, rawExif, err = cs.Exif()
+
+		return rawExif, err
+	}
+
+	// Fallback to an optimistic, brute-force search.
+	return exif.SearchFileAndExtractExif(filename)
+}
+
+// rational parses an Exif rational value like "-1/3" and returns it as float.
+func rational(value string) (float64, bool) {
+	values := strings.Split(value, "/")
+
+	if len(values) != 2 || values[1] == "0" || values[1] == "" {
+		return 0, false
+	}
+
+	number, err := strconv.ParseFloat(values[0], 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	denom, err := strconv.ParseFloat(values[1], 64)
+
+	if err != nil {
+		return 0, false
+	}
+
+	return number / denom, true
+}
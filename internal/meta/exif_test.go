@@ -28,6 +28,11 @@ func TestExif(t *testing.T) {
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, 27, data.FocalLength)
 		assert.Equal(t, 1, int(data.Orientation))
+		assert.Equal(t, "normal", data.Program)
+		assert.Equal(t, "pattern", data.Metering)
+		assert.Equal(t, "auto", data.WhiteBalance)
+		assert.Equal(t, "Adobe Photoshop 21.0 (Macintosh)", data.Software)
+		assert.Equal(t, "2020-01-01 16:28:22", data.GPSTime.Format("2006-01-02 15:04:05"))
 
 		// TODO: Values are empty - why?
 		// assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
@@ -57,6 +62,26 @@ func TestExif(t *testing.T) {
 		assert.Equal(t, 6, int(data.Orientation))
 		assert.Equal(t, "Apple", data.LensMake)
 		assert.Equal(t, "iPhone 7 back camera 3.99mm f/1.8", data.LensModel)
+		assert.Equal(t, "off", data.FlashMode)
+		assert.Equal(t, "ProCam 10.5.8", data.Software)
+	})
+}
+
+func TestRational(t *testing.T) {
+	t.Run("negative", func(t *testing.T) {
+		f, ok := rational("-1/3")
+
+		assert.True(t, ok)
+		assert.InDelta(t, -0.333, f, 0.001)
+	})
+	t.Run("zero denominator", func(t *testing.T) {
+		_, ok := rational("1/0")
+
+		assert.False(t, ok)
+	})
+	t.Run("no fraction", func(t *testing.T) {
+		_, ok := rational("1")
 
+		assert.False(t, ok)
 	})
 }
@@ -0,0 +1,272 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/ugjka/go-tz.v2/tz"
+)
+
+// dateLayout is the date format used by Exif and ExifTool.
+const dateLayout = "2006:01:02 15:04:05"
+
+// jsonValues represents the tags of a single file as returned by "exiftool -n -j".
+type jsonValues map[string]interface{}
+
+// JSON parses a file created by "exiftool -n -j" and returns the meta data of the first file it contains.
+func JSON(jsonName string) (data Data, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			data = Data{}
+			err = fmt.Errorf("meta: %s", e)
+		}
+	}()
+
+	b, err := ioutil.ReadFile(jsonName)
+
+	if err != nil {
+		return data, err
+	}
+
+	var files []jsonValues
+
+	if err := json.Unmarshal(b, &files); err != nil {
+		return data, fmt.Errorf("meta: invalid exiftool json (%s)", err)
+	}
+
+	if len(files) == 0 {
+		return data, fmt.Errorf("meta: exiftool json is empty (%s)", jsonName)
+	}
+
+	v := files[0]
+
+	if msg := v.String("Error"); msg != "" {
+		return data, fmt.Errorf("meta: %s", msg)
+	}
+
+	data.UniqueID = v.String("ImageUniqueID")
+	data.BurstID = v.String("BurstUUID")
+	data.Bracket = v.Int("ExposureMode") == 2
+	data.Title = v.String("Title")
+	data.Artist = v.String("Artist", "Creator")
+	data.Description = v.String("ImageDescription", "Description")
+	data.Copyright = v.String("Copyright", "Rights")
+	data.CameraMake = v.String("Make")
+	data.CameraModel = v.String("Model")
+	data.LensMake = v.String("LensMake")
+	data.LensModel = v.String("LensModel", "Lens")
+	data.Software = v.String("Software", "FirmwareVersion", "Firmware")
+	data.Flash = v.Int("Flash")&1 == 1
+	data.FlashMode = FlashMode(v.Int("Flash"))
+	data.FocalLength = v.Int("FocalLengthIn35mmFormat", "FocalLength")
+	data.Exposure = exposureTime(v.Float("ExposureTime"))
+	data.ExposureBias = math.Round(v.Float("ExposureCompensation", "ExposureBiasValue")*100) / 100
+	data.Program = ExposureProgram(v.Int("ExposureProgram"))
+	data.Metering = MeteringMode(v.Int("MeteringMode"))
+	data.Distance = v.Distance()
+	data.Aperture = math.Round(v.Float("ApertureValue")*1000) / 1000
+	data.FNumber = math.Round(v.Float("FNumber")*1000) / 1000
+	data.Iso = v.Int("ISO")
+	data.Direction = math.Round(v.Float("GPSImgDirection")*100) / 100
+	data.GPSSpeed = GPSSpeed(v.Float("GPSSpeed"), v.String("GPSSpeedRef"))
+	data.GPSTime = v.GPSTime()
+	data.Altitude = int(v.Float("GPSAltitude"))
+	data.Width = v.Int("ImageWidth")
+	data.Height = v.Int("ImageHeight")
+	data.Orientation = v.Int("Orientation")
+	data.Lat, data.Lng = v.Position()
+
+	if _, ok := v["WhiteBalance"]; ok {
+		data.WhiteBalance = WhiteBalance(v.Int("WhiteBalance"))
+	}
+
+	if data.Lat != 0 || data.Lng != 0 {
+		if zones, err := tz.GetZone(tz.Point{Lat: data.Lat, Lon: data.Lng}); err == nil && len(zones) > 0 {
+			data.TimeZone = zones[0]
+		}
+	}
+
+	data.TakenAtLocal, data.TakenAt = v.Taken(data.TimeZone)
+
+	data.All = make(map[string]string, len(v))
+
+	for key := range v {
+		data.All[key] = v.String(key)
+	}
+
+	return data, nil
+}
+
+// String returns the first non-empty value of the given tags as string.
+func (v jsonValues) String(tags ...string) string {
+	for _, tag := range tags {
+		switch val := v[tag].(type) {
+		case string:
+			if s := strings.TrimSpace(val); s != "" {
+				return s
+			}
+		case float64:
+			return strconv.FormatFloat(val, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(val)
+		case nil:
+			continue
+		default:
+			if b, err := json.Marshal(val); err == nil {
+				return string(b)
+			}
+		}
+	}
+
+	return ""
+}
+
+// Float returns the first numeric value of the given tags.
+func (v jsonValues) Float(tags ...string) float64 {
+	for _, tag := range tags {
+		switch val := v[tag].(type) {
+		case float64:
+			return val
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+				return f
+			}
+		}
+	}
+
+	return 0
+}
+
+// Int returns the first numeric value of the given tags as int.
+func (v jsonValues) Int(tags ...string) int {
+	return int(math.Round(v.Float(tags...)))
+}
+
+// Position returns the GPS position in decimal degrees.
+func (v jsonValues) Position() (lat, lng float64) {
+	if pos := strings.Fields(v.String("GPSPosition")); len(pos) == 2 {
+		lat, _ = strconv.ParseFloat(pos[0], 64)
+		lng, _ = strconv.ParseFloat(pos[1], 64)
+
+		return lat, lng
+	}
+
+	lat = math.Abs(v.Float("GPSLatitude"))
+	lng = math.Abs(v.Float("GPSLongitude"))
+
+	if strings.HasPrefix(strings.ToUpper(v.String("GPSLatitudeRef")), "S") {
+		lat = -lat
+	}
+
+	if strings.HasPrefix(strings.ToUpper(v.String("GPSLongitudeRef")), "W") {
+		lng = -lng
+	}
+
+	return lat, lng
+}
+
+// Distance returns the subject distance in meters, or 0 if it is unknown or infinite.
+func (v jsonValues) Distance() float64 {
+	d := v.Float("SubjectDistance")
+
+	if d <= 0 || d >= math.MaxUint32 {
+		return 0
+	}
+
+	return math.Round(d*100) / 100
+}
+
+// GPSTime returns the UTC time of the GPS fix.
+func (v jsonValues) GPSTime() time.Time {
+	if value := v.date("GPSDateTime"); value != "" {
+		if t, err := time.Parse(dateLayout, value); err == nil {
+			return t
+		}
+	}
+
+	date, clock := v.String("GPSDateStamp"), v.String("GPSTimeStamp")
+
+	if len(date) < 10 || len(clock) < 8 {
+		return time.Time{}
+	}
+
+	if t, err := time.Parse(dateLayout, date[:10]+" "+clock[:8]); err == nil {
+		return t
+	}
+
+	return time.Time{}
+}
+
+// Taken returns the local and UTC time when the photo or video was taken. Video creation dates
+// are stored in UTC by QuickTime, image dates are local time with an optional offset.
+func (v jsonValues) Taken(timeZone string) (local, utc time.Time) {
+	loc, err := time.LoadLocation(timeZone)
+
+	if timeZone == "" || err != nil {
+		loc = nil
+	}
+
+	if value := v.date("DateTimeOriginal", "CreateDate"); value != "" {
+		if local, err = time.Parse(dateLayout, value); err != nil {
+			return local, utc
+		}
+
+		if offset := v.String("OffsetTimeOriginal", "OffsetTime"); offset != "" {
+			if t, err := time.Parse(dateLayout+"-07:00", value+offset); err == nil {
+				return local, t.UTC()
+			}
+		}
+
+		if loc != nil {
+			if t, err := time.ParseInLocation(dateLayout, value, loc); err == nil {
+				return local, t.UTC()
+			}
+		}
+
+		return local, local
+	}
+
+	if value := v.date("MediaCreateDate", "TrackCreateDate"); value != "" {
+		if utc, err = time.Parse(dateLayout, value); err != nil {
+			return local, utc
+		}
+
+		if loc != nil {
+			t := utc.In(loc)
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), utc
+		}
+
+		return utc, utc
+	}
+
+	return local, utc
+}
+
+// date returns the first valid date of the given tags without sub seconds and offset.
+func (v jsonValues) date(tags ...string) string {
+	for _, tag := range tags {
+		if value := v.String(tag); len(value) >= len(dateLayout) && !strings.HasPrefix(value, "0000") {
+			return value[:len(dateLayout)]
+		}
+	}
+
+	return ""
+}
+
+// exposureTime returns an exposure time in seconds as fraction, e.g. 1/250.
+func exposureTime(seconds float64) string {
+	if seconds <= 0 {
+		return ""
+	}
+
+	if seconds >= 1 {
+		return strconv.FormatFloat(seconds, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("1/%d", int(math.Round(1/seconds)))
+}
@@ -0,0 +1,128 @@
+package meta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSON(t *testing.T) {
+	t.Run("iphone_7.json", func(t *testing.T) {
+		data, err := JSON("testdata/iphone_7.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Apple", data.CameraMake)
+		assert.Equal(t, "iPhone 7", data.CameraModel)
+		assert.Equal(t, "Apple", data.LensMake)
+		assert.Equal(t, "iPhone 7 back camera 3.99mm f/1.8", data.LensModel)
+		assert.Equal(t, "Asia/Tokyo", data.TimeZone)
+		assert.Equal(t, "2018-09-10T03:16:13Z", data.TakenAtLocal.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "2018-09-09T18:16:13Z", data.TakenAt.Format("2006-01-02T15:04:05Z"))
+		assert.Equal(t, "1/1828", data.Exposure)
+		assert.Equal(t, 1.8, data.FNumber)
+		assert.Equal(t, 1.8, data.Aperture)
+		assert.Equal(t, 20, data.Iso)
+		assert.Equal(t, 28, data.FocalLength)
+		assert.Equal(t, false, data.Flash)
+		assert.Equal(t, "auto", data.FlashMode)
+		assert.Equal(t, false, data.Bracket)
+		assert.Equal(t, -0.33, data.ExposureBias)
+		assert.Equal(t, "normal", data.Program)
+		assert.Equal(t, "pattern", data.Metering)
+		assert.Equal(t, "auto", data.WhiteBalance)
+		assert.Equal(t, 0.0, data.Distance)
+		assert.Equal(t, 215.03, data.Direction)
+		assert.Equal(t, 4.2, data.GPSSpeed)
+		assert.Equal(t, "2018-09-09 18:16:12", data.GPSTime.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, "11.1.2", data.Software)
+		assert.Equal(t, 34.7974583333333, data.Lat)
+		assert.Equal(t, 134.765258333333, data.Lng)
+		assert.Equal(t, 8, data.Altitude)
+		assert.Equal(t, 4032, data.Width)
+		assert.Equal(t, 3024, data.Height)
+		assert.Equal(t, 1, data.Orientation)
+		assert.Equal(t, "HEIC", data.All["FileType"])
+		assert.Equal(t, "11.88", data.All["ExifToolVersion"])
+	})
+	t.Run("file not found", func(t *testing.T) {
+		_, err := JSON("testdata/missing.json")
+
+		assert.Error(t, err)
+	})
+	t.Run("exiftool error", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "meta")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		jsonName := filepath.Join(dir, "error.json")
+
+		if err := ioutil.WriteFile(jsonName, []byte(`[{"SourceFile": "x.jpg", "Error": "File format error"}]`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = JSON(jsonName)
+
+		assert.EqualError(t, err, "meta: File format error")
+	})
+}
+
+func TestJsonValues_Taken(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		v := jsonValues{"DateTimeOriginal": "2020:01:01 17:28:23.123", "OffsetTimeOriginal": "+01:00"}
+		local, utc := v.Taken("")
+
+		assert.Equal(t, "2020-01-01 17:28:23", local.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, "2020-01-01 16:28:23", utc.Format("2006-01-02 15:04:05"))
+	})
+	t.Run("video in utc", func(t *testing.T) {
+		v := jsonValues{"MediaCreateDate": "2020:01:01 16:28:23"}
+		local, utc := v.Taken("Europe/Berlin")
+
+		assert.Equal(t, "2020-01-01 17:28:23", local.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, "2020-01-01 16:28:23", utc.Format("2006-01-02 15:04:05"))
+	})
+	t.Run("empty date", func(t *testing.T) {
+		v := jsonValues{"DateTimeOriginal": "0000:00:00 00:00:00"}
+		local, utc := v.Taken("")
+
+		assert.True(t, local.IsZero())
+		assert.True(t, utc.IsZero())
+	})
+}
+
+func TestJsonValues_GPSTime(t *testing.T) {
+	t.Run("composite", func(t *testing.T) {
+		v := jsonValues{"GPSDateTime": "2019:06:12 08:15:00Z"}
+
+		assert.Equal(t, "2019-06-12 08:15:00", v.GPSTime().Format("2006-01-02 15:04:05"))
+	})
+	t.Run("missing", func(t *testing.T) {
+		v := jsonValues{"GPSTimeStamp": "08:15:00"}
+
+		assert.True(t, v.GPSTime().IsZero())
+	})
+}
+
+func TestJsonValues_Distance(t *testing.T) {
+	assert.Equal(t, 2.5, jsonValues{"SubjectDistance": 2.5}.Distance())
+	assert.Equal(t, 0.0, jsonValues{"SubjectDistance": 4294967295.0}.Distance())
+	assert.Equal(t, 0.0, jsonValues{}.Distance())
+}
+
+func TestJsonValues_Position(t *testing.T) {
+	v := jsonValues{"GPSLatitude": 33.86, "GPSLatitudeRef": "S", "GPSLongitude": 151.21, "GPSLongitudeRef": "E"}
+	lat, lng := v.Position()
+
+	assert.Equal(t, -33.86, lat)
+	assert.Equal(t, 151.21, lng)
+}
@@ -0,0 +1,78 @@
+package meta
+
+import (
+	"math"
+	"strings"
+)
+
+// exposurePrograms maps Exif ExposureProgram values to names, 0 is not defined.
+var exposurePrograms = map[int]string{
+	1: "manual",
+	2: "normal",
+	3: "aperture",
+	4: "shutter",
+	5: "creative",
+	6: "action",
+	7: "portrait",
+	8: "landscape",
+}
+
+// meteringModes maps Exif MeteringMode values to names, 0 is unknown.
+var meteringModes = map[int]string{
+	1:   "average",
+	2:   "center",
+	3:   "spot",
+	4:   "multi-spot",
+	5:   "pattern",
+	6:   "partial",
+	255: "other",
+}
+
+// whiteBalances maps Exif WhiteBalance values to names.
+var whiteBalances = map[int]string{
+	0: "auto",
+	1: "manual",
+}
+
+// flashModes maps bits 3 and 4 of the Exif Flash value to names, 0 is unknown.
+var flashModes = map[int]string{
+	1: "on",
+	2: "off",
+	3: "auto",
+}
+
+// ExposureProgram returns the name of an Exif exposure program, e.g. "aperture" for aperture priority.
+func ExposureProgram(value int) string {
+	return exposurePrograms[value]
+}
+
+// MeteringMode returns the name of an Exif metering mode, e.g. "spot".
+func MeteringMode(value int) string {
+	return meteringModes[value]
+}
+
+// WhiteBalance returns the name of an Exif white balance mode, either "auto" or "manual".
+func WhiteBalance(value int) string {
+	return whiteBalances[value]
+}
+
+// FlashMode returns the name of the flash mode contained in an Exif flash value, e.g. "auto".
+func FlashMode(value int) string {
+	return flashModes[(value>>3)&3]
+}
+
+// GPSSpeed returns a GPS speed in km/h, the Exif speed ref can be K for km/h, M for mph or N for knots.
+func GPSSpeed(value float64, ref string) float64 {
+	if value <= 0 {
+		return 0
+	}
+
+	switch strings.ToUpper(strings.Trim(ref, "\" ")) {
+	case "M":
+		value *= 1.609344
+	case "N":
+		value *= 1.852
+	}
+
+	return math.Round(value*100) / 100
+}
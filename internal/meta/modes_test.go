@@ -0,0 +1,59 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExposureProgram(t *testing.T) {
+	assert.Equal(t, "aperture", ExposureProgram(3))
+	assert.Equal(t, "", ExposureProgram(0))
+	assert.Equal(t, "", ExposureProgram(42))
+}
+
+func TestMeteringMode(t *testing.T) {
+	assert.Equal(t, "pattern", MeteringMode(5))
+	assert.Equal(t, "other", MeteringMode(255))
+	assert.Equal(t, "", MeteringMode(0))
+}
+
+func TestWhiteBalance(t *testing.T) {
+	assert.Equal(t, "auto", WhiteBalance(0))
+	assert.Equal(t, "manual", WhiteBalance(1))
+	assert.Equal(t, "", WhiteBalance(2))
+}
+
+func TestFlashMode(t *testing.T) {
+	t.Run("auto fired", func(t *testing.T) {
+		assert.Equal(t, "auto", FlashMode(0x19))
+	})
+	t.Run("compulsory", func(t *testing.T) {
+		assert.Equal(t, "on", FlashMode(0x9))
+	})
+	t.Run("off", func(t *testing.T) {
+		assert.Equal(t, "off", FlashMode(0x10))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, "", FlashMode(0))
+	})
+}
+
+func TestGPSSpeed(t *testing.T) {
+	t.Run("kmh", func(t *testing.T) {
+		assert.Equal(t, 12.5, GPSSpeed(12.5, "K"))
+	})
+	t.Run("mph", func(t *testing.T) {
+		assert.Equal(t, 16.09, GPSSpeed(10, "M"))
+		assert.Equal(t, 16.09, GPSSpeed(10, "\"M\""))
+	})
+	t.Run("knots", func(t *testing.T) {
+		assert.Equal(t, 3.7, GPSSpeed(2, "N"))
+	})
+	t.Run("no ref", func(t *testing.T) {
+		assert.Equal(t, 2.0, GPSSpeed(2, ""))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Equal(t, 0.0, GPSSpeed(-1, "K"))
+	})
+}
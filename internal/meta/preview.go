@@ -0,0 +1,443 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// PreviewMaxSize is the maximum size of an embedded JPEG preview in bytes.
+const PreviewMaxSize = 64 * 1024 * 1024
+
+// Maximum number of IFDs and TIFF entries read, so that broken files can't cause endless loops.
+const (
+	tiffMaxIfds    = 64
+	tiffMaxEntries = 1024
+)
+
+var (
+	rafHeader    = []byte("FUJIFILMCCD-RAW")
+	olympusNote  = []byte("OLYMPUS\x00")
+	canonPreview = []byte{0xea, 0xf4, 0x2b, 0x5e, 0x1c, 0x98, 0x4b, 0x88, 0xb9, 0xfb, 0xb7, 0xdc, 0x40, 0x6e, 0x4d, 0x16}
+)
+
+// previewRange represents the location of a possible JPEG preview in a RAW file.
+type previewRange struct {
+	Offset int64
+	Length int64
+}
+
+// Preview returns the largest JPEG image embedded in a RAW file, e.g. a CR2, NEF, ARW, DNG, ORF, RW2, RAF or CR3 file.
+func Preview(fileName string) ([]byte, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	size := info.Size()
+	header := make([]byte, 16)
+
+	if _, err := f.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("meta: can't read %s (%s)", filepath.Base(fileName), err)
+	}
+
+	var ranges []previewRange
+
+	switch {
+	case bytes.HasPrefix(header, rafHeader):
+		ranges = rafPreviews(f)
+	case string(header[4:8]) == "ftyp":
+		ranges = bmffPreviews(f, size)
+	default:
+		ranges = tiffPreviews(f, size)
+	}
+
+	var result []byte
+	var pixels int
+
+	for _, r := range ranges {
+		if r.Offset <= 0 || r.Length <= 0 || r.Length > PreviewMaxSize || r.Offset+r.Length > size {
+			continue
+		}
+
+		data := make([]byte, r.Length)
+
+		if _, err := f.ReadAt(data, r.Offset); err != nil {
+			continue
+		}
+
+		// Lossless JPEG compressed raw data can't be decoded and is skipped.
+		if cfg, err := jpeg.DecodeConfig(bytes.NewReader(data)); err == nil && cfg.Width*cfg.Height > pixels {
+			result = data
+			pixels = cfg.Width * cfg.Height
+		}
+	}
+
+	if result == nil {
+		return nil, fmt.Errorf("meta: no jpeg preview found in %s", filepath.Base(fileName))
+	}
+
+	return result, nil
+}
+
+// rafPreviews returns the JPEG preview of a Fujifilm RAF file, its offset and length are stored in the header.
+func rafPreviews(r io.ReaderAt) []previewRange {
+	b := make([]byte, 8)
+
+	if _, err := r.ReadAt(b, 84); err != nil {
+		return nil
+	}
+
+	return []previewRange{{Offset: int64(binary.BigEndian.Uint32(b[0:4])), Length: int64(binary.BigEndian.Uint32(b[4:8]))}}
+}
+
+// bmffPreviews returns the JPEG images of a Canon CR3 file, which is based on the ISO base media file format:
+// the medium size PRVW image and the first sample of each track, one of them is a full size JPEG.
+func bmffPreviews(r io.ReaderAt, size int64) (result []previewRange) {
+	bmffBoxes(r, 0, size, func(boxType string, offset, length int64) {
+		switch boxType {
+		case "uuid":
+			id := make([]byte, 16)
+
+			if _, err := r.ReadAt(id, offset); err != nil || !bytes.Equal(id, canonPreview) {
+				return
+			}
+
+			bmffBoxes(r, offset+24, offset+length, func(boxType string, offset, length int64) {
+				if boxType == "PRVW" && length > 16 {
+					b := make([]byte, 4)
+
+					if _, err := r.ReadAt(b, offset+12); err == nil {
+						result = append(result, previewRange{Offset: offset + 16, Length: int64(binary.BigEndian.Uint32(b))})
+					}
+				}
+			})
+		case "moov":
+			bmffBoxes(r, offset, offset+length, func(boxType string, offset, length int64) {
+				if boxType == "trak" {
+					if sample, ok := bmffFirstSample(r, offset, offset+length); ok {
+						result = append(result, sample)
+					}
+				}
+			})
+		}
+	})
+
+	return result
+}
+
+// bmffFirstSample returns the location of the first sample in a track box.
+func bmffFirstSample(r io.ReaderAt, start, end int64) (result previewRange, ok bool) {
+	var walk func(start, end int64)
+
+	walk = func(start, end int64) {
+		bmffBoxes(r, start, end, func(boxType string, offset, length int64) {
+			b := make([]byte, 16)
+
+			switch boxType {
+			case "mdia", "minf", "stbl":
+				walk(offset, offset+length)
+			case "stsz":
+				if _, err := r.ReadAt(b, offset); err != nil {
+					return
+				}
+
+				// A sample size of zero means that each sample has its own size.
+				if result.Length = int64(binary.BigEndian.Uint32(b[4:8])); result.Length == 0 {
+					result.Length = int64(binary.BigEndian.Uint32(b[12:16]))
+				}
+			case "stco":
+				if _, err := r.ReadAt(b[:12], offset); err == nil && binary.BigEndian.Uint32(b[4:8]) > 0 {
+					result.Offset = int64(binary.BigEndian.Uint32(b[8:12]))
+				}
+			case "co64":
+				if _, err := r.ReadAt(b, offset); err == nil && binary.BigEndian.Uint32(b[4:8]) > 0 {
+					result.Offset = int64(binary.BigEndian.Uint64(b[8:16]))
+				}
+			}
+		})
+	}
+
+	walk(start, end)
+
+	return result, result.Offset > 0 && result.Length > 0
+}
+
+// bmffBoxes calls fn with the type, content offset and content length of each box between start and end.
+func bmffBoxes(r io.ReaderAt, start, end int64, fn func(boxType string, offset, length int64)) {
+	header := make([]byte, 16)
+
+	for offset := start; offset+8 <= end; {
+		if _, err := r.ReadAt(header[:8], offset); err != nil {
+			return
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[0:4]))
+		boxType := string(header[4:8])
+		headerSize := int64(8)
+
+		switch boxSize {
+		case 0:
+			// The last box extends to the end of the file.
+			boxSize = end - offset
+		case 1:
+			if _, err := r.ReadAt(header[8:16], offset+8); err != nil {
+				return
+			}
+
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+
+		if boxSize < headerSize || offset+boxSize > end {
+			return
+		}
+
+		fn(boxType, offset+headerSize, boxSize-headerSize)
+
+		offset += boxSize
+	}
+}
+
+// tiffReader reads image file directories (IFDs) of TIFF based RAW files.
+type tiffReader struct {
+	r      io.ReaderAt
+	order  binary.ByteOrder
+	base   int64 // Offsets are relative to the base, e.g. the start of a maker note
+	size   int64
+	visits int
+}
+
+// tiffEntry represents a single IFD entry.
+type tiffEntry struct {
+	Type  uint16
+	Count uint32
+	Value []byte
+}
+
+// tiffPreviews returns the JPEG images referenced in the IFDs of a TIFF based RAW file.
+func tiffPreviews(r io.ReaderAt, size int64) []previewRange {
+	header := make([]byte, 8)
+
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil
+	}
+
+	t := &tiffReader{r: r, size: size}
+
+	switch string(header[0:2]) {
+	case "II":
+		t.order = binary.LittleEndian
+	case "MM":
+		t.order = binary.BigEndian
+	default:
+		return nil
+	}
+
+	// The magic number is 42 for standard TIFF files, ORF and RW2 files use their own.
+	switch t.order.Uint16(header[2:4]) {
+	case 42, 0x4f52, 0x5352, 0x55:
+	default:
+		return nil
+	}
+
+	return t.walk(int64(t.order.Uint32(header[4:8])), true)
+}
+
+// walk returns the JPEG images referenced in an IFD, its sub IFDs and, if chain is true, the following IFDs.
+func (t *tiffReader) walk(offset int64, chain bool) (result []previewRange) {
+	for offset > 0 && t.visits < tiffMaxIfds {
+		t.visits++
+
+		entries, next := t.ifd(offset)
+
+		if entries == nil {
+			break
+		}
+
+		// Preview referenced with JPEGInterchangeFormat, e.g. in NEF and ARW files.
+		if start, ok := t.value(entries, 0x201); ok {
+			if length, ok := t.value(entries, 0x202); ok {
+				result = append(result, previewRange{Offset: t.base + start, Length: length})
+			}
+		}
+
+		// Preview stored as a single strip with JPEG compression, e.g. in CR2 and DNG files.
+		if compression, ok := t.value(entries, 0x103); ok && (compression == 6 || compression == 7) {
+			if start, ok := t.value(entries, 0x111); ok && entries[0x111].Count == 1 {
+				if length, ok := t.value(entries, 0x117); ok {
+					result = append(result, previewRange{Offset: t.base + start, Length: length})
+				}
+			}
+		}
+
+		// JpgFromRaw in RW2 files.
+		if e, ok := entries[0x2e]; ok && e.Type == 7 && e.Count > 4 {
+			result = append(result, previewRange{Offset: t.base + int64(t.order.Uint32(e.Value)), Length: int64(e.Count)})
+		}
+
+		// SubIFDs and Exif IFD.
+		for _, tag := range []uint16{0x14a, 0x8769} {
+			for _, sub := range t.values(entries[tag]) {
+				result = append(result, t.walk(sub, false)...)
+			}
+		}
+
+		// Olympus maker notes contain the preview of ORF files.
+		if e, ok := entries[0x927c]; ok && e.Count > 12 {
+			result = append(result, t.olympusPreview(t.base+int64(t.order.Uint32(e.Value)))...)
+		}
+
+		if !chain {
+			break
+		}
+
+		offset = next
+	}
+
+	return result
+}
+
+// olympusPreview returns the preview referenced in the CameraSettings IFD of an Olympus maker note.
+func (t *tiffReader) olympusPreview(offset int64) []previewRange {
+	header := make([]byte, 12)
+
+	if _, err := t.r.ReadAt(header, offset); err != nil || !bytes.HasPrefix(header, olympusNote) {
+		return nil
+	}
+
+	note := &tiffReader{r: t.r, base: offset, size: t.size, visits: t.visits}
+
+	switch string(header[8:10]) {
+	case "II":
+		note.order = binary.LittleEndian
+	case "MM":
+		note.order = binary.BigEndian
+	default:
+		return nil
+	}
+
+	entries, _ := note.ifd(12)
+
+	settings, ok := note.value(entries, 0x2020)
+
+	if !ok {
+		return nil
+	}
+
+	entries, _ = note.ifd(settings)
+
+	start, ok := note.value(entries, 0x101)
+
+	if !ok {
+		return nil
+	}
+
+	length, ok := note.value(entries, 0x102)
+
+	if !ok {
+		return nil
+	}
+
+	return []previewRange{{Offset: offset + start, Length: length}}
+}
+
+// ifd returns the entries of an IFD and the offset of the next IFD.
+func (t *tiffReader) ifd(offset int64) (entries map[uint16]tiffEntry, next int64) {
+	offset += t.base
+	b := make([]byte, 2)
+
+	if _, err := t.r.ReadAt(b, offset); err != nil {
+		return nil, 0
+	}
+
+	count := int(t.order.Uint16(b))
+
+	if count == 0 || count > tiffMaxEntries {
+		return nil, 0
+	}
+
+	b = make([]byte, count*12+4)
+
+	if _, err := t.r.ReadAt(b, offset+2); err != nil {
+		return nil, 0
+	}
+
+	entries = make(map[uint16]tiffEntry, count)
+
+	for i := 0; i < count; i++ {
+		e := b[i*12 : i*12+12]
+		entries[t.order.Uint16(e[0:2])] = tiffEntry{Type: t.order.Uint16(e[2:4]), Count: t.order.Uint32(e[4:8]), Value: e[8:12]}
+	}
+
+	return entries, int64(t.order.Uint32(b[count*12:]))
+}
+
+// value returns the first value of an entry with an integer type.
+func (t *tiffReader) value(entries map[uint16]tiffEntry, tag uint16) (int64, bool) {
+	values := t.values(entries[tag])
+
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	return values[0], true
+}
+
+// values returns the values of an entry with an integer type, e.g. SHORT, LONG or IFD.
+func (t *tiffReader) values(e tiffEntry) (result []int64) {
+	var width int
+
+	switch e.Type {
+	case 3:
+		width = 2
+	case 4, 13:
+		width = 4
+	case 7:
+		// Some maker notes store IFD offsets as UNDEFINED.
+		if e.Count != 4 {
+			return nil
+		}
+
+		return []int64{int64(t.order.Uint32(e.Value))}
+	default:
+		return nil
+	}
+
+	if e.Count == 0 || e.Count > tiffMaxEntries {
+		return nil
+	}
+
+	data := e.Value
+
+	if n := int(e.Count) * width; n > 4 {
+		data = make([]byte, n)
+
+		if _, err := t.r.ReadAt(data, t.base+int64(t.order.Uint32(e.Value))); err != nil {
+			return nil
+		}
+	}
+
+	for i := 0; i < int(e.Count); i++ {
+		if width == 2 {
+			result = append(result, int64(t.order.Uint16(data[i*2:])))
+		} else {
+			result = append(result, int64(t.order.Uint32(data[i*4:])))
+		}
+	}
+
+	return result
+}
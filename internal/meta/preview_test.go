@@ -0,0 +1,117 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJpeg returns a JPEG image with the given size.
+func testJpeg(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// bmffBox returns an ISO base media file format box.
+func bmffBox(boxType string, content ...[]byte) []byte {
+	data := bytes.Join(content, nil)
+	b := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint32(b, uint32(8+len(data)))
+	copy(b[4:], boxType)
+
+	return append(b, data...)
+}
+
+func TestPreview(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestPreview")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	t.Run("dng", func(t *testing.T) {
+		data, err := Preview("testdata/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1024, cfg.Width)
+		assert.Equal(t, 683, cfg.Height)
+	})
+
+	t.Run("raf", func(t *testing.T) {
+		preview := testJpeg(t, 64, 48)
+		raf := make([]byte, 100)
+		copy(raf, "FUJIFILMCCD-RAW 0201FF383501")
+		binary.BigEndian.PutUint32(raf[84:], uint32(len(raf)))
+		binary.BigEndian.PutUint32(raf[88:], uint32(len(preview)))
+		fileName := filepath.Join(dir, "test.raf")
+
+		if err := ioutil.WriteFile(fileName, append(raf, preview...), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := Preview(fileName)
+
+		assert.Nil(t, err)
+		assert.Equal(t, preview, data)
+	})
+
+	t.Run("cr3", func(t *testing.T) {
+		small := testJpeg(t, 32, 24)
+		large := testJpeg(t, 64, 48)
+
+		ftyp := bmffBox("ftyp", []byte("crx \x00\x00\x00\x01"))
+
+		prvw := make([]byte, 16)
+		binary.BigEndian.PutUint32(prvw[12:], uint32(len(small)))
+		uuid := bmffBox("uuid", canonPreview, make([]byte, 8), bmffBox("PRVW", prvw, small))
+
+		// The chunk offset of the first track is the position of the large image in the mdat box.
+		stsz := make([]byte, 12)
+		binary.BigEndian.PutUint32(stsz[4:], uint32(len(large)))
+		stco := make([]byte, 12)
+		binary.BigEndian.PutUint32(stco[4:], 1)
+		moov := func() []byte {
+			return bmffBox("moov", bmffBox("trak", bmffBox("mdia", bmffBox("minf", bmffBox("stbl", bmffBox("stsz", stsz), bmffBox("stco", stco))))))
+		}
+
+		binary.BigEndian.PutUint32(stco[8:], uint32(len(ftyp)+len(uuid)+len(moov())+8))
+
+		cr3 := bytes.Join([][]byte{ftyp, uuid, moov(), bmffBox("mdat", large)}, nil)
+		fileName := filepath.Join(dir, "test.cr3")
+
+		if err := ioutil.WriteFile(fileName, cr3, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := Preview(fileName)
+
+		assert.Nil(t, err)
+		assert.Equal(t, large, data)
+	})
+
+	t.Run("no preview", func(t *testing.T) {
+		_, err := Preview("testdata/tweethog.png")
+
+		assert.Error(t, err)
+	})
+}
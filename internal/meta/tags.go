@@ -0,0 +1,333 @@
+package meta
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dsoprea/go-exif/v2"
+	"github.com/dsoprea/go-exif/v2/common"
+	"github.com/dsoprea/go-exif/v2/undefined"
+)
+
+const (
+	rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+)
+
+// Tag represents a single meta data tag with its raw and formatted value.
+type Tag struct {
+	ID    string `json:"ID,omitempty"` // Exif tag id, e.g. 0x9003
+	Name  string
+	Raw   string
+	Value string
+}
+
+// Group represents the tags of an Exif IFD or an XMP namespace.
+type Group struct {
+	Name string
+	Tags []Tag
+}
+
+// Groups is a list of tag groups in the order they were found.
+type Groups []Group
+
+// Add adds a tag to the group with the given name, the group is created if it doesn't exist yet.
+func (g *Groups) Add(name string, tag Tag) {
+	for i := range *g {
+		if (*g)[i].Name == name {
+			(*g)[i].Tags = append((*g)[i].Tags, tag)
+			return
+		}
+	}
+
+	*g = append(*g, Group{Name: name, Tags: []Tag{tag}})
+}
+
+// Find returns the first tag with one of the given names, names are not case sensitive.
+func (g Groups) Find(names ...string) (group string, tag Tag, ok bool) {
+	for _, name := range names {
+		for _, grp := range g {
+			for _, t := range grp.Tags {
+				if strings.EqualFold(t.Name, name) && t.Raw != "" {
+					return grp.Name, t, true
+				}
+			}
+		}
+	}
+
+	return "", Tag{}, false
+}
+
+// ExifTags returns all Exif tags of an image file grouped by IFD, e.g. "IFD/Exif" or "IFD/GPSInfo".
+func ExifTags(filename string) (groups Groups, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("meta: %s", e)
+		}
+	}()
+
+	rawExif, err := RawExif(filename)
+
+	if err != nil {
+		return groups, err
+	}
+
+	ti := exif.NewTagIndex()
+
+	visitor := func(fqIfdPath string, ifdIndex int, ite *exif.IfdTagEntry) (err error) {
+		ifdPath, err := im.StripPathPhraseIndices(fqIfdPath)
+
+		if err != nil {
+			return nil
+		}
+
+		tag := Tag{ID: fmt.Sprintf("0x%04x", ite.TagId())}
+
+		if it, err := ti.Get(ifdPath, ite.TagId()); err == nil {
+			tag.Name = it.Name
+		} else {
+			tag.Name = tag.ID
+		}
+
+		if value, err := ite.Value(); err != nil {
+			tag.Raw = exifundefined.UnparseableUnknownTagValuePlaceholder
+		} else if raw, err := exifcommon.FormatFromType(value, false); err == nil {
+			tag.Raw = raw
+		} else {
+			tag.Raw = fmt.Sprint(value)
+		}
+
+		if first, err := ite.FormatFirst(); err == nil {
+			tag.Value = tagValue(tag.Name, first)
+		} else {
+			tag.Value = tag.Raw
+		}
+
+		groups.Add(ifdPath, tag)
+
+		return nil
+	}
+
+	_, err = exif.Visit(exifcommon.IfdStandard, im, ti, rawExif, visitor)
+
+	return groups, err
+}
+
+// JSONTags returns all tags of a file created by "exiftool -n -j" in the group "ExifTool".
+func JSONTags(jsonName string) (groups Groups, err error) {
+	b, err := ioutil.ReadFile(jsonName)
+
+	if err != nil {
+		return groups, err
+	}
+
+	var files []jsonValues
+
+	if err := json.Unmarshal(b, &files); err != nil {
+		return groups, fmt.Errorf("meta: invalid exiftool json (%s)", err)
+	}
+
+	if len(files) == 0 {
+		return groups, fmt.Errorf("meta: exiftool json is empty (%s)", jsonName)
+	}
+
+	v := files[0]
+	keys := make([]string, 0, len(v))
+
+	for key := range v {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		raw := v.String(key)
+		groups.Add("ExifTool", Tag{Name: key, Raw: raw, Value: tagValue(key, raw)})
+	}
+
+	return groups, nil
+}
+
+// XmpTags returns all properties of an XMP file grouped by namespace prefix, e.g. "dc" or "exif".
+func XmpTags(filename string) (groups Groups, err error) {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		return groups, err
+	}
+
+	defer f.Close()
+
+	d := xml.NewDecoder(f)
+	prefixes := make(map[string]string)
+
+	prefix := func(space string) string {
+		if p, ok := prefixes[space]; ok {
+			return p
+		}
+
+		return space
+	}
+
+	for {
+		token, err := d.Token()
+
+		if err == io.EOF {
+			return groups, nil
+		} else if err != nil {
+			return groups, fmt.Errorf("meta: invalid xmp (%s)", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		xmpNamespaces(start, prefixes)
+
+		if start.Name.Space != rdfNamespace || start.Name.Local != "Description" {
+			continue
+		}
+
+		for _, a := range start.Attr {
+			if xmpProperty(a.Name) {
+				groups.Add(prefix(a.Name.Space), Tag{Name: a.Name.Local, Raw: a.Value, Value: a.Value})
+			}
+		}
+
+		// Read property elements until the description ends.
+		for done := false; !done; {
+			token, err := d.Token()
+
+			if err != nil {
+				return groups, fmt.Errorf("meta: invalid xmp (%s)", err)
+			}
+
+			switch t := token.(type) {
+			case xml.StartElement:
+				xmpNamespaces(t, prefixes)
+
+				value, err := xmpValue(d, t, prefixes)
+
+				if err != nil {
+					return groups, fmt.Errorf("meta: invalid xmp (%s)", err)
+				}
+
+				groups.Add(prefix(t.Name.Space), Tag{Name: t.Name.Local, Raw: value, Value: value})
+			case xml.EndElement:
+				done = true
+			}
+		}
+	}
+}
+
+// xmpNamespaces remembers the prefixes of namespaces declared by an element.
+func xmpNamespaces(start xml.StartElement, prefixes map[string]string) {
+	for _, a := range start.Attr {
+		if a.Name.Space == "xmlns" {
+			prefixes[a.Value] = a.Name.Local
+		}
+	}
+}
+
+// xmpProperty returns true if an attribute is an XMP property and not a namespace declaration or syntax like xml:lang.
+func xmpProperty(name xml.Name) bool {
+	switch name.Space {
+	case "", "xmlns", "xml", xmlNamespace, rdfNamespace:
+		return false
+	}
+
+	return true
+}
+
+// xmpValue returns the text of a property element, list items like rdf:li are separated by commas.
+func xmpValue(d *xml.Decoder, start xml.StartElement, prefixes map[string]string) (string, error) {
+	var values []string
+	var text strings.Builder
+
+	flush := func() {
+		if s := strings.TrimSpace(text.String()); s != "" {
+			values = append(values, s)
+		}
+
+		text.Reset()
+	}
+
+	for _, a := range start.Attr {
+		if xmpProperty(a.Name) {
+			values = append(values, a.Name.Local+"="+a.Value)
+		}
+	}
+
+	for depth := 1; depth > 0; {
+		token, err := d.Token()
+
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			xmpNamespaces(t, prefixes)
+			flush()
+			depth++
+
+			for _, a := range t.Attr {
+				if xmpProperty(a.Name) {
+					values = append(values, a.Name.Local+"="+a.Value)
+				}
+			}
+		case xml.EndElement:
+			flush()
+			depth--
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+
+	return strings.Join(values, ", "), nil
+}
+
+// tagValue returns a human readable value of tags with numeric codes like ExposureProgram.
+func tagValue(name, value string) string {
+	i, err := strconv.Atoi(value)
+
+	if err != nil {
+		return value
+	}
+
+	var s string
+
+	switch name {
+	case "ExposureProgram":
+		s = ExposureProgram(i)
+	case "MeteringMode":
+		s = MeteringMode(i)
+	case "WhiteBalance":
+		s = WhiteBalance(i)
+	case "Flash":
+		if i&1 == 1 {
+			s = "fired"
+		} else {
+			s = "not fired"
+		}
+
+		if mode := FlashMode(i); mode != "" {
+			s = mode + ", " + s
+		}
+	}
+
+	if s == "" {
+		return value
+	}
+
+	return s
+}
@@ -0,0 +1,102 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExifTags(t *testing.T) {
+	t.Run("photoshop.jpg", func(t *testing.T) {
+		groups, err := ExifTags("testdata/photoshop.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		group, tag, ok := groups.Find("DateTimeOriginal")
+
+		assert.True(t, ok)
+		assert.Equal(t, "IFD/Exif", group)
+		assert.Equal(t, "0x9003", tag.ID)
+		assert.Equal(t, "2020:01:01 17:28:23", tag.Value)
+
+		group, tag, ok = groups.Find("MeteringMode")
+
+		assert.True(t, ok)
+		assert.Equal(t, "IFD/Exif", group)
+		assert.Equal(t, "[5]", tag.Raw)
+		assert.Equal(t, "pattern", tag.Value)
+
+		group, _, ok = groups.Find("GPSLatitude")
+
+		assert.True(t, ok)
+		assert.Equal(t, "IFD/GPSInfo", group)
+	})
+	t.Run("png file without exif", func(t *testing.T) {
+		_, err := ExifTags("testdata/tweethog.png")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONTags(t *testing.T) {
+	groups, err := JSONTags("testdata/iphone_7.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, groups, 1)
+	assert.Equal(t, "ExifTool", groups[0].Name)
+
+	_, tag, ok := groups.Find("Flash")
+
+	assert.True(t, ok)
+	assert.Equal(t, "24", tag.Raw)
+	assert.Equal(t, "auto, not fired", tag.Value)
+}
+
+func TestXmpTags(t *testing.T) {
+	groups, err := XmpTags("testdata/photoshop.xmp")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	group, tag, ok := groups.Find("title")
+
+	assert.True(t, ok)
+	assert.Equal(t, "dc", group)
+	assert.Equal(t, "Night Shift / Berlin / 2020", tag.Value)
+
+	_, tag, ok = groups.Find("subject")
+
+	assert.True(t, ok)
+	assert.Equal(t, "desk, coffee, computer", tag.Value)
+
+	group, tag, ok = groups.Find("CreatorTool")
+
+	assert.True(t, ok)
+	assert.Equal(t, "xmp", group)
+	assert.Equal(t, "ELE-L29 10.0.0.168(C431E22R2P5)", tag.Raw)
+}
+
+func TestGroups_Find(t *testing.T) {
+	groups := Groups{}
+	groups.Add("IFD", Tag{Name: "Make", Raw: "Canon"})
+	groups.Add("IFD/Exif", Tag{Name: "LensModel", Raw: ""})
+	groups.Add("IFD", Tag{Name: "Model", Raw: "EOS 6D"})
+
+	assert.Len(t, groups, 2)
+
+	group, tag, ok := groups.Find("LensModel", "model")
+
+	assert.True(t, ok)
+	assert.Equal(t, "IFD", group)
+	assert.Equal(t, "EOS 6D", tag.Raw)
+
+	_, _, ok = groups.Find("Software")
+
+	assert.False(t, ok)
+}
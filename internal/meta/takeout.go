@@ -0,0 +1,105 @@
+package meta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/ugjka/go-tz.v2/tz"
+)
+
+// takeoutTime represents a timestamp in a Google Takeout sidecar file.
+type takeoutTime struct {
+	Timestamp string `json:"timestamp"`
+	Formatted string `json:"formatted"`
+}
+
+// takeoutGeo represents a position in a Google Takeout sidecar file, zero means unknown.
+type takeoutGeo struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Altitude  float64 `json:"altitude"`
+}
+
+// takeoutPerson represents a person tagged in Google Photos.
+type takeoutPerson struct {
+	Name string `json:"name"`
+}
+
+// takeoutJson represents a Google Photos sidecar file as exported by Google Takeout, e.g. IMG_1234.jpg.json.
+type takeoutJson struct {
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	PhotoTakenTime takeoutTime     `json:"photoTakenTime"`
+	GeoData        takeoutGeo      `json:"geoData"`
+	GeoDataExif    takeoutGeo      `json:"geoDataExif"`
+	People         []takeoutPerson `json:"people"`
+	Favorited      bool            `json:"favorited"`
+}
+
+// Takeout parses a Google Photos sidecar file exported by Google Takeout and returns its meta data.
+func Takeout(jsonName string) (data Data, err error) {
+	b, err := ioutil.ReadFile(jsonName)
+
+	if err != nil {
+		return data, err
+	}
+
+	var v takeoutJson
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return data, fmt.Errorf("meta: invalid takeout json (%s)", err)
+	}
+
+	if v.PhotoTakenTime.Timestamp == "" && v.Title == "" {
+		return data, fmt.Errorf("meta: not a google takeout file (%s)", jsonName)
+	}
+
+	data.Description = strings.TrimSpace(v.Description)
+	data.Favorite = v.Favorited
+
+	geo := v.GeoData
+
+	if geo.Latitude == 0 && geo.Longitude == 0 {
+		geo = v.GeoDataExif
+	}
+
+	data.Lat = geo.Latitude
+	data.Lng = geo.Longitude
+	data.Altitude = int(geo.Altitude)
+
+	if data.Lat != 0 || data.Lng != 0 {
+		if zones, err := tz.GetZone(tz.Point{Lat: data.Lat, Lon: data.Lng}); err == nil && len(zones) > 0 {
+			data.TimeZone = zones[0]
+		}
+	}
+
+	if ts, err := strconv.ParseInt(v.PhotoTakenTime.Timestamp, 10, 64); err == nil && ts > 0 {
+		data.TakenAt = time.Unix(ts, 0).UTC()
+		data.TakenAtLocal = data.TakenAt
+
+		if loc, err := time.LoadLocation(data.TimeZone); data.TimeZone != "" && err == nil {
+			t := data.TakenAt.In(loc)
+			data.TakenAtLocal = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		}
+	}
+
+	var people []string
+
+	for _, p := range v.People {
+		if name := strings.TrimSpace(p.Name); name != "" {
+			people = append(people, name)
+		}
+	}
+
+	data.All = map[string]string{
+		"Title":          v.Title,
+		"PhotoTakenTime": v.PhotoTakenTime.Formatted,
+		"People":         strings.Join(people, ", "),
+	}
+
+	return data, nil
+}
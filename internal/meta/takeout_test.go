@@ -0,0 +1,60 @@
+package meta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeout(t *testing.T) {
+	t.Run("takeout.jpg.json", func(t *testing.T) {
+		data, err := Takeout("testdata/takeout.jpg.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "2019-06-12 10:15:00", data.TakenAt.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, "Sunset at the beach", data.Description)
+		assert.Equal(t, true, data.Favorite)
+		assert.Equal(t, 52.4596901, data.Lat)
+		assert.Equal(t, 13.3218317, data.Lng)
+		assert.Equal(t, 34, data.Altitude)
+		assert.Equal(t, "Jane Doe, John Doe", data.All["People"])
+		assert.Equal(t, "IMG_20190612_101500.jpg", data.All["Title"])
+	})
+	t.Run("not a takeout file", func(t *testing.T) {
+		_, err := Takeout("testdata/iphone_7.json")
+
+		assert.Error(t, err)
+	})
+	t.Run("no position", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "meta")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		jsonName := filepath.Join(dir, "IMG_1234.jpg.json")
+
+		if err := ioutil.WriteFile(jsonName, []byte(`{"title": "IMG_1234.jpg", "photoTakenTime": {"timestamp": "1560334500"}}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := Takeout(jsonName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", data.TimeZone)
+		assert.Equal(t, data.TakenAt, data.TakenAtLocal)
+		assert.Equal(t, false, data.Favorite)
+		assert.Equal(t, 0.0, data.Lat)
+	})
+}
@@ -0,0 +1,93 @@
+package meta
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// xmpDateTags are the XMP properties containing the date taken, with their usual namespace prefixes.
+var xmpDateTags = []string{"exif:DateTimeOriginal", "photoshop:DateCreated", "xmp:CreateDate"}
+
+var xmpDateElements = make(map[string]*regexp.Regexp)
+var xmpDateAttributes = make(map[string]*regexp.Regexp)
+
+func init() {
+	for _, tag := range xmpDateTags {
+		name := regexp.QuoteMeta(tag)
+		xmpDateElements[tag] = regexp.MustCompile(`(<` + name + `>)[^<]*(</` + name + `>)`)
+		xmpDateAttributes[tag] = regexp.MustCompile(`(\s` + name + `=")[^"]*(")`)
+	}
+}
+
+const xmpDateTemplate = `<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+   <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+      <rdf:Description rdf:about=""
+            xmlns:exif="http://ns.adobe.com/exif/1.0/"
+            xmlns:photoshop="http://ns.adobe.com/photoshop/1.0/">
+         <exif:DateTimeOriginal>%s</exif:DateTimeOriginal>
+         <photoshop:DateCreated>%s</photoshop:DateCreated>
+      </rdf:Description>
+   </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>
+`
+
+// XmpDate returns the date taken in XMP format, it includes the offset if the time zone is known.
+func XmpDate(utc, local time.Time, timeZone string) string {
+	if timeZone != "" {
+		if loc, err := time.LoadLocation(timeZone); err == nil {
+			return utc.In(loc).Format("2006-01-02T15:04:05-07:00")
+		}
+	}
+
+	return local.Format("2006-01-02T15:04:05")
+}
+
+// XmpSetDate writes the date taken to an XMP sidecar file, which is created if it doesn't exist.
+// Existing date properties are replaced, other properties remain unchanged.
+func XmpSetDate(filename, date string) error {
+	b, err := ioutil.ReadFile(filename)
+
+	if os.IsNotExist(err) {
+		return ioutil.WriteFile(filename, []byte(fmt.Sprintf(xmpDateTemplate, date, date)), 0644)
+	} else if err != nil {
+		return err
+	}
+
+	s := string(b)
+	found := false
+
+	for _, tag := range xmpDateTags {
+		for _, re := range []*regexp.Regexp{xmpDateElements[tag], xmpDateAttributes[tag]} {
+			if re.MatchString(s) {
+				s = re.ReplaceAllString(s, "${1}"+date+"${2}")
+				found = true
+			}
+		}
+	}
+
+	if !found {
+		i := strings.Index(s, "<rdf:Description")
+
+		if i < 0 {
+			return fmt.Errorf("meta: no rdf:Description found in %s", filepath.Base(filename))
+		}
+
+		insert := ` exif:DateTimeOriginal="` + date + `"`
+
+		if !strings.Contains(s, "xmlns:exif=") {
+			insert = ` xmlns:exif="http://ns.adobe.com/exif/1.0/"` + insert
+		}
+
+		i += len("<rdf:Description")
+		s = s[:i] + insert + s[i:]
+	}
+
+	return ioutil.WriteFile(filename, []byte(s), 0644)
+}
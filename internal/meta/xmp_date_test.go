@@ -0,0 +1,109 @@
+package meta
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXmpDate(t *testing.T) {
+	utc := time.Date(2019, 6, 12, 8, 15, 0, 0, time.UTC)
+	local := time.Date(2019, 6, 12, 10, 15, 0, 0, time.UTC)
+
+	assert.Equal(t, "2019-06-12T10:15:00", XmpDate(utc, local, ""))
+	assert.Equal(t, "2019-06-12T17:15:00+09:00", XmpDate(utc, local, "Asia/Tokyo"))
+}
+
+func TestXmpSetDate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "meta")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	t.Run("new file", func(t *testing.T) {
+		fileName := filepath.Join(dir, "new.xmp")
+
+		if err := XmpSetDate(fileName, "2019-06-12T10:15:00"); err != nil {
+			t.Fatal(err)
+		}
+
+		groups, err := XmpTags(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		group, tag, ok := groups.Find("DateTimeOriginal")
+
+		assert.True(t, ok)
+		assert.Equal(t, "exif", group)
+		assert.Equal(t, "2019-06-12T10:15:00", tag.Raw)
+	})
+	t.Run("existing file", func(t *testing.T) {
+		b, err := ioutil.ReadFile("testdata/photoshop.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(dir, "photoshop.xmp")
+
+		if err := ioutil.WriteFile(fileName, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := XmpSetDate(fileName, "2019-06-12T10:15:00+02:00"); err != nil {
+			t.Fatal(err)
+		}
+
+		groups, err := XmpTags(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, tag, _ := groups.Find("CreateDate")
+		assert.Equal(t, "2019-06-12T10:15:00+02:00", tag.Raw)
+
+		_, tag, _ = groups.Find("DateCreated")
+		assert.Equal(t, "2019-06-12T10:15:00+02:00", tag.Raw)
+
+		_, tag, _ = groups.Find("title")
+		assert.Equal(t, "Night Shift / Berlin / 2020", tag.Raw)
+	})
+	t.Run("no date", func(t *testing.T) {
+		fileName := filepath.Join(dir, "nodate.xmp")
+		xmp := `<x:xmpmeta xmlns:x="adobe:ns:meta/"><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">` +
+			`<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" dc:format="image/jpeg"/></rdf:RDF></x:xmpmeta>`
+
+		if err := ioutil.WriteFile(fileName, []byte(xmp), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := XmpSetDate(fileName, "2019-06-12T10:15:00"); err != nil {
+			t.Fatal(err)
+		}
+
+		groups, err := XmpTags(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		group, tag, ok := groups.Find("DateTimeOriginal")
+
+		assert.True(t, ok)
+		assert.Equal(t, "exif", group)
+		assert.Equal(t, "2019-06-12T10:15:00", tag.Raw)
+
+		_, tag, _ = groups.Find("format")
+		assert.Equal(t, "image/jpeg", tag.Raw)
+	})
+}
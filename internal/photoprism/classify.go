@@ -0,0 +1,149 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// Classify runs image classification again for selected photos without re-indexing them.
+type Classify struct {
+	conf  *config.Config
+	index *Index
+	db    *gorm.DB
+}
+
+// NewClassify returns a new classify worker and expects its dependencies as arguments.
+func NewClassify(conf *config.Config, index *Index) *Classify {
+	return &Classify{
+		conf:  conf,
+		index: index,
+		db:    conf.Db(),
+	}
+}
+
+// Cancel stops the current classify operation.
+func (c *Classify) Cancel() {
+	mutex.Worker.Cancel()
+}
+
+// Start replaces the image labels and the NSFW flag of all selected photos and returns
+// the number of classified photos. Manually added labels are kept.
+func (c *Classify) Start(opt form.ClassifyOptions) (count int, err error) {
+	if err := mutex.Worker.Start(); err != nil {
+		return 0, err
+	}
+
+	defer mutex.Worker.Stop()
+
+	if err := c.index.classifier.Init(); err != nil {
+		return 0, err
+	}
+
+	photos, err := c.photos(opt)
+
+	if err != nil {
+		return 0, err
+	}
+
+	log.Infof("classify: %d photos selected", len(photos))
+
+	for _, p := range photos {
+		if mutex.Worker.Canceled() {
+			return count, fmt.Errorf("classify: canceled")
+		}
+
+		event.Publish("index.classifying", event.Data{
+			"fileName": p.FileName,
+			"baseName": filepath.Base(p.FileName),
+			"count":    count + 1,
+			"total":    len(photos),
+		})
+
+		if err := c.photo(p); err != nil {
+			log.Errorf("classify: %s (%s)", err, p.FileName)
+			continue
+		}
+
+		count++
+	}
+
+	event.Publish("config.updated", event.Data(c.conf.ClientConfig()))
+
+	return count, nil
+}
+
+// photos returns all selected photos, they are fetched before classification
+// as the selection may depend on the labels that are replaced.
+func (c *Classify) photos(opt form.ClassifyOptions) (results []query.PhotoResult, err error) {
+	q := query.New(c.conf.OriginalsPath(), c.db)
+	limit := 500
+	offset := 0
+
+	for {
+		photos, err := q.Photos(opt.PhotoSearch(limit, offset))
+
+		if err != nil {
+			return results, err
+		}
+
+		results = append(results, photos...)
+
+		if len(photos) < limit {
+			break
+		}
+
+		offset += limit
+	}
+
+	return results, nil
+}
+
+// photo runs image classification for the primary file of a photo and replaces its image labels.
+func (c *Classify) photo(p query.PhotoResult) error {
+	m, err := NewMediaFile(filepath.Join(c.conf.OriginalsPath(), p.FileName))
+
+	if err != nil {
+		return err
+	}
+
+	if !m.IsJpeg() {
+		return fmt.Errorf("classify: primary file is not a jpeg")
+	}
+
+	labels, predictions := c.index.classifyImage(m)
+
+	if len(predictions) == 0 {
+		return fmt.Errorf("classify: no results")
+	}
+
+	if err := c.db.Where("photo_id = ? AND label_source = ?", p.ID, "image").Delete(&entity.PhotoLabel{}).Error; err != nil {
+		return err
+	}
+
+	addPhotoLabels(c.db, p.ID, labels)
+	c.index.saveClassification(p.ID, predictions)
+
+	if err := entity.IndexPhotoTerms(c.db, p.ID); err != nil {
+		return err
+	}
+
+	if c.conf.DetectNSFW() {
+		nsfw := c.index.isNSFW(m)
+
+		if err := c.db.Model(&entity.Photo{}).Where("id = ?", p.ID).UpdateColumn("photo_nsfw", nsfw).Error; err != nil {
+			return err
+		}
+	}
+
+	log.Debugf("classify: photo %d labeled as %+v", p.ID, labels)
+
+	return nil
+}
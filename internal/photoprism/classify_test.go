@@ -0,0 +1,39 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify_Start(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
+
+	c := NewClassify(conf, NewIndex(conf, tf, nd, fd))
+
+	t.Run("label not found", func(t *testing.T) {
+		count, err := c.Start(form.ClassifyOptions{Label: "xxx-not-existing"})
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("missing files are skipped", func(t *testing.T) {
+		count, err := c.Start(form.ClassifyOptions{Label: "flower"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
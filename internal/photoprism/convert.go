@@ -4,27 +4,36 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"image/jpeg"
+	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 
+	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // Convert represents a converter that can convert RAW/HEIF images to JPEG.
 type Convert struct {
-	conf     *config.Config
-	cmdMutex sync.Mutex
+	conf       *config.Config
+	converters Converters
+	pending    map[string]string
+	mutex      sync.Mutex
 }
 
 // NewConvert returns a new converter and expects the config as argument.
 func NewConvert(conf *config.Config) *Convert {
-	return &Convert{conf: conf}
+	return &Convert{conf: conf, converters: NewConverters(conf), pending: make(map[string]string)}
 }
 
 // Start converts all files in a directory to JPEG if possible.
@@ -87,30 +96,199 @@ func (c *Convert) Start(path string) error {
 	return err
 }
 
-// ConvertCommand returns the command for converting files to JPEG, depending on the format.
-func (c *Convert) ConvertCommand(image *MediaFile, jpegName string, xmpName string) (result *exec.Cmd, useMutex bool, err error) {
+// RetryFailed converts files to JPEG again that failed to convert before, and returns the files that
+// were converted successfully. The JPEG files must be indexed afterwards.
+func (c *Convert) RetryFailed() (result MediaFiles, err error) {
+	if err := mutex.Worker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.Worker.Stop()
+
+	var files []entity.File
+
+	if err := c.conf.Db().Where("file_error <> '' AND file_missing = 0").Find(&files).Error; err != nil {
+		return result, err
+	}
+
+	for _, f := range files {
+		if mutex.Worker.Canceled() {
+			return result, errors.New("convert: canceled")
+		}
+
+		mf, err := NewMediaFile(filepath.Join(c.conf.OriginalsPath(), f.FileName))
+
+		if err != nil || !(mf.IsRaw() || mf.IsHEIF() || mf.IsImageOther()) {
+			continue
+		}
+
+		_, err = c.ToJpeg(mf)
+
+		c.SaveError(mf, err)
+
+		if err != nil {
+			log.Errorf("convert: could not create jpeg for %s (%s)", f.FileName, strings.TrimSpace(err.Error()))
+			continue
+		}
+
+		result = append(result, mf)
+	}
+
+	return result, nil
+}
+
+// SaveError stores the error of a failed conversion in the files table, so that the photo can be found when
+// searching for errors. A nil error resets a previous error. Errors of files that haven't been indexed yet
+// are kept until SavePendingErrors is called.
+func (c *Convert) SaveError(image *MediaFile, err error) {
+	fileName := image.RelativeName(c.conf.OriginalsPath())
+	db := c.conf.Db()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.pending == nil {
+		c.pending = make(map[string]string)
+	}
+
+	delete(c.pending, fileName)
+
+	if err == nil {
+		if err := db.Model(&entity.File{}).
+			Where("file_name = ? AND file_error <> ''", fileName).
+			UpdateColumn("file_error", "").Error; err != nil {
+			log.Errorf("convert: %s", err)
+		}
+
+		return
+	}
+
+	fileError := txt.Clip(err.Error(), 512)
+
+	var count int
+
+	if err := db.Model(&entity.File{}).Where("file_name = ?", fileName).Count(&count).Error; err != nil {
+		log.Errorf("convert: %s", err)
+		return
+	} else if count == 0 {
+		c.pending[fileName] = fileError
+		return
+	}
+
+	if err := db.Model(&entity.File{}).
+		Where("file_name = ? AND file_error <> ?", fileName, fileError).
+		UpdateColumn("file_error", fileError).Error; err != nil {
+		log.Errorf("convert: %s", err)
+	}
+}
+
+// SavePendingErrors stores the conversion errors of files that have been indexed after the conversion failed.
+func (c *Convert) SavePendingErrors() {
+	db := c.conf.Db()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for fileName, fileError := range c.pending {
+		res := db.Model(&entity.File{}).Where("file_name = ?", fileName).UpdateColumn("file_error", fileError)
+
+		if res.Error != nil {
+			log.Errorf("convert: %s", res.Error)
+		} else if res.RowsAffected > 0 {
+			delete(c.pending, fileName)
+		}
+	}
+}
+
+// usePreview returns true if the JPEG preview embedded in a RAW file should be extracted before running a converter.
+func (c *Convert) usePreview() bool {
+	switch c.conf.RawPreview() {
+	case "prefer":
+		return true
+	case "fallback":
+		// Only converters configured for RAW files count, e.g. not ImageMagick by default
+		return len(c.converters.Find(fs.TypeRaw)) == 0
+	}
+
+	return false
+}
+
+// rawPreview saves the largest JPEG preview embedded in a RAW file, it is rotated if needed
+// as previews don't contain the orientation of the RAW file.
+func (c *Convert) rawPreview(image *MediaFile, jpegName string) (*MediaFile, error) {
+	data, err := meta.Preview(image.FileName())
+
+	if err != nil {
+		return nil, err
+	}
+
+	if orientation := image.Orientation(); orientation > 1 {
+		img, err := jpeg.Decode(bytes.NewReader(data))
+
+		if err != nil {
+			return nil, fmt.Errorf("convert: can't decode preview of %s (%s)", image.Base(), err)
+		}
+
+		if err := imaging.Save(thumb.Rotate(img, orientation), jpegName, imaging.JPEGQuality(thumb.JpegQuality)); err != nil {
+			return nil, err
+		}
+	} else if err := ioutil.WriteFile(jpegName, data, 0644); err != nil {
+		return nil, err
+	}
+
+	log.Infof("convert: extracted jpeg preview of %s", image.Base())
+
+	return NewMediaFile(jpegName)
+}
+
+// Converters returns the installed converters that support the file type in order of priority.
+func (c *Convert) Converters(image *MediaFile) (Converters, error) {
+	result := c.converters.Find(image.Type())
+
+	if len(result) > 0 {
+		return result, nil
+	}
+
 	if image.IsRaw() {
-		if c.conf.SipsBin() != "" {
-			result = exec.Command(c.conf.SipsBin(), "-s", "format", "jpeg", "--out", jpegName, image.fileName)
-		} else if c.conf.DarktableBin() != "" {
-			// Only one instance of darktable-cli allowed due to locking
-			useMutex = true
-
-			if xmpName != "" {
-				result = exec.Command(c.conf.DarktableBin(), image.fileName, xmpName, jpegName)
-			} else {
-				result = exec.Command(c.conf.DarktableBin(), image.fileName, jpegName)
-			}
-		} else {
-			return nil, useMutex, fmt.Errorf("convert: no raw to jpeg converter installed (%s)", image.Base())
+		return nil, fmt.Errorf("convert: no raw to jpeg converter installed (%s)", image.Base())
+	}
+
+	return nil, fmt.Errorf("convert: image type not supported for conversion (%s)", image.Type())
+}
+
+// run converts a file to JPEG with the given converter, incomplete files are removed if it fails.
+func (c *Convert) run(conv *Converter, image *MediaFile, jpegName, xmpName string) error {
+	cmd := conv.Command(image.FileName(), jpegName, xmpName)
+
+	// Unclear if this is really necessary here, but safe is safe.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	// Limit the number of commands running at the same time, e.g. darktable-cli fails because of a locked database file.
+	// See https://photo.stackexchange.com/questions/105969/darktable-cli-fails-because-of-locked-database-file
+	conv.Lock()
+	defer conv.Unlock()
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	// Run convert command.
+	if err := conv.Run(cmd); err != nil {
+		if fs.FileExists(jpegName) {
+			os.Remove(jpegName)
+		}
+
+		if err != ErrConvertTimeout && stderr.String() != "" {
+			return errors.New(stderr.String())
 		}
-	} else if image.IsHEIF() {
-		result = exec.Command(c.conf.HeifConvertBin(), image.fileName, jpegName)
-	} else {
-		return nil, useMutex, fmt.Errorf("convert: image type not supported for conversion (%s)", image.Type())
+
+		return err
 	}
 
-	return result, useMutex, nil
+	return nil
 }
 
 // ToJpeg converts a single image file to JPEG if possible.
@@ -125,7 +303,7 @@ func (c *Convert) ToJpeg(image *MediaFile) (*MediaFile, error) {
 
 	base := image.AbsBase()
 
-	jpegName := base + ".jpg"
+	jpegName := image.JpegName()
 
 	mediaFile, err := NewMediaFile(jpegName)
 
@@ -154,6 +332,21 @@ func (c *Convert) ToJpeg(image *MediaFile) (*MediaFile, error) {
 		"xmpName":  filepath.Base(xmpName),
 	})
 
+	if image.IsRaw() && c.usePreview() {
+		mediaFile, err := c.rawPreview(image, jpegName)
+
+		if err == nil {
+			return mediaFile, nil
+		}
+
+		// No converter installed in fallback mode.
+		if c.conf.RawPreview() == "fallback" {
+			return nil, err
+		}
+
+		log.Debugf("convert: %s, using converter", err)
+	}
+
 	if image.IsImageOther() {
 		_, err = thumb.Jpeg(image.FileName(), jpegName)
 
@@ -164,37 +357,30 @@ func (c *Convert) ToJpeg(image *MediaFile) (*MediaFile, error) {
 		return NewMediaFile(jpegName)
 	}
 
-	cmd, useMutex, err := c.ConvertCommand(image, jpegName, xmpName)
+	converters, err := c.Converters(image)
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Unclear if this is really necessary here, but safe is safe.
-	runtime.LockOSThread()
-	defer runtime.UnlockOSThread()
+	retries := c.conf.ConvertRetries()
 
-	if useMutex {
-		// Make sure only one command is executed at a time.
-		// See https://photo.stackexchange.com/questions/105969/darktable-cli-fails-because-of-locked-database-file
-		c.cmdMutex.Lock()
-		defer c.cmdMutex.Unlock()
-	}
+	// Retry failed commands and try the next converter if they keep failing, a command
+	// that timed out isn't retried as it will most likely hang again.
+	for _, conv := range converters {
+		for attempt := 0; attempt <= retries; attempt++ {
+			if err = c.run(conv, image, jpegName, xmpName); err == nil {
+				return NewMediaFile(jpegName)
+			}
 
-	// Fetch command output.
-	var out bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = &out
-	cmd.Stderr = &stderr
+			if err == ErrConvertTimeout {
+				log.Warnf("convert: %s killed after %s for %s", conv.Name, conv.Timeout, fileName)
+				break
+			}
 
-	// Run convert command.
-	if err := cmd.Run(); err != nil {
-		if stderr.String() != "" {
-			return nil, errors.New(stderr.String())
-		} else {
-			return nil, err
+			log.Warnf("convert: %s failed for %s (%s)", conv.Name, fileName, strings.TrimSpace(err.Error()))
 		}
 	}
 
-	return NewMediaFile(jpegName)
+	return nil, err
 }
@@ -1,10 +1,12 @@
 package photoprism
 
 import (
+	"errors"
 	"os"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +19,47 @@ func TestNewConvert(t *testing.T) {
 	assert.IsType(t, &Convert{}, convert)
 }
 
+func TestConvert_SaveError(t *testing.T) {
+	conf := config.TestConfig()
+	db := conf.Db()
+	convert := NewConvert(conf)
+	image := &MediaFile{fileName: conf.OriginalsPath() + "/convert/IMG_9100.CR2"}
+
+	convert.SaveError(image, errors.New("darktable-cli: timeout"))
+
+	assert.Equal(t, "darktable-cli: timeout", convert.pending["convert/IMG_9100.CR2"])
+
+	photo := entity.Photo{PhotoPath: "convert", PhotoName: "IMG_9100"}
+
+	if err := db.Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUUID: photo.PhotoUUID, FileName: "convert/IMG_9100.CR2", FileHash: "convert9100"}
+
+	if err := db.Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	convert.SavePendingErrors()
+
+	assert.Empty(t, convert.pending)
+
+	if err := db.First(&file, file.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "darktable-cli: timeout", file.FileError)
+
+	convert.SaveError(image, nil)
+
+	if err := db.First(&file, file.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "", file.FileError)
+}
+
 func TestConvert_ToJpeg(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
@@ -83,6 +126,37 @@ func TestConvert_ToJpeg(t *testing.T) {
 	assert.Equal(t, "Canon EOS 6D", infoRaw.CameraModel)
 }
 
+func TestConvert_rawPreview(t *testing.T) {
+	conf := config.TestConfig()
+
+	tmpPath := conf.CachePath() + "/_tmp/TestConvert_rawPreview"
+
+	os.MkdirAll(tmpPath, os.ModePerm)
+
+	defer os.RemoveAll(tmpPath)
+
+	convert := NewConvert(conf)
+
+	rawMediaFile, err := NewMediaFile(conf.ExamplesPath() + "/canon_eos_6d.dng")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jpegName := tmpPath + "/canon_eos_6d.jpg"
+
+	imageRaw, err := convert.rawPreview(rawMediaFile, jpegName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fs.FileExists(jpegName))
+	assert.True(t, imageRaw.IsJpeg())
+	assert.Equal(t, 1024, imageRaw.Width())
+	assert.Equal(t, 683, imageRaw.Height())
+}
+
 func TestConvert_Start(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
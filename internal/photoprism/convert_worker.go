@@ -1,6 +1,10 @@
 package photoprism
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
 
 type ConvertJob struct {
 	image   *MediaFile
@@ -9,7 +13,16 @@ type ConvertJob struct {
 
 func convertWorker(jobs <-chan ConvertJob) {
 	for job := range jobs {
-		if _, err := job.convert.ToJpeg(job.image); err != nil {
+		// Existing JPEG files are skipped without touching the database.
+		if fs.FileExists(job.image.JpegName()) {
+			continue
+		}
+
+		_, err := job.convert.ToJpeg(job.image)
+
+		job.convert.SaveError(job.image, err)
+
+		if err != nil {
 			fileName := job.image.RelativeName(job.convert.conf.OriginalsPath())
 			log.Errorf("convert: could not create jpeg for %s (%s)", fileName, strings.TrimSpace(err.Error()))
 		}
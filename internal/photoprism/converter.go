@@ -0,0 +1,230 @@
+package photoprism
+
+import (
+	"errors"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ErrConvertTimeout is returned if a converter command was killed because it didn't finish in time.
+var ErrConvertTimeout = errors.New("convert: command timed out")
+
+// Converter represents an external command that converts images to JPEG.
+type Converter struct {
+	Name     string
+	Bin      string
+	Types    []fs.Type
+	Args     []string      // "{src}", "{dest}" and "{xmp}" are replaced with file names, "{xmp}" is omitted if there is none
+	Priority int           // Converters with a higher priority are used first
+	Limit    int           // Maximum number of commands running at the same time, 0 means unlimited
+	Timeout  time.Duration // Commands are killed after this time, 0 means no timeout
+	slots    chan struct{}
+}
+
+// NewConverter returns a new converter, the binary is empty if it is not installed.
+func NewConverter(params config.ConverterParams) *Converter {
+	c := &Converter{
+		Name:     params.Name,
+		Bin:      params.Bin,
+		Args:     params.Args,
+		Priority: params.Priority,
+		Limit:    params.Limit,
+		Timeout:  time.Duration(params.Timeout) * time.Second,
+	}
+
+	for _, t := range params.Types {
+		c.Types = append(c.Types, fs.Type(strings.ToLower(t)))
+	}
+
+	if c.Limit > 0 {
+		c.slots = make(chan struct{}, c.Limit)
+	}
+
+	return c
+}
+
+// Handles returns true if the converter supports the file type.
+func (c *Converter) Handles(t fs.Type) bool {
+	for _, ct := range c.Types {
+		if ct == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Command returns the command for converting a file to JPEG, it is started in a new process group.
+func (c *Converter) Command(srcName, jpegName, xmpName string) *exec.Cmd {
+	var args []string
+
+	for _, arg := range c.Args {
+		if strings.Contains(arg, "{xmp}") && xmpName == "" {
+			continue
+		}
+
+		arg = strings.Replace(arg, "{src}", srcName, -1)
+		arg = strings.Replace(arg, "{dest}", jpegName, -1)
+		arg = strings.Replace(arg, "{xmp}", xmpName, -1)
+
+		args = append(args, arg)
+	}
+
+	cmd := exec.Command(c.Bin, args...)
+
+	setProcessGroup(cmd)
+
+	return cmd
+}
+
+// Run runs a command and waits for it to finish. If it takes longer than the timeout, the command
+// is killed including its child processes and ErrConvertTimeout is returned.
+func (c *Converter) Run(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	if c.Timeout <= 0 {
+		return cmd.Wait()
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	timer := time.NewTimer(c.Timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		if err := killProcessGroup(cmd); err != nil {
+			log.Errorf("convert: could not kill %s (%s)", c.Name, err)
+		}
+
+		<-done
+
+		return ErrConvertTimeout
+	}
+}
+
+// Lock waits until the number of running commands is below the limit.
+func (c *Converter) Lock() {
+	if c.slots != nil {
+		c.slots <- struct{}{}
+	}
+}
+
+// Unlock releases a slot acquired with Lock.
+func (c *Converter) Unlock() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// Converters is a list of converters sorted by priority.
+type Converters []*Converter
+
+// DefaultConverters returns the built-in converter definitions.
+func DefaultConverters(conf *config.Config) []config.ConverterParams {
+	return []config.ConverterParams{
+		{
+			Name:     "sips",
+			Bin:      conf.SipsBin(),
+			Types:    []string{string(fs.TypeRaw), string(fs.TypeHEIF)},
+			Args:     []string{"-s", "format", "jpeg", "--out", "{dest}", "{src}"},
+			Priority: 50,
+		},
+		{
+			// Only one instance of darktable-cli allowed due to locking
+			Name:     "darktable",
+			Bin:      conf.DarktableBin(),
+			Types:    []string{string(fs.TypeRaw)},
+			Args:     []string{"{src}", "{xmp}", "{dest}"},
+			Priority: 40,
+			Limit:    1,
+		},
+		{
+			Name:     "rawtherapee",
+			Bin:      conf.RawTherapeeBin(),
+			Types:    []string{string(fs.TypeRaw)},
+			Args:     []string{"-Y", "-j92", "-o", "{dest}", "-c", "{src}"},
+			Priority: 30,
+		},
+		{
+			Name:     "heif-convert",
+			Bin:      conf.HeifConvertBin(),
+			Types:    []string{string(fs.TypeHEIF)},
+			Args:     []string{"{src}", "{dest}"},
+			Priority: 40,
+		},
+		{
+			// RAW delegates are often missing, configure a custom converter to use it for RAW files
+			Name:     "imagemagick",
+			Bin:      conf.ImageMagickBin(),
+			Types:    []string{string(fs.TypeHEIF)},
+			Args:     []string{"{src}", "{dest}"},
+			Priority: 10,
+		},
+	}
+}
+
+// NewConverters returns the installed built-in and custom converters sorted by priority,
+// custom converters replace built-in converters with the same name.
+func NewConverters(conf *config.Config) (result Converters) {
+	params := DefaultConverters(conf)
+
+	for _, custom := range conf.Converters() {
+		replaced := false
+
+		for i := range params {
+			if params[i].Name == custom.Name {
+				params[i] = custom
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			params = append(params, custom)
+		}
+	}
+
+	for _, p := range params {
+		if p.Bin == "" {
+			continue
+		}
+
+		conv := NewConverter(p)
+
+		if conv.Timeout <= 0 {
+			conv.Timeout = conf.ConvertTimeout()
+		}
+
+		result = append(result, conv)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Priority > result[j].Priority
+	})
+
+	return result
+}
+
+// Find returns the converters that support a file type in order of priority.
+func (list Converters) Find(t fs.Type) (result Converters) {
+	for _, c := range list {
+		if c.Handles(t) {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,165 @@
+package photoprism
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConverter(t *testing.T) {
+	conv := NewConverter(config.ConverterParams{
+		Name:    "rawtherapee",
+		Bin:     "/usr/bin/rawtherapee-cli",
+		Types:   []string{"RAW"},
+		Limit:   2,
+		Timeout: 30,
+	})
+
+	assert.Equal(t, "rawtherapee", conv.Name)
+	assert.Equal(t, []fs.Type{fs.TypeRaw}, conv.Types)
+	assert.Equal(t, 2, cap(conv.slots))
+	assert.Equal(t, "30s", conv.Timeout.String())
+}
+
+func TestConverter_Handles(t *testing.T) {
+	conv := NewConverter(config.ConverterParams{Name: "sips", Bin: "sips", Types: []string{"raw", "heif"}})
+
+	assert.True(t, conv.Handles(fs.TypeRaw))
+	assert.True(t, conv.Handles(fs.TypeHEIF))
+	assert.False(t, conv.Handles(fs.TypeJpeg))
+}
+
+func TestConverter_Command(t *testing.T) {
+	conv := NewConverter(config.ConverterParams{
+		Name: "darktable",
+		Bin:  "/usr/bin/darktable-cli",
+		Args: []string{"{src}", "{xmp}", "{dest}"},
+	})
+
+	t.Run("with xmp", func(t *testing.T) {
+		cmd := conv.Command("/photos/a.cr2", "/cache/a.jpg", "/photos/a.xmp")
+
+		assert.Equal(t, []string{"/usr/bin/darktable-cli", "/photos/a.cr2", "/photos/a.xmp", "/cache/a.jpg"}, cmd.Args)
+	})
+
+	t.Run("without xmp", func(t *testing.T) {
+		cmd := conv.Command("/photos/a.cr2", "/cache/a.jpg", "")
+
+		assert.Equal(t, []string{"/usr/bin/darktable-cli", "/photos/a.cr2", "/cache/a.jpg"}, cmd.Args)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		conv := NewConverter(config.ConverterParams{Name: "custom", Bin: "custom", Args: []string{"--out={dest}", "{src}"}})
+		cmd := conv.Command("a.raw", "a.jpg", "")
+
+		assert.Equal(t, []string{"custom", "--out=a.jpg", "a.raw"}, cmd.Args)
+	})
+}
+
+func TestConverter_Run(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	t.Run("success", func(t *testing.T) {
+		conv := NewConverter(config.ConverterParams{Name: "sh", Bin: "sh", Args: []string{"-c", "exit 0"}, Timeout: 5})
+
+		assert.NoError(t, conv.Run(conv.Command("a.raw", "a.jpg", "")))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		conv := NewConverter(config.ConverterParams{Name: "sh", Bin: "sh", Args: []string{"-c", "exit 1"}, Timeout: 5})
+
+		err := conv.Run(conv.Command("a.raw", "a.jpg", ""))
+
+		assert.Error(t, err)
+		assert.NotEqual(t, ErrConvertTimeout, err)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		// The child process keeps stdout open, so Run only returns if the whole process group is killed.
+		conv := NewConverter(config.ConverterParams{Name: "sh", Bin: "sh", Args: []string{"-c", "sleep 30 & wait"}, Timeout: 1})
+
+		var out bytes.Buffer
+		cmd := conv.Command("a.raw", "a.jpg", "")
+		cmd.Stdout = &out
+
+		start := time.Now()
+
+		assert.Equal(t, ErrConvertTimeout, conv.Run(cmd))
+		assert.True(t, time.Since(start) < 10*time.Second)
+	})
+}
+
+func TestConverter_Lock(t *testing.T) {
+	conv := NewConverter(config.ConverterParams{Name: "darktable", Bin: "darktable-cli", Limit: 1})
+
+	conv.Lock()
+	assert.Len(t, conv.slots, 1)
+	conv.Unlock()
+	assert.Len(t, conv.slots, 0)
+}
+
+func TestConverters_Find(t *testing.T) {
+	list := Converters{
+		NewConverter(config.ConverterParams{Name: "darktable", Bin: "darktable-cli", Types: []string{"raw"}, Priority: 40}),
+		NewConverter(config.ConverterParams{Name: "heif-convert", Bin: "heif-convert", Types: []string{"heif"}, Priority: 40}),
+		NewConverter(config.ConverterParams{Name: "imagemagick", Bin: "convert", Types: []string{"heif", "raw"}, Priority: 10}),
+	}
+
+	t.Run("raw", func(t *testing.T) {
+		result := list.Find(fs.TypeRaw)
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, "darktable", result[0].Name)
+			assert.Equal(t, "imagemagick", result[1].Name)
+		}
+	})
+
+	t.Run("jpeg", func(t *testing.T) {
+		assert.Empty(t, list.Find(fs.TypeJpeg))
+	})
+}
+
+func TestDefaultConverters(t *testing.T) {
+	conf := config.NewConfig(config.CliTestContext())
+
+	for _, params := range DefaultConverters(conf) {
+		if params.Name == "imagemagick" {
+			assert.Equal(t, []string{"heif"}, params.Types)
+		}
+	}
+}
+
+func TestConvert_usePreview(t *testing.T) {
+	conf := config.NewConfig(config.CliTestContext())
+	imagemagick := NewConverter(config.ConverterParams{Name: "imagemagick", Bin: "magick", Types: []string{"heif"}})
+	darktable := NewConverter(config.ConverterParams{Name: "darktable", Bin: "darktable-cli", Types: []string{"raw"}})
+
+	t.Run("no raw converter", func(t *testing.T) {
+		c := &Convert{conf: conf, converters: Converters{imagemagick}}
+		assert.True(t, c.usePreview())
+	})
+	t.Run("raw converter", func(t *testing.T) {
+		c := &Convert{conf: conf, converters: Converters{darktable, imagemagick}}
+		assert.False(t, c.usePreview())
+	})
+}
+
+func TestNewConverters(t *testing.T) {
+	conf := config.TestConfig()
+
+	result := NewConverters(conf)
+
+	for i, conv := range result {
+		assert.NotEmpty(t, conv.Bin)
+
+		if i > 0 {
+			assert.LessOrEqual(t, conv.Priority, result[i-1].Priority)
+		}
+	}
+}
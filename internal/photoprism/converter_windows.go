@@ -0,0 +1,14 @@
+package photoprism
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup is not implemented on Windows.
+func setProcessGroup(cmd *exec.Cmd) {
+}
+
+// killProcessGroup kills a command, child processes are not killed on Windows.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
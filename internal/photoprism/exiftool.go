@@ -0,0 +1,102 @@
+package photoprism
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ExifToolJsonName returns the cache file name for ExifTool JSON data, it's based on the file hash
+// so that the cached data is reused after moving or renaming the file.
+func (m *MediaFile) ExifToolJsonName(cachePath string) (string, error) {
+	hash := m.Hash()
+
+	if len(hash) < 4 {
+		return "", fmt.Errorf("exiftool: file hash is empty or too short (\"%s\")", hash)
+	}
+
+	if cachePath == "" {
+		return "", errors.New("exiftool: cache path is empty")
+	}
+
+	return filepath.Join(cachePath, "json", hash[0:1], hash[1:2], hash[2:3], hash+"_exiftool.json"), nil
+}
+
+// ToJson runs exiftool once per file and caches its JSON output, so that meta data can be read from
+// formats that aren't supported natively, e.g. HEIC, CR3 and videos.
+func (c *Convert) ToJson(m *MediaFile) (jsonName string, err error) {
+	if m.IsSidecar() {
+		return "", fmt.Errorf("exiftool: no meta data in sidecar files (%s)", m.Base())
+	}
+
+	jsonName, err = m.ExifToolJsonName(c.conf.CachePath())
+
+	if err != nil {
+		return "", err
+	}
+
+	if fs.FileExists(jsonName) {
+		return jsonName, nil
+	}
+
+	bin := c.conf.ExifToolBin()
+
+	if bin == "" {
+		return "", errors.New("exiftool: command not found")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.conf.ExifToolTimeout())
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, bin, "-n", "-j", m.FileName())
+
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("exiftool: timed out after %s (%s)", c.conf.ExifToolTimeout(), m.Base())
+		} else if stderr.String() != "" {
+			return "", fmt.Errorf("exiftool: %s", stderr.String())
+		} else {
+			return "", err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(jsonName), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := ioutil.WriteFile(jsonName, out.Bytes(), 0644); err != nil {
+		return "", err
+	}
+
+	return jsonName, nil
+}
+
+// readExifToolJson adds meta data read by exiftool to a media file if exiftool is installed.
+func (ind *Index) readExifToolJson(m *MediaFile) {
+	if m.IsSidecar() {
+		return
+	}
+
+	jsonName, err := NewConvert(ind.conf).ToJson(m)
+
+	if err != nil {
+		log.Debugf("index: %s", err)
+		return
+	}
+
+	if err := m.ReadExifToolJson(jsonName); err != nil {
+		log.Warnf("index: %s", err)
+	}
+}
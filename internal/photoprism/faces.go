@@ -0,0 +1,134 @@
+package photoprism
+
+import (
+	"sync"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// facesMutex serializes face clustering, so that concurrent workers don't create duplicate people.
+var facesMutex = sync.Mutex{}
+
+// indexFaces detects faces in a jpeg file, replaces faces found previously
+// and assigns each face to the most similar person or to a new unnamed person.
+func (ind *Index) indexFaces(jpeg *MediaFile, file entity.File) {
+	if ind.faceDetector == nil {
+		return
+	}
+
+	filename, err := jpeg.Thumbnail(ind.thumbnailsPath(), "fit_720")
+
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	faces, err := ind.faceDetector.File(filename)
+
+	if err != nil {
+		log.Errorf("index: %s", err)
+		return
+	}
+
+	facesMutex.Lock()
+	defer facesMutex.Unlock()
+
+	personIDs, err := entity.DeleteFileFaces(ind.db, file.ID)
+
+	if err != nil {
+		log.Errorf("index: %s", err)
+		return
+	}
+
+	refreshPeople(ind.db, personIDs)
+
+	for _, f := range faces {
+		m := entity.NewFace(file.ID, f.Area.X, f.Area.Y, f.Area.W, f.Area.H, f.Score, f.Embedding.JSON())
+
+		if err := clusterFace(ind.db, m, f.Embedding); err != nil {
+			log.Errorf("index: %s", err)
+		}
+	}
+
+	if len(faces) > 0 {
+		log.Infof("index: found %d faces in \"%s\"", len(faces), jpeg.RelativeName(ind.originalsPath()))
+	}
+}
+
+// clusterFace saves a face and assigns it to the person with the closest mean embedding,
+// a new person is created if no person is close enough.
+func clusterFace(db *gorm.DB, m *entity.Face, embedding face.Embedding) error {
+	var people []entity.Person
+
+	if err := db.Where("embedding <> ''").Find(&people).Error; err != nil {
+		return err
+	}
+
+	var best *entity.Person
+	var bestEmbedding face.Embedding
+
+	distance := face.ClusterDistance
+
+	for i, person := range people {
+		e, err := face.ParseEmbedding(person.Embedding)
+
+		if err != nil {
+			continue
+		}
+
+		if d := embedding.Distance(e); d < distance {
+			best = &people[i]
+			bestEmbedding = e
+			distance = d
+		}
+	}
+
+	if best == nil {
+		person := entity.NewPerson(embedding.JSON())
+
+		mutex.Db.Lock()
+		err := db.Create(person).Error
+		mutex.Db.Unlock()
+
+		if err != nil {
+			return err
+		}
+
+		m.PersonID = person.ID
+
+		return m.Save(db)
+	}
+
+	best.Embedding = bestEmbedding.Add(embedding, best.FaceCount).JSON()
+	best.FaceCount++
+
+	mutex.Db.Lock()
+	err := db.Model(best).Updates(map[string]interface{}{"face_count": best.FaceCount, "embedding": best.Embedding}).Error
+	mutex.Db.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	m.PersonID = best.ID
+
+	return m.Save(db)
+}
+
+// refreshPeople updates face counts and mean embeddings after faces have been removed.
+func refreshPeople(db *gorm.DB, personIDs []uint) {
+	for _, id := range personIDs {
+		var person entity.Person
+
+		if err := db.First(&person, id).Error; err != nil {
+			continue
+		}
+
+		if err := person.Refresh(db); err != nil {
+			log.Errorf("index: %s", err)
+		}
+	}
+}
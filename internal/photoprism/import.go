@@ -58,7 +58,7 @@ func (imp *Import) Start(opt ImportOptions) {
 
 	defer mutex.Worker.Stop()
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("import: %s", err.Error())
 		return
 	}
@@ -158,6 +158,9 @@ func (imp *Import) Start(opt ImportOptions) {
 	close(jobs)
 	wg.Wait()
 
+	// Conversion errors of files indexed as related files of another main file.
+	imp.convert.SavePendingErrors()
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
@@ -191,6 +194,11 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 	fileExtension := mediaFile.Extension()
 	dateCreated := mainFile.DateCreated()
 
+	if mediaFile.IsEdited() && mediaFile.FileName() != mainFile.FileName() {
+		// keep edited versions apart from their original, the suffix is ignored by MediaFile.Base()
+		fileName += "_edited"
+	}
+
 	if !mediaFile.IsSidecar() {
 		if f, err := entity.FirstFileByHash(imp.conf.Db(), mediaFile.Hash()); err == nil {
 			existingFilename := imp.conf.OriginalsPath() + string(os.PathSeparator) + f.FileName
@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,8 +15,9 @@ func TestNewImport(t *testing.T) {
 
 	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
 
-	ind := NewIndex(conf, tf, nd)
+	ind := NewIndex(conf, tf, nd, fd)
 
 	convert := NewConvert(conf)
 
@@ -31,8 +33,9 @@ func TestImport_DestinationFilename(t *testing.T) {
 
 	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
 
-	ind := NewIndex(conf, tf, nd)
+	ind := NewIndex(conf, tf, nd, fd)
 
 	convert := NewConvert(conf)
 
@@ -60,8 +63,9 @@ func TestImport_Start(t *testing.T) {
 
 	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
 
-	ind := NewIndex(conf, tf, nd)
+	ind := NewIndex(conf, tf, nd, fd)
 
 	convert := NewConvert(conf)
 
@@ -32,6 +32,14 @@ func importWorker(jobs <-chan ImportJob) {
 
 		originalName := related.main.RelativeName(importPath)
 
+		// Read meta data with exiftool and from Google Takeout sidecars first, the destination path depends on the date taken
+		imp.index.readExifToolJson(related.main)
+		imp.index.readTakeoutJson(related.main)
+
+		// The date is cached by the media file and determines the destination folder
+		takenAt, takenSrc := related.main.TakenAtFromPath(originalName)
+		log.Debugf("import: %s taken at %s according to %s", originalName, takenAt.String(), takenSrc)
+
 		event.Publish("import.file", event.Data{
 			"fileName": originalName,
 			"baseName": filepath.Base(related.main.FileName()),
@@ -79,9 +87,11 @@ func importWorker(jobs <-chan ImportJob) {
 				continue
 			}
 
+			var convertErr error
+
 			if importedMainFile.IsRaw() || importedMainFile.IsHEIF() || importedMainFile.IsImageOther() {
-				if _, err := imp.convert.ToJpeg(importedMainFile); err != nil {
-					log.Errorf("import: creating jpeg failed (%s)", err.Error())
+				if _, convertErr = imp.convert.ToJpeg(importedMainFile); convertErr != nil {
+					log.Errorf("import: creating jpeg failed (%s)", convertErr.Error())
 				}
 			}
 
@@ -126,6 +136,11 @@ func importWorker(jobs <-chan ImportJob) {
 
 				log.Infof("import: %s related %s file \"%s\"", res, f.Type(), f.RelativeName(ind.originalsPath()))
 			}
+
+			// Conversion errors can only be saved once the file has been indexed.
+			if convertErr != nil {
+				imp.convert.SaveError(importedMainFile, convertErr)
+			}
 		}
 	}
 }
@@ -11,7 +11,9 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -20,17 +22,19 @@ import (
 // Index represents an indexer that indexes files in the originals directory.
 type Index struct {
 	conf         *config.Config
-	tensorFlow   *classify.TensorFlow
+	classifier   classify.Classifier
 	nsfwDetector *nsfw.Detector
+	faceDetector *face.Detector
 	db           *gorm.DB
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector) *Index {
+func NewIndex(conf *config.Config, classifier classify.Classifier, nsfwDetector *nsfw.Detector, faceDetector *face.Detector) *Index {
 	i := &Index{
 		conf:         conf,
-		tensorFlow:   tensorFlow,
+		classifier:   classifier,
 		nsfwDetector: nsfwDetector,
+		faceDetector: faceDetector,
 		db:           conf.Db(),
 	}
 
@@ -67,7 +71,7 @@ func (ind *Index) Start(options IndexOptions) map[string]bool {
 
 	defer mutex.Worker.Stop()
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("index: %s", err.Error())
 
 		return done
@@ -151,5 +155,11 @@ func (ind *Index) Start(options IndexOptions) map[string]bool {
 		log.Error(err.Error())
 	}
 
+	if count, err := entity.IndexMissingTerms(ind.db); err != nil {
+		log.Errorf("index: %s", err)
+	} else if count > 0 {
+		log.Infof("index: added %d photos to the search index", count)
+	}
+
 	return done
 }
@@ -1,6 +1,7 @@
 package photoprism
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -39,6 +40,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 	var keywords []string
 
 	labels := classify.Labels{}
+	var predictions []classify.Predictions
 	fileBase := m.Base()
 	filePath := m.RelativePath(ind.originalsPath())
 	fileName := m.RelativeName(ind.originalsPath())
@@ -47,6 +49,8 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 	fileChanged := true
 	fileExists := false
 	photoExists := false
+	detectFaces := false
+	updateTerms := false
 
 	event.Publish("index.indexing", event.Data{
 		"fileHash": fileHash,
@@ -65,6 +69,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 	}
 
 	if !fileExists {
+		ind.readExifToolJson(m)
+		ind.readTakeoutJson(m)
+
 		photoQuery = ind.db.Unscoped().First(&photo, "photo_path = ? AND photo_name = ?", filePath, fileBase)
 
 		if photoQuery.Error != nil && m.HasTimeAndPlace() {
@@ -75,10 +82,19 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		photoQuery = ind.db.Unscoped().First(&photo, "id = ?", file.PhotoID)
 
 		fileChanged = file.Changed(fileSize, fileModified)
+
+		if fileChanged || !o.SkipUnchanged() {
+			ind.readExifToolJson(m)
+			ind.readTakeoutJson(m)
+		}
 	}
 
 	photoExists = photoQuery.Error == nil
 
+	if !photoExists && !fileExists {
+		photo, photoExists = ind.findStack(m)
+	}
+
 	if !fileChanged && photoExists && o.SkipUnchanged() {
 		return indexResultSkipped
 	}
@@ -96,6 +112,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		if photoExists {
 			if q := ind.db.Where("file_type = 'jpg' AND file_primary = 1 AND photo_id = ?", photo.ID).First(&primaryFile); q.Error != nil {
 				file.FilePrimary = m.IsJpeg()
+			} else if m.IsJpeg() && m.IsEdited() && !primaryFile.FileEdited {
+				// Edited versions replace the original as primary file, example: IMG_E1234.JPG
+				if err := ind.db.Model(&primaryFile).UpdateColumn("file_primary", false).Error; err != nil {
+					log.Errorf("index: %s", err)
+				} else {
+					file.FilePrimary = true
+				}
 			}
 		} else {
 			file.FilePrimary = m.IsJpeg()
@@ -109,26 +132,46 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		photo.PhotoName = fileBase
 
 		if !ind.conf.TensorFlowDisabled() && (fileChanged || o.UpdateKeywords || o.UpdateLabels || o.UpdateTitle) {
-			// Image classification via TensorFlow
-			labels = ind.classifyImage(m)
+			// Image classification
+			labels, predictions = ind.classifyImage(m)
 			photo.PhotoNSFW = ind.isNSFW(m)
 		}
 
+		if ind.conf.DetectFaces() && (fileChanged || o.UpdateKeywords || o.UpdateLabels || o.UpdateTitle) {
+			detectFaces = true
+		}
+
 		if fileChanged || o.UpdateExif {
 			// Read UpdateExif data
 			if metaData, err := m.MetaData(); err == nil {
 				photo.PhotoLat = metaData.Lat
 				photo.PhotoLng = metaData.Lng
-				photo.TakenAt = metaData.TakenAt
-				photo.TakenAtLocal = metaData.TakenAtLocal
-				photo.TimeZone = metaData.TimeZone
 				photo.PhotoAltitude = metaData.Altitude
+				photo.PhotoDirection = metaData.Direction
+				photo.GPSSpeed = metaData.GPSSpeed
 				photo.PhotoArtist = metaData.Artist
 
-				if len(metaData.UniqueID) > 15 {
-					log.Debugf("index: file uuid \"%s\"", metaData.UniqueID)
+				if metaData.Description != "" && !photo.ModifiedDetails {
+					photo.PhotoDescription = metaData.Description
+				}
+
+				// Favorites can be removed by users, so they are only set for new photos.
+				if metaData.Favorite && !photoExists {
+					photo.PhotoFavorite = true
+				}
+
+				if metaData.GPSTime.IsZero() {
+					photo.GPSTime = nil
+				} else {
+					gpsTime := metaData.GPSTime.UTC()
+					photo.GPSTime = &gpsTime
+				}
 
-					file.FileUUID = metaData.UniqueID
+				if !photo.ModifiedDate {
+					photo.TakenAt = metaData.TakenAt
+					photo.TakenAtLocal = metaData.TakenAtLocal
+					photo.TimeZone = metaData.TimeZone
+					photo.TakenSrc = entity.TakenSrcMeta
 				}
 			}
 		}
@@ -141,6 +184,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 			photo.PhotoFNumber = m.FNumber()
 			photo.PhotoIso = m.Iso()
 			photo.PhotoExposure = m.Exposure()
+
+			if metaData, err := m.MetaData(); err == nil {
+				photo.PhotoBias = metaData.ExposureBias
+				photo.PhotoProgram = metaData.Program
+				photo.PhotoMetering = metaData.Metering
+				photo.PhotoWhiteBalance = metaData.WhiteBalance
+				photo.PhotoFlash = metaData.Flash
+				photo.PhotoFlashMode = metaData.FlashMode
+				photo.PhotoDistance = metaData.Distance
+			}
 		}
 
 		if fileChanged || o.UpdateKeywords || o.UpdateLocation || o.UpdateTitle {
@@ -174,27 +227,21 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		}
 
 		if photo.TakenAt.IsZero() || photo.TakenAtLocal.IsZero() {
-			photo.TakenAt = m.DateCreated()
+			relName := fileName
+
+			if originalName != "" {
+				relName = originalName
+			} else if file.OriginalName != "" {
+				relName = file.OriginalName
+			}
+
+			photo.TakenAt, photo.TakenSrc = m.TakenAtFromPath(relName)
 			photo.TakenAtLocal = photo.TakenAt
 		}
 	} else if m.IsXMP() {
 		// TODO: Proof-of-concept for indexing XMP sidecar files
 		if data, err := meta.XMP(m.FileName()); err == nil {
-			if data.Title != "" && !photo.ModifiedTitle {
-				photo.PhotoTitle = data.Title
-			}
-
-			if data.Copyright != "" {
-				photo.PhotoCopyright = data.Copyright
-			}
-
-			if data.Artist != "" {
-				photo.PhotoArtist = data.Artist
-			}
-
-			if data.Description != "" {
-				photo.PhotoDescription = data.Description
-			}
+			xmpDetails(&photo, data)
 		}
 	}
 
@@ -212,8 +259,6 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 			return indexResultFailed
 		}
 	} else {
-		photo.PhotoFavorite = false
-
 		if err := ind.db.Create(&photo).Error; err != nil {
 			log.Errorf("index: %s", err)
 			return indexResultFailed
@@ -231,6 +276,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		ind.addLabels(photo.ID, labels)
 	}
 
+	if len(predictions) > 0 {
+		ind.saveClassification(photo.ID, predictions)
+	}
+
 	if originalName != "" {
 		file.OriginalName = originalName
 	}
@@ -247,6 +296,29 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 	file.FileType = string(m.Type())
 	file.FileMime = m.MimeType()
 	file.FileOrientation = m.Orientation()
+	file.FileEdited = m.IsEdited()
+
+	if !m.IsSidecar() && (fileChanged || o.UpdateExif) {
+		if metaData, err := m.MetaData(); err == nil {
+			if len(metaData.UniqueID) > 15 {
+				log.Debugf("index: file unique id \"%s\"", metaData.UniqueID)
+				file.FileUniqueID = metaData.UniqueID
+			}
+
+			file.FileBurstID = metaData.BurstID
+			file.FileSoftware = metaData.Software
+		}
+	}
+
+	if m.IsAAE() && (fileChanged || o.UpdateExif) {
+		// Adjustments of photos edited with Apple Photos
+		if adj, err := meta.AAE(m.FileName()); err != nil {
+			log.Warnf("index: %s", err)
+		} else {
+			file.FileSoftware = adj.Editor
+			file.FileAdjustments = strings.Join(adj.Names, ", ")
+		}
+	}
 
 	if m.IsJpeg() && (fileChanged || o.UpdateColors) {
 		// Color information
@@ -256,6 +328,10 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 			file.FileLuminance = p.Luminance.Hex()
 			file.FileChroma = p.Chroma.Uint()
 		}
+
+		if sharpness, err := m.Sharpness(ind.thumbnailsPath()); err == nil {
+			file.FileSharpness = sharpness
+		}
 	}
 
 	if m.IsJpeg() && (fileChanged || o.UpdateSize) {
@@ -282,6 +358,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		keywords = append(keywords, file.FileMainColor)
 		keywords = append(keywords, labels.Keywords()...)
 		photo.IndexKeywords(keywords, ind.db)
+		updateTerms = true
 	}
 
 	if fileQuery.Error == nil {
@@ -292,6 +369,14 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 			return indexResultFailed
 		}
 
+		if detectFaces {
+			ind.indexFaces(m, file)
+		}
+
+		if updateTerms {
+			ind.indexTerms(&photo)
+		}
+
 		return indexResultUpdated
 	}
 
@@ -302,9 +387,24 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) I
 		return indexResultFailed
 	}
 
+	if detectFaces {
+		ind.indexFaces(m, file)
+	}
+
+	if updateTerms {
+		ind.indexTerms(&photo)
+	}
+
 	return indexResultAdded
 }
 
+// indexTerms updates the full-text search index of a photo.
+func (ind *Index) indexTerms(photo *entity.Photo) {
+	if err := photo.IndexTerms(ind.db); err != nil {
+		log.Errorf("index: %s", err)
+	}
+}
+
 // isNSFW returns true if media file might be offensive and detection is enabled.
 func (ind *Index) isNSFW(jpeg *MediaFile) bool {
 	if !ind.conf.DetectNSFW() {
@@ -331,8 +431,8 @@ func (ind *Index) isNSFW(jpeg *MediaFile) bool {
 	return false
 }
 
-// classifyImage returns all matching labels for a media file.
-func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
+// classifyImage returns all matching labels and the raw classifier results for a media file.
+func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels, predictions []classify.Predictions) {
 	start := time.Now()
 
 	var thumbs []string
@@ -343,8 +443,6 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 		thumbs = []string{"tile_224", "left_224", "right_224"}
 	}
 
-	var labels classify.Labels
-
 	for _, thumb := range thumbs {
 		filename, err := jpeg.Thumbnail(ind.thumbnailsPath(), thumb)
 
@@ -353,14 +451,31 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 			continue
 		}
 
-		imageLabels, err := ind.tensorFlow.File(filename)
+		p, err := ind.classifier.FilePredictions(filename)
 
 		if err != nil {
 			log.Error(err)
 			continue
 		}
 
-		labels = append(labels, imageLabels...)
+		predictions = append(predictions, p)
+	}
+
+	results = bestLabels(predictions)
+
+	elapsed := time.Since(start)
+
+	log.Debugf("index: image classification took %s", elapsed)
+
+	return results, predictions
+}
+
+// bestLabels applies the label rules to raw classifier results and returns the most confident labels.
+func bestLabels(predictions []classify.Predictions) (results classify.Labels) {
+	var labels classify.Labels
+
+	for _, p := range predictions {
+		labels = append(labels, p.Labels()...)
 	}
 
 	// Sort by priority and uncertainty
@@ -378,16 +493,31 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 		}
 	}
 
-	elapsed := time.Since(start)
+	return results
+}
 
-	log.Debugf("index: image classification took %s", elapsed)
+// saveClassification stores raw classifier results, so that label rules can be reapplied later.
+func (ind *Index) saveClassification(photoId uint, predictions []classify.Predictions) {
+	data, err := json.Marshal(predictions)
 
-	return results
+	if err != nil {
+		log.Errorf("index: %s", err)
+		return
+	}
+
+	if err := entity.NewClassification(photoId, string(data)).Save(ind.db); err != nil {
+		log.Errorf("index: %s", err)
+	}
 }
 
 func (ind *Index) addLabels(photoId uint, labels classify.Labels) {
+	addPhotoLabels(ind.db, photoId, labels)
+}
+
+// addPhotoLabels adds labels to a photo, creating missing labels and categories.
+func addPhotoLabels(db *gorm.DB, photoId uint, labels classify.Labels) {
 	for _, label := range labels {
-		lm := entity.NewLabel(txt.Title(label.Name), label.Priority).FirstOrCreate(ind.db)
+		lm := entity.NewLabel(txt.Title(label.Name), label.Priority).FirstOrCreate(db)
 
 		if lm.New {
 			event.EntitiesCreated("labels", []*entity.Label{lm})
@@ -402,17 +532,17 @@ func (ind *Index) addLabels(photoId uint, labels classify.Labels) {
 		if lm.LabelPriority != label.Priority {
 			lm.LabelPriority = label.Priority
 
-			if err := ind.db.Save(&lm).Error; err != nil {
+			if err := db.Save(&lm).Error; err != nil {
 				log.Errorf("index: %s", err)
 			}
 		}
 
-		plm := entity.NewPhotoLabel(photoId, lm.ID, label.Uncertainty, label.Source).FirstOrCreate(ind.db)
+		plm := entity.NewPhotoLabel(photoId, lm.ID, label.Uncertainty, label.Source).FirstOrCreate(db)
 
 		// Add categories
 		for _, category := range label.Categories {
-			sn := entity.NewLabel(txt.Title(category), -3).FirstOrCreate(ind.db)
-			if err := ind.db.Model(&lm).Association("LabelCategories").Append(sn).Error; err != nil {
+			sn := entity.NewLabel(txt.Title(category), -3).FirstOrCreate(db)
+			if err := db.Model(&lm).Association("LabelCategories").Append(sn).Error; err != nil {
 				log.Errorf("index: %s", err)
 			}
 		}
@@ -420,7 +550,7 @@ func (ind *Index) addLabels(photoId uint, labels classify.Labels) {
 		if plm.LabelUncertainty > label.Uncertainty {
 			plm.LabelUncertainty = label.Uncertainty
 			plm.LabelSource = label.Source
-			if err := ind.db.Save(&plm).Error; err != nil {
+			if err := db.Save(&plm).Error; err != nil {
 				log.Errorf("index: %s", err)
 			}
 		}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 )
 
@@ -19,8 +20,9 @@ func TestIndex_Start(t *testing.T) {
 
 	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
 
-	ind := NewIndex(conf, tf, nd)
+	ind := NewIndex(conf, tf, nd, fd)
 
 	convert := NewConvert(conf)
 
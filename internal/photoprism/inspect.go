@@ -0,0 +1,352 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Meta data sources in addition to the entity.TakenSrc values used for dates.
+const (
+	MetaSrcExifTool = "exiftool"
+	MetaSrcExif     = "exif"
+	MetaSrcXmp      = "xmp"
+	MetaSrcTakeout  = "takeout"
+)
+
+// MetaSource represents all tags found in a media or sidecar file.
+type MetaSource struct {
+	FileName string
+	Type     string
+	Groups   meta.Groups
+	Error    string `json:"Error,omitempty"`
+}
+
+// MetaField shows where the indexer got the value of a photo or file field from.
+type MetaField struct {
+	Field  string
+	Source string
+	Group  string `json:"Group,omitempty"`
+	Tag    string `json:"Tag,omitempty"`
+	Raw    string `json:"Raw,omitempty"`
+}
+
+// MetaInspection contains the meta data of a file and its sidecars.
+type MetaInspection struct {
+	FileUUID string
+	FileName string
+	Sources  []MetaSource
+	Fields   []MetaField
+}
+
+// metaSource is a source together with the values the indexer parses from it.
+type metaSource struct {
+	MetaSource
+	Data meta.Data
+}
+
+// metaField maps a photo or file field to the meta.Data value it is indexed from.
+// Tags are only used to show the raw value, precedence is the same as when indexing.
+type metaField struct {
+	Field string
+	Data  string
+	Tags  []string
+}
+
+var metaFields = []metaField{
+	{Field: "TakenAt", Data: "TakenAt", Tags: []string{"DateTimeOriginal", "CreateDate", "MediaCreateDate", "TrackCreateDate"}},
+	{Field: "PhotoLat", Data: "Lat", Tags: []string{"GPSPosition", "GPSLatitude"}},
+	{Field: "PhotoLng", Data: "Lng", Tags: []string{"GPSPosition", "GPSLongitude"}},
+	{Field: "PhotoAltitude", Data: "Altitude", Tags: []string{"GPSAltitude"}},
+	{Field: "PhotoDirection", Data: "Direction", Tags: []string{"GPSImgDirection"}},
+	{Field: "GPSTime", Data: "GPSTime", Tags: []string{"GPSDateTime", "GPSDateStamp"}},
+	{Field: "PhotoTitle", Data: "Title", Tags: []string{"Title"}},
+	{Field: "PhotoDescription", Data: "Description", Tags: []string{"ImageDescription", "Description"}},
+	{Field: "PhotoArtist", Data: "Artist", Tags: []string{"Artist", "Creator"}},
+	{Field: "PhotoCopyright", Data: "Copyright", Tags: []string{"Copyright", "Rights"}},
+	{Field: "PhotoFavorite", Data: "Favorite"},
+	{Field: "Camera", Data: "CameraModel", Tags: []string{"Model", "Make"}},
+	{Field: "Lens", Data: "LensModel", Tags: []string{"LensModel", "Lens", "LensMake"}},
+	{Field: "PhotoFocalLength", Data: "FocalLength", Tags: []string{"FocalLengthIn35mmFormat", "FocalLengthIn35mmFilm", "FocalLength"}},
+	{Field: "PhotoFNumber", Data: "FNumber", Tags: []string{"FNumber"}},
+	{Field: "PhotoIso", Data: "Iso", Tags: []string{"ISO", "ISOSpeedRatings"}},
+	{Field: "PhotoExposure", Data: "Exposure", Tags: []string{"ExposureTime"}},
+	{Field: "PhotoBias", Data: "ExposureBias", Tags: []string{"ExposureCompensation", "ExposureBiasValue"}},
+	{Field: "PhotoProgram", Data: "Program", Tags: []string{"ExposureProgram"}},
+	{Field: "PhotoMetering", Data: "Metering", Tags: []string{"MeteringMode"}},
+	{Field: "PhotoWhiteBalance", Data: "WhiteBalance", Tags: []string{"WhiteBalance"}},
+	{Field: "PhotoFlash", Data: "Flash", Tags: []string{"Flash"}},
+	{Field: "PhotoDistance", Data: "Distance", Tags: []string{"SubjectDistance"}},
+	{Field: "FileUniqueID", Data: "UniqueID", Tags: []string{"ImageUniqueID"}},
+	{Field: "FileBurstID", Data: "BurstID", Tags: []string{"BurstUUID"}},
+	{Field: "FileSoftware", Data: "Software", Tags: []string{"Software", "FirmwareVersion", "Firmware"}},
+	{Field: "FileOrientation", Data: "Orientation", Tags: []string{"Orientation"}},
+}
+
+// Inspect returns all tags found in a file and its sidecar files, and which of them were used for indexing.
+// Meta data is merged by the same functions as in Index.MediaFile, so that both always agree.
+func Inspect(conf *config.Config, file entity.File, sidecars []entity.File) (result MetaInspection, err error) {
+	result.FileUUID = file.FileUUID
+	result.FileName = file.FileName
+
+	fileName := filepath.Join(conf.OriginalsPath(), file.FileName)
+
+	if !fs.FileExists(fileName) {
+		return result, fmt.Errorf("inspect: file not found (%s)", file.FileName)
+	}
+
+	mediaFile, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	var sources []metaSource
+
+	if file.FileSidecar {
+		sources = append(sources, inspectSidecar(file, fileName))
+	} else {
+		sources = inspectMediaFile(conf, mediaFile, file.FileName)
+	}
+
+	done := map[string]bool{file.FileName: true}
+
+	for _, s := range sources {
+		done[s.FileName] = true
+	}
+
+	for _, sidecar := range sidecars {
+		if done[sidecar.FileName] {
+			continue
+		}
+
+		if sidecarName := filepath.Join(conf.OriginalsPath(), sidecar.FileName); fs.FileExists(sidecarName) {
+			sources = append(sources, inspectSidecar(sidecar, sidecarName))
+		}
+	}
+
+	for _, s := range sources {
+		result.Sources = append(result.Sources, s.MetaSource)
+	}
+
+	merged, _ := mediaFile.MetaData()
+
+	result.Fields = inspectFields(file.Photo, merged, sources)
+
+	return result, nil
+}
+
+// inspectMediaFile returns the meta data sources of a media file in the order the indexer reads them,
+// the media file contains the merged meta data afterwards.
+func inspectMediaFile(conf *config.Config, m *MediaFile, relName string) (sources []metaSource) {
+	if jsonName, err := m.ExifToolJsonName(conf.CachePath()); err == nil && fs.FileExists(jsonName) {
+		groups, err := meta.JSONTags(jsonName)
+		data, dataErr := meta.JSON(jsonName)
+
+		sources = append(sources, newMetaSource(relName, MetaSrcExifTool, groups, data, err, dataErr))
+
+		if err := m.ReadExifToolJson(jsonName); err != nil {
+			log.Debugf("inspect: %s", err)
+		}
+	}
+
+	groups, err := meta.ExifTags(m.FileName())
+	data, dataErr := meta.Exif(m.FileName())
+
+	sources = append(sources, newMetaSource(relName, MetaSrcExif, groups, data, err, dataErr))
+
+	if takeoutName := m.TakeoutName(); takeoutName != "" {
+		data, err := meta.Takeout(takeoutName)
+		takeoutRel := filepath.Join(filepath.Dir(relName), filepath.Base(takeoutName))
+
+		sources = append(sources, newMetaSource(takeoutRel, MetaSrcTakeout, dataTags(MetaSrcTakeout, data), data, err))
+
+		if err := m.ReadTakeoutJson(takeoutName); err != nil {
+			log.Debugf("inspect: %s", err)
+		}
+	}
+
+	return sources
+}
+
+// inspectSidecar returns the meta data source of a sidecar file.
+func inspectSidecar(f entity.File, fileName string) metaSource {
+	switch fs.Type(f.FileType) {
+	case fs.TypeXMP:
+		groups, err := meta.XmpTags(fileName)
+		data, dataErr := meta.XMP(fileName)
+
+		return newMetaSource(f.FileName, MetaSrcXmp, groups, data, err, dataErr)
+	case fs.TypeJson:
+		if data, err := meta.Takeout(fileName); err == nil {
+			return newMetaSource(f.FileName, MetaSrcTakeout, dataTags(MetaSrcTakeout, data), data)
+		}
+
+		// Other JSON sidecars are shown, but not indexed
+		groups, err := meta.JSONTags(fileName)
+
+		return newMetaSource(f.FileName, f.FileType, groups, meta.Data{}, err)
+	}
+
+	return newMetaSource(f.FileName, f.FileType, nil, meta.Data{}, fmt.Errorf("inspect: unsupported sidecar type"))
+}
+
+// newMetaSource creates a meta data source, the first error is added as text.
+func newMetaSource(fileName, srcType string, groups meta.Groups, data meta.Data, errs ...error) metaSource {
+	result := metaSource{MetaSource: MetaSource{FileName: fileName, Type: srcType, Groups: groups}, Data: data}
+
+	for _, err := range errs {
+		if err != nil {
+			result.Error = err.Error()
+			break
+		}
+	}
+
+	return result
+}
+
+// dataTags returns the values of parsed meta data as tags, for sources without tags of their own.
+func dataTags(group string, data meta.Data) (groups meta.Groups) {
+	v := reflect.ValueOf(data)
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Map || isZero(field) {
+			continue
+		}
+
+		raw := fmt.Sprint(field.Interface())
+
+		if t, ok := field.Interface().(time.Time); ok {
+			raw = t.Format(time.RFC3339)
+		}
+
+		groups.Add(strings.Title(group), meta.Tag{Name: v.Type().Field(i).Name, Raw: raw, Value: raw})
+	}
+
+	return groups
+}
+
+// inspectFields returns the source of each field: manual changes first, then XMP sidecar details as
+// applied by xmpDetails, then the source of the merged meta data value.
+func inspectFields(photo *entity.Photo, merged meta.Data, sources []metaSource) (fields []MetaField) {
+	for _, mf := range metaFields {
+		if src := manualSource(photo, merged, mf.Field); src != "" {
+			fields = append(fields, MetaField{Field: mf.Field, Source: src})
+		} else if field, ok := xmpField(mf, sources); ok {
+			fields = append(fields, field)
+		} else if field, ok := dataField(mf, merged, sources); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// manualSource returns the source of a field that wasn't read from meta data, e.g. a date taken from the file name.
+func manualSource(photo *entity.Photo, merged meta.Data, field string) string {
+	if photo == nil {
+		return ""
+	}
+
+	switch field {
+	case "TakenAt":
+		if photo.TakenSrc != entity.TakenSrcMeta {
+			return photo.TakenSrc
+		}
+	case "PhotoTitle":
+		if photo.ModifiedTitle {
+			return entity.TakenSrcManual
+		}
+	case "PhotoLat", "PhotoLng":
+		if photo.ModifiedLocation {
+			return entity.TakenSrcManual
+		}
+	case "PhotoDescription", "PhotoArtist", "PhotoCopyright":
+		if photo.ModifiedDetails {
+			return entity.TakenSrcManual
+		}
+	case "PhotoFavorite":
+		// Meta data only sets favorites of new photos, see Index.MediaFile
+		if merged.Favorite && !photo.PhotoFavorite {
+			return entity.TakenSrcManual
+		}
+	}
+
+	return ""
+}
+
+// xmpField returns the field if the indexer copies it from an XMP sidecar file, the last file wins.
+func xmpField(mf metaField, sources []metaSource) (MetaField, bool) {
+	for i := len(sources) - 1; i >= 0; i-- {
+		if sources[i].Type != MetaSrcXmp {
+			continue
+		}
+
+		var photo entity.Photo
+
+		xmpDetails(&photo, sources[i].Data)
+
+		if value := reflect.ValueOf(photo).FieldByName(mf.Field); value.IsValid() && !isZero(value) {
+			return newMetaField(mf, sources[i]), true
+		}
+	}
+
+	return MetaField{}, false
+}
+
+// dataField returns the field with the first source providing the merged value, if any.
+func dataField(mf metaField, merged meta.Data, sources []metaSource) (MetaField, bool) {
+	value := reflect.ValueOf(merged).FieldByName(mf.Data)
+
+	if !value.IsValid() || isZero(value) {
+		return MetaField{}, false
+	}
+
+	for _, src := range sources {
+		if src.Type == MetaSrcXmp {
+			continue
+		}
+
+		if sameValue(reflect.ValueOf(src.Data).FieldByName(mf.Data), value) {
+			return newMetaField(mf, src), true
+		}
+	}
+
+	return MetaField{}, false
+}
+
+// newMetaField returns a field with its source and the raw tag value, if found.
+func newMetaField(mf metaField, src metaSource) MetaField {
+	result := MetaField{Field: mf.Field, Source: src.Type}
+
+	if group, tag, ok := src.Groups.Find(append(mf.Tags, mf.Data)...); ok {
+		result.Group, result.Tag, result.Raw = group, tag.Name, tag.Raw
+	}
+
+	return result
+}
+
+// isZero returns true if a value is the zero value of its type.
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// sameValue returns true if two values are equal, times are compared by instant.
+func sameValue(a, b reflect.Value) bool {
+	if ta, ok := a.Interface().(time.Time); ok {
+		if tb, ok := b.Interface().(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
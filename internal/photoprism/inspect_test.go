@@ -0,0 +1,101 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspectFields(t *testing.T) {
+	taken := time.Date(2019, 6, 12, 10, 15, 0, 0, time.UTC)
+
+	exifGroups := meta.Groups{}
+	exifGroups.Add("IFD", meta.Tag{Name: "Model", Raw: "EOS 6D"})
+	exifGroups.Add("IFD/Exif", meta.Tag{Name: "DateTimeOriginal", Raw: "2019:06:12 10:15:00"})
+	exifGroups.Add("IFD/Exif", meta.Tag{Name: "ISOSpeedRatings", Raw: "[400]"})
+	exifData := meta.Data{CameraModel: "EOS 6D", TakenAt: taken, Iso: 400}
+
+	jsonGroups := meta.Groups{}
+	jsonGroups.Add("ExifTool", meta.Tag{Name: "ISO", Raw: "400"})
+	jsonGroups.Add("ExifTool", meta.Tag{Name: "Title", Raw: "Embedded"})
+	jsonData := meta.Data{Iso: 400, Title: "Embedded"}
+
+	takeoutData := meta.Data{TakenAt: taken.Add(time.Hour), Lat: 48.5, Lng: 9.05, Description: "Neckar", Favorite: true}
+
+	xmpGroups := meta.Groups{}
+	xmpGroups.Add("dc", meta.Tag{Name: "title", Raw: "Sidecar"})
+	xmpGroups.Add("tiff", meta.Tag{Name: "Model", Raw: "Ignored"})
+	xmpData := meta.Data{Title: "Sidecar", CameraModel: "Ignored"}
+
+	sources := []metaSource{
+		newMetaSource("2019/IMG_1234.JPG", MetaSrcExifTool, jsonGroups, jsonData),
+		newMetaSource("2019/IMG_1234.JPG", MetaSrcExif, exifGroups, exifData),
+		newMetaSource("2019/IMG_1234.JPG.json", MetaSrcTakeout, dataTags(MetaSrcTakeout, takeoutData), takeoutData),
+		newMetaSource("2019/IMG_1234.xmp", MetaSrcXmp, xmpGroups, xmpData),
+	}
+
+	// Same order as MediaFile.ReadExifToolJson and MediaFile.ReadTakeoutJson
+	merged := jsonData
+	merged.Merge(exifData)
+	merged.Merge(takeoutData)
+
+	fieldMap := func(fields []MetaField) map[string]MetaField {
+		result := make(map[string]MetaField)
+
+		for _, f := range fields {
+			result[f.Field] = f
+		}
+
+		return result
+	}
+
+	t.Run("meta data", func(t *testing.T) {
+		fields := fieldMap(inspectFields(&entity.Photo{TakenSrc: entity.TakenSrcMeta}, merged, sources))
+
+		assert.Equal(t, MetaField{Field: "TakenAt", Source: MetaSrcExif, Group: "IFD/Exif", Tag: "DateTimeOriginal", Raw: "2019:06:12 10:15:00"}, fields["TakenAt"])
+		assert.Equal(t, MetaField{Field: "PhotoIso", Source: MetaSrcExifTool, Group: "ExifTool", Tag: "ISO", Raw: "400"}, fields["PhotoIso"])
+		assert.Equal(t, MetaField{Field: "PhotoTitle", Source: MetaSrcXmp, Group: "dc", Tag: "title", Raw: "Sidecar"}, fields["PhotoTitle"])
+		assert.Equal(t, MetaSrcExif, fields["Camera"].Source)
+		assert.Equal(t, "EOS 6D", fields["Camera"].Raw)
+		assert.NotContains(t, fields, "PhotoAltitude")
+	})
+	t.Run("takeout", func(t *testing.T) {
+		fields := fieldMap(inspectFields(&entity.Photo{TakenSrc: entity.TakenSrcMeta, PhotoFavorite: true}, merged, sources))
+
+		assert.Equal(t, MetaField{Field: "PhotoLat", Source: MetaSrcTakeout, Group: "Takeout", Tag: "Lat", Raw: "48.5"}, fields["PhotoLat"])
+		assert.Equal(t, MetaSrcTakeout, fields["PhotoDescription"].Source)
+		assert.Equal(t, MetaSrcTakeout, fields["PhotoFavorite"].Source)
+	})
+	t.Run("manual changes and file name", func(t *testing.T) {
+		fields := fieldMap(inspectFields(&entity.Photo{TakenSrc: entity.TakenSrcName, ModifiedTitle: true}, merged, sources))
+
+		assert.Equal(t, MetaField{Field: "TakenAt", Source: entity.TakenSrcName}, fields["TakenAt"])
+		assert.Equal(t, MetaField{Field: "PhotoTitle", Source: entity.TakenSrcManual}, fields["PhotoTitle"])
+		assert.Equal(t, MetaField{Field: "PhotoFavorite", Source: entity.TakenSrcManual}, fields["PhotoFavorite"])
+	})
+}
+
+func TestInspectSidecar(t *testing.T) {
+	t.Run("takeout", func(t *testing.T) {
+		src := inspectSidecar(entity.File{FileName: "takeout.json", FileType: "json"}, "../meta/testdata/takeout.jpg.json")
+
+		assert.Equal(t, MetaSrcTakeout, src.Type)
+		assert.Empty(t, src.Error)
+		assert.False(t, src.Data.TakenAt.IsZero())
+	})
+	t.Run("exiftool json", func(t *testing.T) {
+		src := inspectSidecar(entity.File{FileName: "iphone_7.json", FileType: "json"}, "../meta/testdata/iphone_7.json")
+
+		assert.Equal(t, "json", src.Type)
+		assert.Empty(t, src.Error)
+		assert.NotEmpty(t, src.Groups)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		src := inspectSidecar(entity.File{FileName: "IMG_1234.AAE", FileType: "aae"}, "IMG_1234.AAE")
+
+		assert.Equal(t, "inspect: unsupported sidecar type", src.Error)
+	})
+}
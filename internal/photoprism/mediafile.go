@@ -19,21 +19,31 @@ import (
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/capture"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// editSuffixes are appended to file names by image editors when exporting a photo.
+var editSuffixes = []string{"-edited", "_edited", "-edit", "_edit"}
+
+// appleEditRegexp matches edited versions of photos created by iPhones, example: IMG_E1234.
+var appleEditRegexp = regexp.MustCompile(`^([Ii][Mm][Gg]_)[Ee](\d+)$`)
+
 // MediaFile represents a single photo, video or sidecar file.
 type MediaFile struct {
 	fileName    string
 	fileType    fs.Type
 	mimeType    string
 	dateCreated time.Time
+	takenAtSrc  string
 	hash        string
 	checksum    string
 	width       int
 	height      int
 	once        sync.Once
 	metaData    meta.Data
+	metaDataErr error
 	location    *entity.Location
+	original    string
 }
 
 // NewMediaFile returns a new media file.
@@ -64,20 +74,42 @@ func (m MediaFile) Stat() (size int64, mod time.Time) {
 
 // DateCreated returns the date on which the media file was created in UTC.
 func (m *MediaFile) DateCreated() time.Time {
+	t, _ := m.TakenAt()
+
+	return t
+}
+
+// TakenAt returns the date on which the media file was created in UTC and the source of this date.
+// Meta data takes precedence over dates in file names, folder names and the file system time.
+func (m *MediaFile) TakenAt() (time.Time, string) {
 	if !m.dateCreated.IsZero() {
-		return m.dateCreated
+		return m.dateCreated, m.takenAtSrc
 	}
 
 	m.dateCreated = time.Now().UTC()
+	m.takenAtSrc = entity.TakenSrcFile
 
 	info, err := m.MetaData()
 
 	if err == nil && !info.TakenAt.IsZero() && info.TakenAt.Year() > 1000 {
 		m.dateCreated = info.TakenAt.UTC()
+		m.takenAtSrc = entity.TakenSrcMeta
 
 		log.Infof("exif: taken at %s", m.dateCreated.String())
 
-		return m.dateCreated
+		return m.dateCreated, m.takenAtSrc
+	}
+
+	// Canonical names already contain the best date known when importing the file
+	if NonCanonical(m.Base()) {
+		if t := txt.Date(filepath.Base(m.FileName())); !t.IsZero() {
+			m.dateCreated = t
+			m.takenAtSrc = entity.TakenSrcName
+
+			log.Infof("mediafile: taken at %s according to file name", m.dateCreated.String())
+
+			return m.dateCreated, m.takenAtSrc
+		}
 	}
 
 	t, err := times.Stat(m.FileName())
@@ -85,7 +117,7 @@ func (m *MediaFile) DateCreated() time.Time {
 	if err != nil {
 		log.Debug(err.Error())
 
-		return m.dateCreated
+		return m.dateCreated, m.takenAtSrc
 	}
 
 	if t.HasBirthTime() {
@@ -96,7 +128,26 @@ func (m *MediaFile) DateCreated() time.Time {
 
 	log.Infof("mediafile: taken at %s", m.dateCreated.String())
 
-	return m.dateCreated
+	return m.dateCreated, m.takenAtSrc
+}
+
+// TakenAtFromPath returns the date on which the media file was created like TakenAt, but also checks
+// the name and folders of a relative path if the date would otherwise be the file system time.
+// The relative path may be the original name of an imported file.
+func (m *MediaFile) TakenAtFromPath(relName string) (time.Time, string) {
+	if t, src := m.TakenAt(); src != entity.TakenSrcFile || relName == "" {
+		return t, src
+	}
+
+	if t := txt.Date(filepath.Base(relName)); !t.IsZero() {
+		m.dateCreated = t
+		m.takenAtSrc = entity.TakenSrcName
+	} else if t := txt.DatePath(filepath.Dir(relName)); !t.IsZero() {
+		m.dateCreated = t
+		m.takenAtSrc = entity.TakenSrcPath
+	}
+
+	return m.dateCreated, m.takenAtSrc
 }
 
 func (m *MediaFile) HasTimeAndPlace() bool {
@@ -270,6 +321,8 @@ func (m *MediaFile) EditedName() string {
 
 // RelatedFiles returns files which are related to this file.
 func (m *MediaFile) RelatedFiles() (result RelatedFiles, err error) {
+	m.findOriginal()
+
 	baseFilename := m.AbsBase()
 	// escape any meta characters in the file name
 	baseFilename = regexp.QuoteMeta(baseFilename)
@@ -283,13 +336,40 @@ func (m *MediaFile) RelatedFiles() (result RelatedFiles, err error) {
 		matches = append(matches, filename)
 	}
 
+	// edited photos may have a different base name, example: IMG_E1234.JPG
+	matches = append(matches, m.FileName())
+
+	takeoutName := m.TakeoutName()
+
+	if takeoutName != "" {
+		matches = append(matches, takeoutName)
+	}
+
+	done := make(map[string]bool)
+
 	for _, filename := range matches {
+		if done[filename] {
+			continue
+		}
+
+		done[filename] = true
+
+		if filename != takeoutName && takeoutCopyJsonRegexp.MatchString(filename) {
+			// Sidecar of a numbered copy, e.g. IMG_1234.jpg(1).json
+			continue
+		}
+
 		resultFile, err := NewMediaFile(filename)
 
 		if err != nil {
 			continue
 		}
 
+		// Edited versions of this file, example: IMG_E1234.JPG or IMG_1234-edit.jpg
+		if original, ok := editOriginal(resultFile.Base()); ok && original == m.Base() {
+			resultFile.original = original
+		}
+
 		if result.main == nil && resultFile.IsJpeg() {
 			result.main = resultFile
 		} else if resultFile.IsRaw() {
@@ -370,8 +450,13 @@ func (m MediaFile) Directory() string {
 	return filepath.Dir(m.fileName)
 }
 
-// Base returns the filename base without any extensions and path.
+// Base returns the filename base without any extensions and path. Edited versions like IMG_E1234.JPG or
+// IMG_1234-edit.jpg return the base of their original once it has been found by RelatedFiles.
 func (m MediaFile) Base() string {
+	if m.original != "" {
+		return m.original
+	}
+
 	basename := filepath.Base(m.FileName())
 
 	if end := strings.Index(basename, "."); end != -1 {
@@ -390,6 +475,53 @@ func (m MediaFile) Base() string {
 	return basename
 }
 
+// editOriginal returns the base name of the original if a base name looks like an edited version,
+// example: IMG_E1234 or IMG_1234-edit.
+func editOriginal(basename string) (string, bool) {
+	if match := appleEditRegexp.FindStringSubmatch(basename); match != nil {
+		// edited photos created by iPhones
+		return match[1] + match[2], true
+	}
+
+	for _, suffix := range editSuffixes {
+		// exports of edited photos
+		if len(basename) > len(suffix) && strings.HasSuffix(strings.ToLower(basename), suffix) {
+			return basename[:len(basename)-len(suffix)], true
+		}
+	}
+
+	return "", false
+}
+
+// findOriginal remembers the base name of the original if this is an edited version and the original is
+// in the same directory, so that Base() returns the base name of the original.
+func (m *MediaFile) findOriginal() {
+	if m.original != "" {
+		return
+	}
+
+	if original, ok := editOriginal(m.Base()); ok && hasOriginal(m.Directory(), original) {
+		m.original = original
+	}
+}
+
+// hasOriginal returns true if a directory contains a file with the base name.
+func hasOriginal(dir, base string) bool {
+	if strings.ContainsAny(base, `*?[\`) {
+		return false
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, base) + ".*")
+
+	return err == nil && len(matches) > 0
+}
+
+// IsEdited returns true if the file is an edited version of an original found by RelatedFiles,
+// example: IMG_E1234.JPG or IMG_1234-edit.jpg
+func (m MediaFile) IsEdited() bool {
+	return m.original != ""
+}
+
 // AbsBase returns the directory and base filename without any extensions.
 func (m MediaFile) AbsBase() string {
 	return m.Directory() + string(os.PathSeparator) + m.Base()
@@ -558,6 +690,11 @@ func (m MediaFile) IsXMP() bool {
 	return m.Type() == fs.TypeXMP
 }
 
+// IsAAE returns true if this file is an Apple Photos AAE sidecar file containing adjustments.
+func (m MediaFile) IsAAE() bool {
+	return m.Type() == fs.TypeAAE
+}
+
 // IsSidecar returns true if this media file is a sidecar file (containing metadata).
 func (m MediaFile) IsSidecar() bool {
 	switch m.Type() {
@@ -595,6 +732,16 @@ func (m MediaFile) IsPhoto() bool {
 	return m.IsJpeg() || m.IsRaw() || m.IsHEIF() || m.IsImageOther()
 }
 
+// JpegName returns the name of the JPEG file converted from this file. Edited versions keep their
+// name, so that they don't share a JPEG with the original, example: IMG_E1234.jpg
+func (m MediaFile) JpegName() string {
+	if m.IsEdited() {
+		return fmt.Sprintf("%s.%s", strings.TrimSuffix(m.FileName(), filepath.Ext(m.FileName())), fs.TypeJpeg)
+	}
+
+	return fmt.Sprintf("%s.%s", m.AbsBase(), fs.TypeJpeg)
+}
+
 // Jpeg returns a the JPEG version of an image or sidecar file (if exists).
 func (m *MediaFile) Jpeg() (*MediaFile, error) {
 	if m.IsJpeg() {
@@ -605,7 +752,7 @@ func (m *MediaFile) Jpeg() (*MediaFile, error) {
 		return m, nil
 	}
 
-	jpegFilename := fmt.Sprintf("%s.%s", m.AbsBase(), fs.TypeJpeg)
+	jpegFilename := m.JpegName()
 
 	if !fs.FileExists(jpegFilename) {
 		return nil, fmt.Errorf("jpeg file does not exist: %s", jpegFilename)
@@ -1,9 +1,12 @@
 package photoprism
 
 import (
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
 
@@ -325,6 +328,88 @@ func TestMediaFile_RelatedFiles_Ordering(t *testing.T) {
 	}
 }
 
+func TestMediaFile_RelatedFiles_Edited(t *testing.T) {
+	conf := config.TestConfig()
+
+	mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_E4120.JPG")
+
+	assert.Nil(t, err)
+
+	related, err := mediaFile.RelatedFiles()
+
+	assert.Nil(t, err)
+
+	assert.Len(t, related.files, 5)
+
+	assert.Equal(t, conf.ExamplesPath()+"/IMG_4120.AAE", related.files[0].FileName())
+	assert.Equal(t, conf.ExamplesPath()+"/IMG_4120.JPG", related.files[1].FileName())
+	assert.Equal(t, conf.ExamplesPath()+"/IMG_4120.JPG", related.main.FileName())
+}
+
+func TestMediaFile_RelatedFiles_EditSuffix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "photoprism-related")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"IMG_4120.CR2", "IMG_4120-edit.jpg", "Trip_edit.jpg"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("edited export", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(filepath.Join(dir, "IMG_4120-edit.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := mediaFile.RelatedFiles()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "IMG_4120", mediaFile.Base())
+		assert.Len(t, related.files, 2)
+		assert.Equal(t, filepath.Join(dir, "IMG_4120.CR2"), related.main.FileName())
+
+		for _, f := range related.files {
+			assert.Equal(t, "IMG_4120", f.Base())
+		}
+	})
+	t.Run("original", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(filepath.Join(dir, "IMG_4120.CR2"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := mediaFile.RelatedFiles()
+
+		assert.Nil(t, err)
+		assert.Len(t, related.files, 2)
+
+		for _, f := range related.files {
+			assert.Equal(t, "IMG_4120", f.Base())
+		}
+	})
+	t.Run("no original", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(filepath.Join(dir, "Trip_edit.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := mediaFile.RelatedFiles()
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Trip_edit", mediaFile.Base())
+		assert.Len(t, related.files, 1)
+	})
+}
+
 func TestMediaFile_SetFilename(t *testing.T) {
 	conf := config.TestConfig()
 
@@ -439,6 +524,111 @@ func TestMediaFile_Basename(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, "IMG_4120", mediaFile.Base())
 	})
+	t.Run("/IMG_4120-edit.jpg", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "photoprism-base")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(dir)
+
+		if err := ioutil.WriteFile(filepath.Join(dir, "IMG_4120.CR2"), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		mediaFile := MediaFile{fileName: filepath.Join(dir, "IMG_4120-edit.jpg")}
+		assert.Equal(t, "IMG_4120-edit", mediaFile.Base())
+		mediaFile.findOriginal()
+		assert.Equal(t, "IMG_4120", mediaFile.Base())
+
+		mediaFile = MediaFile{fileName: filepath.Join(dir, "IMG_4120_Edited.jpg")}
+		mediaFile.findOriginal()
+		assert.Equal(t, "IMG_4120", mediaFile.Base())
+	})
+	t.Run("/IMG_4120-edit.jpg without original", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG_4120-edit.jpg"}
+		mediaFile.findOriginal()
+		assert.Equal(t, "IMG_4120-edit", mediaFile.Base())
+	})
+	t.Run("/credit_edit.jpg", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/credit_edit.jpg"}
+		assert.Equal(t, "credit_edit", mediaFile.Base())
+	})
+	t.Run("/edit.jpg", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/-edit.jpg"}
+		assert.Equal(t, "-edit", mediaFile.Base())
+	})
+	t.Run("/IMG_E4120.JPG", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG_E4120.JPG"}
+		assert.Equal(t, "IMG_E4120", mediaFile.Base())
+	})
+	t.Run("/IMG_EDIT.JPG", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG_EDIT.JPG"}
+		assert.Equal(t, "IMG_EDIT", mediaFile.Base())
+	})
+}
+
+func TestMediaFile_IsEdited(t *testing.T) {
+	dir, err := ioutil.TempDir("", "photoprism-edited")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"IMG_4120.HEIC", "20190609_105922_EB12A6C8.cr2"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte{}, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	isEdited := func(name string) bool {
+		mediaFile := MediaFile{fileName: filepath.Join(dir, name)}
+		mediaFile.findOriginal()
+
+		return mediaFile.IsEdited()
+	}
+
+	t.Run("/IMG_E4120.JPG", func(t *testing.T) {
+		assert.True(t, isEdited("IMG_E4120.JPG"))
+	})
+	t.Run("/IMG_4120-edit.jpg", func(t *testing.T) {
+		assert.True(t, isEdited("IMG_4120-edit.jpg"))
+	})
+	t.Run("/20190609_105922_EB12A6C8_edited.jpg", func(t *testing.T) {
+		assert.True(t, isEdited("20190609_105922_EB12A6C8_edited.jpg"))
+	})
+	t.Run("/IMG_4120.JPG", func(t *testing.T) {
+		assert.False(t, isEdited("IMG_4120.JPG"))
+	})
+	t.Run("/Trip_edit.jpg without original", func(t *testing.T) {
+		assert.False(t, isEdited("Trip_edit.jpg"))
+	})
+	t.Run("/IMG_E5000.JPG without original", func(t *testing.T) {
+		assert.False(t, isEdited("IMG_E5000.JPG"))
+	})
+	t.Run("not resolved", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: filepath.Join(dir, "IMG_4120-edit.jpg")}
+		assert.False(t, mediaFile.IsEdited())
+	})
+}
+
+func TestMediaFile_JpegName(t *testing.T) {
+	t.Run("/IMG_4120.HEIC", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG_4120.HEIC"}
+		assert.Equal(t, "/photos/IMG_4120.jpg", mediaFile.JpegName())
+	})
+	t.Run("/IMG_E4120.HEIC", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG_E4120.HEIC"}
+		assert.Equal(t, "/photos/IMG_E4120.jpg", mediaFile.JpegName())
+	})
+	t.Run("/IMG_E4120.HEIC next to original", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG_E4120.HEIC", original: "IMG_4120"}
+		assert.Equal(t, "IMG_4120", mediaFile.Base())
+		assert.Equal(t, "/photos/IMG_E4120.jpg", mediaFile.JpegName())
+	})
 }
 
 func TestMediaFile_MimeType(t *testing.T) {
@@ -763,6 +953,23 @@ func TestMediaFile_IsImageOther(t *testing.T) {
 	})
 }
 
+func TestMediaFile_IsAAE(t *testing.T) {
+	t.Run("/IMG_4120.AAE", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.AAE")
+		assert.Nil(t, err)
+		assert.Equal(t, true, mediaFile.IsAAE())
+	})
+	t.Run("/IMG_4120.JPG", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_4120.JPG")
+		assert.Nil(t, err)
+		assert.Equal(t, false, mediaFile.IsAAE())
+	})
+}
+
 func TestMediaFile_IsSidecar(t *testing.T) {
 	t.Run("/iphone_7.xmp", func(t *testing.T) {
 		conf := config.TestConfig()
@@ -1118,3 +1325,26 @@ func TestMediaFile_RenderDefaultThumbs(t *testing.T) {
 
 	assert.Empty(t, err)
 }
+
+func TestMediaFile_TakenAt(t *testing.T) {
+	t.Run("file name", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/IMG-20190612-WA0004.jpg"}
+		takenAt, src := mediaFile.TakenAt()
+
+		assert.Equal(t, "2019-06-12 00:00:00", takenAt.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, entity.TakenSrcName, src)
+	})
+	t.Run("canonical name", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/20190612_101500_ABCDEF12.jpg"}
+		_, src := mediaFile.TakenAt()
+
+		assert.Equal(t, entity.TakenSrcFile, src)
+	})
+	t.Run("original name", func(t *testing.T) {
+		mediaFile := MediaFile{fileName: "/photos/20190612_101500_ABCDEF12.jpg"}
+		takenAt, src := mediaFile.TakenAtFromPath("Scans/2008/Summer/scan_0001.jpg")
+
+		assert.Equal(t, "2008-01-01 00:00:00", takenAt.Format("2006-01-02 15:04:05"))
+		assert.Equal(t, entity.TakenSrcPath, src)
+	})
+}
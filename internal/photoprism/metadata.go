@@ -6,6 +6,43 @@ import (
 
 // MetaData returns exif meta data of a media file.
 func (m *MediaFile) MetaData() (result meta.Data, err error) {
-	m.once.Do(func() { m.metaData, err = meta.Exif(m.FileName()) })
-	return m.metaData, err
+	m.once.Do(func() { m.metaData, m.metaDataErr = meta.Exif(m.FileName()) })
+	return m.metaData, m.metaDataErr
+}
+
+// ReadExifToolJson adds meta data from an ExifTool JSON file, its values take precedence over native Exif data.
+func (m *MediaFile) ReadExifToolJson(jsonName string) error {
+	data, err := meta.JSON(jsonName)
+
+	if err != nil {
+		return err
+	}
+
+	if native, err := m.MetaData(); err == nil {
+		data.Merge(native)
+	}
+
+	m.metaData = data
+	m.metaDataErr = nil
+
+	return nil
+}
+
+// ReadTakeoutJson adds meta data from a Google Takeout sidecar file, embedded meta data takes precedence.
+func (m *MediaFile) ReadTakeoutJson(jsonName string) error {
+	data, err := meta.Takeout(jsonName)
+
+	if err != nil {
+		return err
+	}
+
+	if embedded, err := m.MetaData(); err == nil {
+		embedded.Merge(data)
+		data = embedded
+	}
+
+	m.metaData = data
+	m.metaDataErr = nil
+
+	return nil
 }
@@ -0,0 +1,100 @@
+package photoprism
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+// Reclassify reapplies the current label rules to stored classifier results.
+type Reclassify struct {
+	conf *config.Config
+	db   *gorm.DB
+}
+
+// NewReclassify returns a new reclassify worker and expects the config as argument.
+func NewReclassify(conf *config.Config) *Reclassify {
+	return &Reclassify{
+		conf: conf,
+		db:   conf.Db(),
+	}
+}
+
+// Start replaces the image labels of all photos with stored classifier results
+// and returns the number of updated photos. Manually added labels are kept.
+func (r *Reclassify) Start() (count int, err error) {
+	if err := mutex.Worker.Start(); err != nil {
+		return 0, err
+	}
+
+	defer mutex.Worker.Stop()
+
+	if _, err := classify.LoadModels(r.conf.ModelsFile()); err != nil {
+		return 0, err
+	}
+
+	limit := 500
+	offset := 0
+
+	for {
+		var classifications []entity.Classification
+
+		if err := r.db.Order("photo_id").Limit(limit).Offset(offset).Find(&classifications).Error; err != nil {
+			return count, err
+		}
+
+		if len(classifications) == 0 {
+			break
+		}
+
+		for _, c := range classifications {
+			if mutex.Worker.Canceled() {
+				return count, fmt.Errorf("reclassify: canceled")
+			}
+
+			if err := r.photo(c); err != nil {
+				log.Errorf("reclassify: photo %d %s", c.PhotoID, err)
+				continue
+			}
+
+			count++
+		}
+
+		offset += limit
+	}
+
+	event.Publish("config.updated", event.Data(r.conf.ClientConfig()))
+
+	return count, nil
+}
+
+// photo replaces the image labels of a single photo.
+func (r *Reclassify) photo(c entity.Classification) error {
+	var predictions []classify.Predictions
+
+	if err := json.Unmarshal([]byte(c.Predictions), &predictions); err != nil {
+		return err
+	}
+
+	labels := bestLabels(predictions)
+
+	if err := r.db.Where("photo_id = ? AND label_source = ?", c.PhotoID, "image").Delete(&entity.PhotoLabel{}).Error; err != nil {
+		return err
+	}
+
+	addPhotoLabels(r.db, c.PhotoID, labels)
+
+	if err := entity.IndexPhotoTerms(r.db, c.PhotoID); err != nil {
+		return err
+	}
+
+	log.Debugf("reclassify: photo %d labeled as %+v", c.PhotoID, labels)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBestLabels(t *testing.T) {
+	t.Run("confident labels", func(t *testing.T) {
+		predictions := []classify.Predictions{
+			{{Name: "tabby cat", Probability: 0.9}},
+			{{Name: "egyptian cat", Probability: 0.5}, {Name: "tiger cat", Probability: 0.1}},
+		}
+
+		result := bestLabels(predictions)
+
+		assert.NotEmpty(t, result)
+		assert.Equal(t, "cat", result[0].Name)
+		assert.Equal(t, 10, result[0].Uncertainty)
+	})
+	t.Run("no predictions", func(t *testing.T) {
+		assert.Empty(t, bestLabels(nil))
+	})
+}
+
+func TestNewReclassify(t *testing.T) {
+	conf := config.TestConfig()
+
+	r := NewReclassify(conf)
+
+	assert.IsType(t, &Reclassify{}, r)
+}
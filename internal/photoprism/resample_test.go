@@ -7,6 +7,7 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/thumb"
 
@@ -29,8 +30,9 @@ func TestResample_Start(t *testing.T) {
 
 	tf := classify.New(conf.ResourcesPath(), conf.TensorFlowDisabled())
 	nd := nsfw.New(conf.NSFWModelPath())
+	fd := face.New(conf.FacesModelPath())
 
-	ind := NewIndex(conf, tf, nd)
+	ind := NewIndex(conf, tf, nd, fd)
 
 	convert := NewConvert(conf)
 
@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"errors"
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// Sharpness returns a score from 0 to 100 based on the variance of the Laplacian
+// of a small grayscale thumbnail, blurry images have a low score (only JPEG supported).
+func (m *MediaFile) Sharpness(thumbPath string) (uint, error) {
+	if !m.IsJpeg() {
+		return 0, errors.New("no sharpness information: not a JPEG file")
+	}
+
+	img, err := m.Resample(thumbPath, "tile_224")
+
+	if err != nil {
+		return 0, err
+	}
+
+	return sharpnessScore(laplacianVariance(img)), nil
+}
+
+// laplacianVariance returns the variance of the Laplacian of the image luminance.
+func laplacianVariance(img image.Image) float64 {
+	gray := imaging.Grayscale(img)
+	bounds := gray.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width < 3 || height < 3 {
+		return 0
+	}
+
+	lum := func(x, y int) float64 {
+		return float64(gray.Pix[y*gray.Stride+x*4])
+	}
+
+	var sum, sumSquares float64
+	count := float64((width - 2) * (height - 2))
+
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			l := 4*lum(x, y) - lum(x-1, y) - lum(x+1, y) - lum(x, y-1) - lum(x, y+1)
+			sum += l
+			sumSquares += l * l
+		}
+	}
+
+	mean := sum / count
+
+	return sumSquares/count - mean*mean
+}
+
+// sharpnessScore maps the Laplacian variance to a logarithmic scale from 0 to 100.
+func sharpnessScore(variance float64) uint {
+	score := math.Round(math.Log10(1+variance) * 25)
+
+	if score > 100 {
+		return 100
+	}
+
+	return uint(score)
+}
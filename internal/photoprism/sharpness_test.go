@@ -0,0 +1,54 @@
+package photoprism
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_Sharpness(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("elephants.jpg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := mediaFile.Sharpness(conf.ThumbnailsPath())
+
+		assert.Nil(t, err)
+		assert.True(t, result > 0)
+	})
+	t.Run("not a jpeg", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/iphone_7.heic")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.Sharpness(conf.ThumbnailsPath())
+
+		assert.Error(t, err)
+	})
+}
+
+func TestLaplacianVariance(t *testing.T) {
+	blank := imaging.New(32, 32, color.White)
+	checkered := imaging.New(32, 32, color.White)
+
+	for y := 0; y < 32; y++ {
+		for x := (y % 2); x < 32; x += 2 {
+			checkered.Set(x, y, color.Black)
+		}
+	}
+
+	assert.Equal(t, float64(0), laplacianVariance(blank))
+	assert.True(t, laplacianVariance(checkered) > laplacianVariance(blank))
+	assert.Equal(t, uint(0), sharpnessScore(0))
+	assert.Equal(t, uint(100), sharpnessScore(1e6))
+}
@@ -0,0 +1,82 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// StackSeconds is the maximum time between two frames of a burst or exposure bracket taken with the same camera.
+var StackSeconds = 2
+
+// findStack returns an existing photo that a new media file belongs to, for example an exposure bracket,
+// a burst sequence or a RAW file and edited exports of the same shot, even if the file names differ.
+func (ind *Index) findStack(m *MediaFile) (photo entity.Photo, ok bool) {
+	if m.IsSidecar() {
+		return photo, false
+	}
+
+	data, err := m.MetaData()
+
+	if err != nil {
+		return photo, false
+	}
+
+	stack := ind.db.Table("photos").
+		Select("photos.*").
+		Where("photos.deleted_at IS NULL AND photos.photo_single = 0")
+
+	if len(data.UniqueID) > 15 {
+		q := stack.Joins("JOIN files ON files.photo_id = photos.id AND files.deleted_at IS NULL").
+			Where("files.file_unique_id = ?", data.UniqueID)
+
+		if q.First(&photo).Error == nil {
+			log.Debugf("index: stacking \"%s\" by unique id \"%s\"", m.Base(), data.UniqueID)
+			return photo, true
+		}
+	}
+
+	if data.BurstID != "" {
+		q := stack.Joins("JOIN files ON files.photo_id = photos.id AND files.deleted_at IS NULL").
+			Where("files.file_burst_id = ?", data.BurstID)
+
+		if q.First(&photo).Error == nil {
+			log.Debugf("index: stacking \"%s\" by burst id \"%s\"", m.Base(), data.BurstID)
+			return photo, true
+		}
+	}
+
+	if data.TakenAt.IsZero() || data.CameraModel == "" {
+		return photo, false
+	}
+
+	// Look up the camera without creating it, a new camera has no photos to stack with anyway
+	camera := entity.NewCamera(data.CameraModel, data.CameraMake)
+	takenMin, takenMax := stackInterval(data)
+
+	q := stack.Joins("JOIN cameras ON cameras.id = photos.camera_id").
+		Where("cameras.camera_model = ? AND cameras.camera_make = ?", camera.CameraModel, camera.CameraMake).
+		Where("photos.taken_at BETWEEN ? AND ?", takenMin, takenMax).
+		Order("photos.taken_at")
+
+	if q.First(&photo).Error == nil {
+		log.Debugf("index: stacking \"%s\" by camera and time", m.Base())
+		return photo, true
+	}
+
+	return photo, false
+}
+
+// stackInterval returns the time interval in which photos taken with the same camera belong to the same stack.
+// Frames of bursts and brackets are a few seconds apart, other files must be taken in the same second.
+func stackInterval(data meta.Data) (takenMin, takenMax time.Time) {
+	taken := data.TakenAt.UTC().Truncate(time.Second)
+
+	if data.Bracket || data.BurstID != "" {
+		d := time.Duration(StackSeconds) * time.Second
+		return taken.Add(-d), taken.Add(d)
+	}
+
+	return taken, taken.Add(time.Second - time.Nanosecond)
+}
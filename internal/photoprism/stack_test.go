@@ -0,0 +1,100 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+// stackFile returns a media file with the given meta data, the file itself doesn't need to exist.
+func stackFile(fileName string, data meta.Data) *MediaFile {
+	m := &MediaFile{fileName: fileName, metaData: data}
+	m.once.Do(func() {})
+
+	return m
+}
+
+func TestIndex_FindStack(t *testing.T) {
+	conf := config.TestConfig()
+	db := conf.Db()
+	ind := &Index{conf: conf, db: db}
+
+	camera := entity.NewCamera("Stack 1", "Stack Make").FirstOrCreate(db)
+	taken := time.Date(2019, 5, 10, 12, 30, 15, 0, time.UTC)
+	photo := entity.Photo{PhotoPath: "stack", PhotoName: "IMG_9001", CameraID: camera.ID, TakenAt: taken, TakenAtLocal: taken}
+
+	if err := db.Create(&photo).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{PhotoID: photo.ID, PhotoUUID: photo.PhotoUUID, FileName: "stack/IMG_9001.jpg", FileHash: "stack9001", FileUniqueID: "9A1E2B3C4D5E6F708192A3B4C5D6E7F8"}
+
+	if err := db.Create(&file).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unique id", func(t *testing.T) {
+		result, ok := ind.findStack(stackFile("/stack/export.jpg", meta.Data{UniqueID: file.FileUniqueID}))
+
+		assert.True(t, ok)
+		assert.Equal(t, photo.ID, result.ID)
+	})
+	t.Run("camera and time", func(t *testing.T) {
+		data := meta.Data{TakenAt: taken.Add(500 * time.Millisecond), CameraModel: "Stack 1", CameraMake: "Stack Make"}
+		result, ok := ind.findStack(stackFile("/stack/IMG_9002.jpg", data))
+
+		assert.True(t, ok)
+		assert.Equal(t, photo.ID, result.ID)
+	})
+	t.Run("bracket", func(t *testing.T) {
+		data := meta.Data{TakenAt: taken.Add(2 * time.Second), CameraModel: "Stack 1", CameraMake: "Stack Make", Bracket: true}
+		result, ok := ind.findStack(stackFile("/stack/IMG_9003.jpg", data))
+
+		assert.True(t, ok)
+		assert.Equal(t, photo.ID, result.ID)
+	})
+	t.Run("other second", func(t *testing.T) {
+		data := meta.Data{TakenAt: taken.Add(2 * time.Second), CameraModel: "Stack 1", CameraMake: "Stack Make"}
+		_, ok := ind.findStack(stackFile("/stack/IMG_9004.jpg", data))
+
+		assert.False(t, ok)
+	})
+	t.Run("unknown camera", func(t *testing.T) {
+		data := meta.Data{TakenAt: taken, CameraModel: "Stack 2", CameraMake: "Stack Make"}
+		_, ok := ind.findStack(stackFile("/stack/IMG_9005.jpg", data))
+
+		assert.False(t, ok)
+		assert.True(t, db.Where("camera_model = ?", "Stack 2").First(&entity.Camera{}).RecordNotFound())
+	})
+	t.Run("sidecar", func(t *testing.T) {
+		_, ok := ind.findStack(stackFile("/stack/IMG_9001.xmp", meta.Data{UniqueID: file.FileUniqueID}))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestStackInterval(t *testing.T) {
+	taken := time.Date(2019, 5, 10, 12, 30, 15, 500, time.UTC)
+
+	t.Run("same second", func(t *testing.T) {
+		takenMin, takenMax := stackInterval(meta.Data{TakenAt: taken})
+
+		assert.Equal(t, time.Date(2019, 5, 10, 12, 30, 15, 0, time.UTC), takenMin)
+		assert.True(t, takenMax.Before(time.Date(2019, 5, 10, 12, 30, 16, 0, time.UTC)))
+	})
+	t.Run("bracket", func(t *testing.T) {
+		takenMin, takenMax := stackInterval(meta.Data{TakenAt: taken, Bracket: true})
+
+		assert.Equal(t, time.Date(2019, 5, 10, 12, 30, 13, 0, time.UTC), takenMin)
+		assert.Equal(t, time.Date(2019, 5, 10, 12, 30, 17, 0, time.UTC), takenMax)
+	})
+	t.Run("burst", func(t *testing.T) {
+		takenMin, takenMax := stackInterval(meta.Data{TakenAt: taken, BurstID: "5C5A6A2C-1F27-4C8E-8E9A-0A2C4F3F2B11"})
+
+		assert.Equal(t, 4*time.Second, takenMax.Sub(takenMin))
+	})
+}
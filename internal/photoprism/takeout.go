@@ -0,0 +1,66 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"regexp"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// TakeoutMaxName is the maximum length of a media file name in Google Takeout sidecar names,
+// longer names are truncated before ".json" is appended.
+const TakeoutMaxName = 46
+
+// takeoutCopyRegexp matches numbered copies like IMG_1234(1).jpg, their sidecars are named IMG_1234.jpg(1).json.
+var takeoutCopyRegexp = regexp.MustCompile(`^(.+)(\(\d+\))(\.[^.]+)$`)
+
+// takeoutCopyJsonRegexp matches sidecars of numbered copies, so that they aren't related to the original.
+var takeoutCopyJsonRegexp = regexp.MustCompile(`\.[^.(]+\(\d+\)\.json$`)
+
+// TakeoutName returns the name of the Google Takeout JSON sidecar file if there is one, e.g. IMG_1234.jpg.json.
+func (m *MediaFile) TakeoutName() string {
+	if m.IsSidecar() {
+		return ""
+	}
+
+	dir := filepath.Dir(m.FileName())
+	name := filepath.Base(m.FileName())
+
+	candidates := []string{name + ".json"}
+
+	if match := takeoutCopyRegexp.FindStringSubmatch(name); match != nil {
+		candidates = append(candidates, match[1]+match[3]+match[2]+".json")
+	}
+
+	if runes := []rune(name); len(runes) > TakeoutMaxName {
+		candidates = append(candidates, string(runes[:TakeoutMaxName])+".json")
+	}
+
+	// Edited versions share the sidecar of the original, imported files are renamed.
+	candidates = append(candidates, m.Base()+m.Extension()+".json", m.Base()+".json")
+
+	if original, ok := editOriginal(m.Base()); ok {
+		candidates = append(candidates, original+m.Extension()+".json")
+	}
+
+	for _, candidate := range candidates {
+		if fileName := filepath.Join(dir, candidate); fs.FileExists(fileName) {
+			return fileName
+		}
+	}
+
+	return ""
+}
+
+// readTakeoutJson adds meta data from a Google Takeout sidecar file to a media file if there is one.
+func (ind *Index) readTakeoutJson(m *MediaFile) {
+	jsonName := m.TakeoutName()
+
+	if jsonName == "" {
+		return
+	}
+
+	if err := m.ReadTakeoutJson(jsonName); err != nil {
+		log.Debugf("index: %s", err)
+	}
+}
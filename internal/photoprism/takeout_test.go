@@ -0,0 +1,119 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMediaFile_TakeoutName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestMediaFile_TakeoutName")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	longName := "PXL_20200101_123456789_with_a_very_long_filename.jpg"
+
+	for _, name := range []string{
+		"IMG_1234.jpg", "IMG_1234.jpg.json",
+		"IMG_1234(1).jpg", "IMG_1234.jpg(1).json",
+		"IMG_1234-edited.jpg",
+		"IMG_5678.jpg",
+		longName, longName[:TakeoutMaxName] + ".json",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	takeoutName := func(name string) string {
+		m, err := NewMediaFile(filepath.Join(dir, name))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result := m.TakeoutName(); result != "" {
+			return filepath.Base(result)
+		}
+
+		return ""
+	}
+
+	t.Run("original", func(t *testing.T) {
+		assert.Equal(t, "IMG_1234.jpg.json", takeoutName("IMG_1234.jpg"))
+	})
+	t.Run("numbered copy", func(t *testing.T) {
+		assert.Equal(t, "IMG_1234.jpg(1).json", takeoutName("IMG_1234(1).jpg"))
+	})
+	t.Run("edited", func(t *testing.T) {
+		assert.Equal(t, "IMG_1234.jpg.json", takeoutName("IMG_1234-edited.jpg"))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		assert.Equal(t, longName[:TakeoutMaxName]+".json", takeoutName(longName))
+	})
+	t.Run("no sidecar", func(t *testing.T) {
+		assert.Equal(t, "", takeoutName("IMG_5678.jpg"))
+	})
+	t.Run("sidecar", func(t *testing.T) {
+		assert.Equal(t, "", takeoutName("IMG_1234.jpg.json"))
+	})
+	t.Run("related files", func(t *testing.T) {
+		m, err := NewMediaFile(filepath.Join(dir, "IMG_1234.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		related, err := m.RelatedFiles()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+
+		for _, f := range related.files {
+			names = append(names, filepath.Base(f.FileName()))
+		}
+
+		assert.Contains(t, names, "IMG_1234.jpg.json")
+		assert.NotContains(t, names, "IMG_1234.jpg(1).json")
+	})
+}
+
+func TestMediaFile_ReadTakeoutJson(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestMediaFile_ReadTakeoutJson")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "takeout.jpg")
+
+	if err := ioutil.WriteFile(fileName, []byte{}, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewMediaFile(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, m.ReadTakeoutJson("../meta/testdata/takeout.jpg.json"))
+
+	data, err := m.MetaData()
+
+	assert.Nil(t, err)
+	assert.True(t, data.Favorite)
+	assert.False(t, data.TakenAt.IsZero())
+}
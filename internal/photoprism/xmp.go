@@ -0,0 +1,65 @@
+package photoprism
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// XmpSidecarName returns the name of the XMP sidecar file of a photo relative to the originals path.
+// An existing sidecar is used if there is one, otherwise it is named like the primary file.
+func XmpSidecarName(primary entity.File, sidecars []entity.File) (string, error) {
+	for _, f := range sidecars {
+		if fs.Type(f.FileType) == fs.TypeXMP {
+			return f.FileName, nil
+		}
+	}
+
+	if primary.FileName == "" {
+		return "", errors.New("xmp: primary file name is empty")
+	}
+
+	base := filepath.Base(primary.FileName)
+
+	if end := strings.Index(base, "."); end != -1 {
+		base = base[:end]
+	}
+
+	return filepath.Join(filepath.Dir(primary.FileName), base+".xmp"), nil
+}
+
+// WriteXmpDate writes the date a photo was taken to its XMP sidecar file and returns the file name.
+func WriteXmpDate(originalsPath string, photo entity.Photo, primary entity.File, sidecars []entity.File) (string, error) {
+	xmpName, err := XmpSidecarName(primary, sidecars)
+
+	if err != nil {
+		return "", err
+	}
+
+	date := meta.XmpDate(photo.TakenAt, photo.TakenAtLocal, photo.TimeZone)
+
+	return xmpName, meta.XmpSetDate(filepath.Join(originalsPath, xmpName), date)
+}
+
+// xmpDetails copies the details found in an XMP sidecar file to a photo, a title changed by the user is kept.
+func xmpDetails(photo *entity.Photo, data meta.Data) {
+	if data.Title != "" && !photo.ModifiedTitle {
+		photo.PhotoTitle = data.Title
+	}
+
+	if data.Copyright != "" {
+		photo.PhotoCopyright = data.Copyright
+	}
+
+	if data.Artist != "" {
+		photo.PhotoArtist = data.Artist
+	}
+
+	if data.Description != "" {
+		photo.PhotoDescription = data.Description
+	}
+}
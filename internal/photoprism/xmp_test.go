@@ -0,0 +1,29 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXmpSidecarName(t *testing.T) {
+	t.Run("existing sidecar", func(t *testing.T) {
+		sidecars := []entity.File{{FileName: "2019/IMG_1234.json", FileType: "json"}, {FileName: "2019/IMG_1234.XMP", FileType: "xmp"}}
+		name, err := XmpSidecarName(entity.File{FileName: "2019/IMG_1234.JPG"}, sidecars)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2019/IMG_1234.XMP", name)
+	})
+	t.Run("new sidecar", func(t *testing.T) {
+		name, err := XmpSidecarName(entity.File{FileName: "2019/IMG_1234.JPG"}, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2019/IMG_1234.xmp", name)
+	})
+	t.Run("no primary file", func(t *testing.T) {
+		_, err := XmpSidecarName(entity.File{}, nil)
+
+		assert.Error(t, err)
+	})
+}
@@ -17,6 +17,8 @@ type AlbumResult struct {
 	UpdatedAt        time.Time
 	DeletedAt        time.Time
 	AlbumUUID        string
+	CoverUUID        string
+	ParentUUID       string
 	AlbumSlug        string
 	AlbumName        string
 	AlbumCount       int
@@ -34,20 +36,70 @@ func (s *Repo) FindAlbumByUUID(albumUUID string) (album entity.Album, err error)
 	return album, nil
 }
 
+// coverOrder sorts cover candidates by quality: favorites first, then by the highest
+// label priority and sharpness of the primary file, safe photos before NSFW photos.
+const coverOrder = `photos.photo_favorite DESC,
+	(SELECT MAX(cl.label_priority) FROM photos_labels cpl JOIN labels cl ON cl.id = cpl.label_id WHERE cpl.photo_id = files.photo_id) DESC,
+	files.file_sharpness DESC, photos.photo_nsfw ASC`
+
 // FindAlbumThumbByUUID returns a album preview file based on the uuid.
+// The cover photo is used if set, otherwise the best photo is picked automatically.
 func (s *Repo) FindAlbumThumbByUUID(albumUUID string) (file entity.File, err error) {
 	// s.db.LogMode(true)
 
-	if err := s.db.Where("files.file_primary AND files.deleted_at IS NULL").
+	q := s.db.Where("files.file_primary AND files.file_missing = 0 AND files.deleted_at IS NULL").
 		Joins("JOIN albums ON albums.album_uuid = ?", albumUUID).
 		Joins("JOIN photos_albums pa ON pa.album_uuid = albums.album_uuid AND pa.photo_uuid = files.photo_uuid").
-		First(&file).Error; err != nil {
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL")
+
+	if err := q.Where("albums.cover_uuid = files.photo_uuid").First(&file).Error; err == nil {
+		return file, nil
+	}
+
+	if err := q.Order(coverOrder).First(&file).Error; err != nil {
 		return file, err
 	}
 
 	return file, nil
 }
 
+// FindPhotoAlbum returns the album assignment of a photo.
+func (s *Repo) FindPhotoAlbum(albumUUID, photoUUID string) (result entity.PhotoAlbum, err error) {
+	if err := s.db.Where("album_uuid = ? AND photo_uuid = ?", albumUUID, photoUUID).First(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// AlbumDescendants returns the UUIDs of all albums nested below an album.
+func (s *Repo) AlbumDescendants(albumUUID string) (result []string, err error) {
+	seen := map[string]bool{albumUUID: true}
+	parents := []string{albumUUID}
+
+	for len(parents) > 0 {
+		var children []string
+
+		if err := s.db.Model(&entity.Album{}).Where("parent_uuid IN (?)", parents).Pluck("album_uuid", &children).Error; err != nil {
+			return result, err
+		}
+
+		parents = nil
+
+		for _, child := range children {
+			if seen[child] {
+				continue
+			}
+
+			seen[child] = true
+			parents = append(parents, child)
+			result = append(result, child)
+		}
+	}
+
+	return result, nil
+}
+
 // Albums searches albums based on their name.
 func (s *Repo) Albums(f form.AlbumSearch) (results []AlbumResult, err error) {
 	if err := f.ParseQueryString(); err != nil {
@@ -79,6 +131,18 @@ func (s *Repo) Albums(f form.AlbumSearch) (results []AlbumResult, err error) {
 		q = q.Where("LOWER(albums.album_name) LIKE ?", likeString)
 	}
 
+	if f.Parent != "" && f.Descendants {
+		descendants, err := s.AlbumDescendants(f.Parent)
+
+		if err != nil {
+			return results, err
+		}
+
+		q = q.Where("albums.album_uuid IN (?)", descendants)
+	} else if f.Parent != "" {
+		q = q.Where("albums.parent_uuid = ?", f.Parent)
+	}
+
 	if f.Favorites {
 		q = q.Where("albums.album_favorite = 1")
 	}
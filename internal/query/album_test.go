@@ -75,7 +75,22 @@ func TestRepo_Albums(t *testing.T) {
 
 		result, err := search.Albums(query)
 		assert.Nil(t, err)
-		assert.Equal(t, 3, len(result))
+		assert.Equal(t, 4, len(result))
+	})
+	t.Run("children of parent", func(t *testing.T) {
+		query := form.NewAlbumSearch("parent:5")
+
+		result, err := search.Albums(query)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, "Kreuzberg2019", result[0].AlbumName)
+	})
+	t.Run("descendants of parent", func(t *testing.T) {
+		query := form.NewAlbumSearch("parent:5 descendants:true")
+
+		result, err := search.Albums(query)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(result))
 	})
 	t.Run("search with invalid query string", func(t *testing.T) {
 		query := form.NewAlbumSearch("xxx:bla")
@@ -84,3 +99,20 @@ func TestRepo_Albums(t *testing.T) {
 		t.Log(result)
 	})
 }
+
+func TestRepo_AlbumDescendants(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("nested album", func(t *testing.T) {
+		result, err := search.AlbumDescendants("5")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"6"}, result)
+	})
+	t.Run("no descendants", func(t *testing.T) {
+		result, err := search.AlbumDescendants("3")
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -12,9 +12,10 @@ func (s *Repo) FindFiles(limit int, offset int) (files []entity.File, err error)
 	return files, nil
 }
 
-// FindFilesByUUID
+// FindFilesByUUID returns the primary files of photos and files with the given uuids,
+// Apple Photos AAE sidecar files are included so that edits can be exported too.
 func (s *Repo) FindFilesByUUID(u []string, limit int, offset int) (files []entity.File, err error) {
-	if err := s.db.Where("(photo_uuid IN (?) AND file_primary = 1) OR file_uuid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
+	if err := s.db.Where("(photo_uuid IN (?) AND (file_primary = 1 OR file_type = 'aae')) OR file_uuid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
 		return files, err
 	}
 
@@ -47,3 +48,21 @@ func (s *Repo) FindFileByHash(fileHash string) (file entity.File, err error) {
 
 	return file, nil
 }
+
+// FindFileByUUID returns the file with the given uuid including its photo.
+func (s *Repo) FindFileByUUID(fileUUID string) (file entity.File, err error) {
+	if err := s.db.Where("file_uuid = ?", fileUUID).Preload("Photo").First(&file).Error; err != nil {
+		return file, err
+	}
+
+	return file, nil
+}
+
+// FindSidecarFiles returns the sidecar files of a photo, e.g. XMP files.
+func (s *Repo) FindSidecarFiles(photoID uint) (files []entity.File, err error) {
+	if err := s.db.Where("photo_id = ? AND file_sidecar = 1", photoID).Find(&files).Error; err != nil {
+		return files, err
+	}
+
+	return files, nil
+}
@@ -16,7 +16,7 @@ func TestRepo_FindFiles(t *testing.T) {
 		files, err := search.FindFiles(1000, 0)
 
 		assert.Nil(t, err)
-		assert.Equal(t, 5, len(files))
+		assert.Equal(t, 7, len(files))
 	})
 }
 
@@ -93,3 +93,29 @@ func TestRepo_FindFileByHash(t *testing.T) {
 		t.Log(file)
 	})
 }
+
+func TestRepo_FindFileByUUID(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("no files found", func(t *testing.T) {
+		file, err := search.FindFileByUUID("fxxx")
+
+		assert.Error(t, err, "record not found")
+		t.Log(file)
+	})
+}
+
+func TestRepo_FindSidecarFiles(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("no sidecars", func(t *testing.T) {
+		files, err := search.FindSidecarFiles(1)
+
+		assert.Nil(t, err)
+		assert.Empty(t, files)
+	})
+}
@@ -0,0 +1,172 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// filterColumn represents the database column of a photo search filter.
+type filterColumn struct {
+	Name string
+	Like bool // Match parts of lowercase text
+	Bool bool // Match yes/no values
+}
+
+// photoFilterColumns maps photo search form fields to database columns.
+var photoFilterColumns = map[string]filterColumn{
+	"Title":       {Name: "photos.photo_title", Like: true},
+	"Description": {Name: "photos.photo_description", Like: true},
+	"Notes":       {Name: "photos.photo_notes", Like: true},
+	"Artist":      {Name: "photos.photo_artist", Like: true},
+	"Hash":        {Name: "files.file_hash"},
+	"Duplicate":   {Name: "files.file_duplicate"},
+	"Lat":         {Name: "photos.photo_lat"},
+	"Lng":         {Name: "photos.photo_lng"},
+	"Chroma":      {Name: "files.file_chroma"},
+	"Portrait":    {Name: "files.file_portrait"},
+	"Country":     {Name: "photos.photo_country"},
+	"Year":        {Name: "photos.photo_year"},
+	"Month":       {Name: "photos.photo_month"},
+	"Color":       {Name: "files.file_main_color"},
+	"Camera":      {Name: "photos.camera_id"},
+	"Lens":        {Name: "photos.lens_id"},
+	"Iso":         {Name: "photos.photo_iso"},
+	"F":           {Name: "photos.photo_f_number"},
+	"Taken":       {Name: "photos.taken_at"},
+	"Dated":       {Name: "photos.taken_src"},
+	"Bias":        {Name: "photos.photo_bias"},
+	"Program":     {Name: "photos.photo_program"},
+	"Metering":    {Name: "photos.photo_metering"},
+	"Balance":     {Name: "photos.photo_white_balance"},
+	"Flash":       {Name: "photos.photo_flash", Bool: true},
+	"Flashmode":   {Name: "photos.photo_flash_mode"},
+	"Distance":    {Name: "photos.photo_distance"},
+	"Direction":   {Name: "photos.photo_direction"},
+	"Speed":       {Name: "photos.gps_speed"},
+	"Software":    {Name: "files.file_software", Like: true},
+	"Favorites":   {Name: "photos.photo_favorite"},
+	"Story":       {Name: "photos.photo_story"},
+	"Nsfw":        {Name: "photos.photo_nsfw"},
+}
+
+// filterPhotos adds a search filter with operators to a photo query.
+func (s *Repo) filterPhotos(q *gorm.DB, filter form.Filter) (*gorm.DB, error) {
+	var sql string
+	var values []interface{}
+
+	switch filter.Name {
+	case "Album":
+		sql = "photos.photo_uuid IN (SELECT photo_uuid FROM photos_albums WHERE album_uuid IN (?))"
+		values = append(values, filter.Values)
+	case "Person":
+		sql = "photos.id IN (SELECT files.photo_id FROM files JOIN files_faces ON files_faces.file_id = files.id " +
+			"JOIN people ON people.id = files_faces.person_id AND people.deleted_at IS NULL " +
+			"WHERE people.person_uuid IN (?) OR people.person_slug IN (?))"
+		values = append(values, filter.Values, filter.Values)
+	case "Label":
+		labelIds, err := s.filterLabelIds(filter.Values)
+
+		if err != nil {
+			return q, err
+		}
+
+		sql = "photos.id IN (SELECT photo_id FROM photos_labels WHERE label_id IN (?))"
+		values = append(values, labelIds)
+	default:
+		column, ok := photoFilterColumns[filter.Name]
+
+		if !ok {
+			return q, fmt.Errorf("%s: operators are not supported", strings.ToLower(filter.Name))
+		}
+
+		sql, values = column.condition(filter)
+	}
+
+	if filter.Negate {
+		return q.Where("NOT ("+sql+")", values...), nil
+	}
+
+	return q.Where("("+sql+")", values...), nil
+}
+
+// filterLabelIds returns the ids of labels with the given slugs including their categories.
+func (s *Repo) filterLabelIds(slugs []interface{}) (labelIds []uint, err error) {
+	for _, labelSlug := range slugs {
+		var label entity.Label
+		var categories []entity.Category
+
+		name := strings.ToLower(fmt.Sprint(labelSlug))
+
+		if err := s.db.First(&label, "label_slug = ?", name).Error; err != nil {
+			log.Errorf("search: label \"%s\" not found", name)
+			return labelIds, fmt.Errorf("label \"%s\" not found", name)
+		}
+
+		labelIds = append(labelIds, label.ID)
+
+		s.db.Where("category_id = ?", label.ID).Find(&categories)
+
+		for _, category := range categories {
+			labelIds = append(labelIds, category.LabelID)
+		}
+	}
+
+	return labelIds, nil
+}
+
+// condition returns an SQL condition and values matching one of the filter values or ranges.
+func (c filterColumn) condition(filter form.Filter) (string, []interface{}) {
+	var conditions []string
+	var values []interface{}
+
+	for _, v := range filter.Values {
+		if c.Like {
+			conditions = append(conditions, fmt.Sprintf("LOWER(%s) LIKE ?", c.Name))
+			values = append(values, fmt.Sprintf("%%%s%%", strings.ToLower(fmt.Sprint(v))))
+		} else if c.Bool {
+			conditions = append(conditions, fmt.Sprintf("%s = ?", c.Name))
+			values = append(values, txt.Bool(fmt.Sprint(v)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("%s = ?", c.Name))
+			values = append(values, filterValue(v))
+		}
+	}
+
+	for _, r := range filter.Ranges {
+		var bounds []string
+
+		if r.Min != nil {
+			bounds = append(bounds, fmt.Sprintf("%s >= ?", c.Name))
+			values = append(values, filterValue(r.Min))
+		}
+
+		if r.Max != nil {
+			if _, ok := r.Max.(time.Time); ok {
+				bounds = append(bounds, fmt.Sprintf("%s < ?", c.Name))
+			} else {
+				bounds = append(bounds, fmt.Sprintf("%s <= ?", c.Name))
+			}
+
+			values = append(values, filterValue(r.Max))
+		}
+
+		conditions = append(conditions, "("+strings.Join(bounds, " AND ")+")")
+	}
+
+	return strings.Join(conditions, " OR "), values
+}
+
+// filterValue returns dates in a format that can be compared on all supported databases.
+func filterValue(v interface{}) interface{} {
+	if t, ok := v.(time.Time); ok {
+		return t.Format("2006-01-02 15:04:05")
+	}
+
+	return v
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterColumn_Condition(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		sql, values := photoFilterColumns["Country"].condition(form.Filter{Name: "Country", Values: []interface{}{"de", "at"}})
+
+		assert.Equal(t, "photos.photo_country = ? OR photos.photo_country = ?", sql)
+		assert.Equal(t, []interface{}{"de", "at"}, values)
+	})
+	t.Run("like", func(t *testing.T) {
+		sql, values := photoFilterColumns["Title"].condition(form.Filter{Name: "Title", Values: []interface{}{"Dog"}})
+
+		assert.Equal(t, "LOWER(photos.photo_title) LIKE ?", sql)
+		assert.Equal(t, []interface{}{"%dog%"}, values)
+	})
+	t.Run("bool", func(t *testing.T) {
+		sql, values := photoFilterColumns["Flash"].condition(form.Filter{Name: "Flash", Values: []interface{}{"no"}, Negate: true})
+
+		assert.Equal(t, "photos.photo_flash = ?", sql)
+		assert.Equal(t, []interface{}{false}, values)
+	})
+	t.Run("ranges", func(t *testing.T) {
+		sql, values := photoFilterColumns["Iso"].condition(form.Filter{Name: "Iso", Values: []interface{}{100}, Ranges: []form.Range{{Min: 400, Max: 1600}, {Min: 3200}}})
+
+		assert.Equal(t, "photos.photo_iso = ? OR (photos.photo_iso >= ? AND photos.photo_iso <= ?) OR (photos.photo_iso >= ?)", sql)
+		assert.Equal(t, []interface{}{100, 400, 1600, 3200}, values)
+	})
+	t.Run("dates", func(t *testing.T) {
+		r := form.Range{Min: time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC), Max: time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC)}
+		sql, values := photoFilterColumns["Taken"].condition(form.Filter{Name: "Taken", Ranges: []form.Range{r}})
+
+		assert.Equal(t, "(photos.taken_at >= ? AND photos.taken_at < ?)", sql)
+		assert.Equal(t, []interface{}{"2019-06-01 00:00:00", "2019-09-01 00:00:00"}, values)
+	})
+}
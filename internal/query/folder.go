@@ -0,0 +1,112 @@
+package query
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// FolderResult contains a subdirectory of originals and the number of photos in it, including nested folders.
+type FolderResult struct {
+	FolderPath string
+	FolderName string
+	PhotoCount int
+}
+
+// FolderPath returns a clean relative originals path that can't point outside originals.
+func FolderPath(folder string) string {
+	return strings.Trim(path.Clean("/"+folder), "/")
+}
+
+// Folders returns the subdirectories of an originals folder with photo counts.
+func (s *Repo) Folders(folder string) (results []FolderResult, err error) {
+	folder = FolderPath(folder)
+
+	files, err := ioutil.ReadDir(path.Join(s.originalsPath, folder))
+
+	if err != nil {
+		return results, err
+	}
+
+	counts, err := s.folderCounts(folder)
+
+	if err != nil {
+		return results, err
+	}
+
+	for _, f := range files {
+		if !f.IsDir() || strings.HasPrefix(f.Name(), ".") || strings.HasPrefix(f.Name(), "@") {
+			continue
+		}
+
+		results = append(results, FolderResult{
+			FolderPath: strings.TrimPrefix(path.Join(folder, f.Name()), "/"),
+			FolderName: f.Name(),
+			PhotoCount: counts[f.Name()],
+		})
+	}
+
+	return results, nil
+}
+
+// folderCounts returns the number of photos below each direct subdirectory of a folder.
+func (s *Repo) folderCounts(folder string) (counts map[string]int, err error) {
+	var rows []struct {
+		PhotoPath  string
+		PhotoCount int
+	}
+
+	prefix := ""
+
+	if folder != "" {
+		prefix = folder + "/"
+	}
+
+	q := s.db.NewScope(nil).DB().
+		Table("photos").
+		Select("photo_path, COUNT(*) AS photo_count").
+		Where("deleted_at IS NULL AND photo_path LIKE ?", escapeLike(prefix)+"%").
+		Group("photo_path")
+
+	if err := q.Scan(&rows).Error; err != nil {
+		return counts, err
+	}
+
+	counts = make(map[string]int)
+
+	for _, row := range rows {
+		if !strings.HasPrefix(row.PhotoPath, prefix) || row.PhotoPath == folder {
+			continue
+		}
+
+		name := strings.SplitN(strings.TrimPrefix(row.PhotoPath, prefix), "/", 2)[0]
+		counts[name] += row.PhotoCount
+	}
+
+	return counts, nil
+}
+
+// FindFolderThumb returns the best photo file in a folder and its subdirectories.
+func (s *Repo) FindFolderThumb(folder string) (file entity.File, err error) {
+	folder = FolderPath(folder)
+
+	q := s.db.Where("files.file_primary AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL")
+
+	if folder != "" {
+		q = q.Where("photos.photo_path = ? OR photos.photo_path LIKE ?", folder, escapeLike(folder)+"/%")
+	}
+
+	err = q.Order(coverOrder).First(&file).Error
+
+	return file, err
+}
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike escapes wildcards in a LIKE pattern, so that folder names containing % or _ match literally.
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
@@ -0,0 +1,21 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolderPath(t *testing.T) {
+	assert.Equal(t, "", FolderPath(""))
+	assert.Equal(t, "", FolderPath("/"))
+	assert.Equal(t, "2019/Japan", FolderPath("/2019/Japan/"))
+	assert.Equal(t, "etc", FolderPath("../../etc"))
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "2019/Japan", escapeLike("2019/Japan"))
+	assert.Equal(t, `100\% Fun`, escapeLike("100% Fun"))
+	assert.Equal(t, `IMG\_2019`, escapeLike("IMG_2019"))
+	assert.Equal(t, `a\\b`, escapeLike(`a\b`))
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/capture"
 	"github.com/photoprism/photoprism/pkg/pluscode"
@@ -13,15 +14,16 @@ import (
 
 // GeoResult represents a photo for displaying it on a map.
 type GeoResult struct {
-	ID         string    `json:"ID"`
-	PhotoLat   float64   `json:"Lat"`
-	PhotoLng   float64   `json:"Lng"`
-	PhotoUUID  string    `json:"PhotoUUID"`
-	PhotoTitle string    `json:"PhotoTitle"`
-	FileHash   string    `json:"FileHash"`
-	FileWidth  int       `json:"FileWidth"`
-	FileHeight int       `json:"FileHeight"`
-	TakenAt    time.Time `json:"TakenAt"`
+	ID            string    `json:"ID"`
+	PhotoLat      float64   `json:"Lat"`
+	PhotoLng      float64   `json:"Lng"`
+	PhotoUUID     string    `json:"PhotoUUID"`
+	PhotoTitle    string    `json:"PhotoTitle"`
+	PhotoFavorite bool      `json:"PhotoFavorite"`
+	FileHash      string    `json:"FileHash"`
+	FileWidth     int       `json:"FileWidth"`
+	FileHeight    int       `json:"FileHeight"`
+	TakenAt       time.Time `json:"TakenAt"`
 }
 
 // Geo searches for photos based on a Form and returns a PhotoResult slice.
@@ -35,18 +37,47 @@ func (s *Repo) Geo(f form.GeoSearch) (results []GeoResult, err error) {
 	q := s.db.NewScope(nil).DB()
 
 	q = q.Table("photos").
-		Select(`photos.id, photos.photo_uuid, photos.photo_lat, photos.photo_lng, photos.photo_title, photos.taken_at, 
+		Select(`photos.id, photos.photo_uuid, photos.photo_lat, photos.photo_lng, photos.photo_title, photos.photo_favorite, photos.taken_at, 
 		files.file_hash, files.file_width, files.file_height`).
 		Joins(`JOIN files ON files.photo_id = photos.id 
 		AND files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL`).
-		Where("photos.deleted_at IS NULL").
-		Where("photos.photo_lat <> 0").
 		Group("photos.id, files.id")
 
+	q, area, hasArea, err := whereGeo(q, f)
+
+	if err != nil {
+		return results, err
+	}
+
+	q = q.Order("taken_at, photos.photo_uuid")
+
+	if result := q.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	if hasArea {
+		var inArea []GeoResult
+
+		for _, r := range results {
+			if area.Contains(r.PhotoLat, r.PhotoLng) {
+				inArea = append(inArea, r)
+			}
+		}
+
+		return inArea, nil
+	}
+
+	return results, nil
+}
+
+// whereGeo adds the filters of a geo search form, the area must still be checked with area.Contains.
+func whereGeo(q *gorm.DB, f form.GeoSearch) (_ *gorm.DB, area s2.Area, hasArea bool, err error) {
+	q = q.Where("photos.deleted_at IS NULL").
+		Where("photos.photo_lat <> 0")
+
 	if f.Query != "" {
-		q = q.Joins("LEFT JOIN photos_keywords ON photos_keywords.photo_id = photos.id").
-			Joins("LEFT JOIN keywords ON photos_keywords.keyword_id = keywords.id").
-			Where("keywords.keyword LIKE ?", strings.ToLower(f.Query)+"%")
+		q = q.Where(`photos.id IN (SELECT photos_keywords.photo_id FROM photos_keywords 
+		JOIN keywords ON photos_keywords.keyword_id = keywords.id WHERE keywords.keyword LIKE ?)`, strings.ToLower(f.Query)+"%")
 	}
 
 	if f.S2 != "" {
@@ -55,19 +86,10 @@ func (s *Repo) Geo(f form.GeoSearch) (results []GeoResult, err error) {
 	} else if f.Olc != "" {
 		s2Min, s2Max := s2.Range(pluscode.S2(f.Olc), 7)
 		q = q.Where("photos.location_id BETWEEN ? AND ?", s2Min, s2Max)
-	} else {
-		// Inaccurate distance search, but probably 'good enough' for now
-		if f.Lat > 0 {
-			latMin := f.Lat - SearchRadius*float64(f.Dist)
-			latMax := f.Lat + SearchRadius*float64(f.Dist)
-			q = q.Where("photos.photo_lat BETWEEN ? AND ?", latMin, latMax)
-		}
-
-		if f.Lng > 0 {
-			lngMin := f.Lng - SearchRadius*float64(f.Dist)
-			lngMax := f.Lng + SearchRadius*float64(f.Dist)
-			q = q.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
-		}
+	} else if area, hasArea, err = searchArea(f.Lat, f.Lng, f.Dist, f.Bbox, f.Polygon); err != nil {
+		return q, area, false, err
+	} else if hasArea {
+		q = whereArea(q, area)
 	}
 
 	if !f.Before.IsZero() {
@@ -78,11 +100,5 @@ func (s *Repo) Geo(f form.GeoSearch) (results []GeoResult, err error) {
 		q = q.Where("photos.taken_at >= ?", f.After.Format("2006-01-02"))
 	}
 
-	q = q.Order("taken_at, photos.photo_uuid")
-
-	if result := q.Scan(&results); result.Error != nil {
-		return results, result.Error
-	}
-
-	return results, nil
+	return q, area, hasArea, nil
 }
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/s2"
+)
+
+// DefaultDist is the default search radius in km.
+const DefaultDist = 20
+
+// MaxDist is the maximum search radius in km.
+const MaxDist = 5000
+
+// searchArea returns the geographic search area of a form, a polygon takes precedence over
+// a bounding box and a bounding box over a radius around lat and lng.
+func searchArea(lat, lng float64, dist uint, bbox, polygon string) (area s2.Area, ok bool, err error) {
+	if polygon != "" {
+		area, err = s2.GeoJSON([]byte(polygon))
+
+		return area, err == nil, err
+	}
+
+	if bbox != "" {
+		area, err = parseBbox(bbox)
+
+		return area, err == nil, err
+	}
+
+	if lat == 0 && lng == 0 {
+		return area, false, nil
+	}
+
+	if dist == 0 {
+		dist = DefaultDist
+	} else if dist > MaxDist {
+		dist = MaxDist
+	}
+
+	area, err = s2.Circle(lat, lng, float64(dist))
+
+	return area, err == nil, err
+}
+
+// parseBbox returns the area of a bounding box in GeoJSON order: west, south, east, north.
+func parseBbox(bbox string) (area s2.Area, err error) {
+	values := strings.Split(bbox, ",")
+
+	if len(values) != 4 {
+		return area, fmt.Errorf("bbox must contain 4 comma separated values")
+	}
+
+	var v [4]float64
+
+	for i, s := range values {
+		if v[i], err = strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+			return area, fmt.Errorf("bbox: \"%s\" is not a number", s)
+		}
+	}
+
+	return s2.Rect(v[1], v[0], v[3], v[2])
+}
+
+// whereArea prefilters photos by the cells covering an area and its bounding box.
+// Results must be checked with area.Contains, as coverings are slightly larger than the area.
+func whereArea(q *gorm.DB, area s2.Area) *gorm.DB {
+	var conditions []string
+	var values []interface{}
+
+	for _, r := range area.Ranges() {
+		conditions = append(conditions, "photos.location_id BETWEEN ? AND ?")
+		values = append(values, r.Min, r.Max)
+	}
+
+	// Photos without location id still have coordinates, e.g. if geocoding failed
+	conditions = append(conditions, "photos.location_id IS NULL OR photos.location_id = ''")
+
+	q = q.Where("("+strings.Join(conditions, " OR ")+")", values...)
+
+	q = q.Where("(photos.photo_lat <> 0 OR photos.photo_lng <> 0)")
+
+	latMin, lngMin, latMax, lngMax := area.Bounds()
+	q = q.Where("photos.photo_lat BETWEEN ? AND ?", latMin, latMax)
+
+	if lngMin <= lngMax {
+		q = q.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
+	} else {
+		q = q.Where("(photos.photo_lng >= ? OR photos.photo_lng <= ?)", lngMin, lngMax)
+	}
+
+	return q
+}
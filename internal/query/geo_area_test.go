@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/pkg/s2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchArea(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		_, ok, err := searchArea(0, 0, 10, "", "")
+
+		assert.Nil(t, err)
+		assert.False(t, ok)
+	})
+	t.Run("radius", func(t *testing.T) {
+		area, ok, err := searchArea(-33.8688, -70.6693, 0, "", "")
+
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.True(t, area.Contains(-33.9, -70.7))
+		assert.False(t, area.Contains(-33.9, -71))
+	})
+	t.Run("bbox", func(t *testing.T) {
+		area, ok, err := searchArea(0, 0, 0, "13.3,52.4,13.5,52.6", "")
+
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.True(t, area.Contains(52.52, 13.405))
+	})
+	t.Run("invalid bbox", func(t *testing.T) {
+		_, _, err := searchArea(0, 0, 0, "13.3,52.4,13.5", "")
+
+		assert.EqualError(t, err, "bbox must contain 4 comma separated values")
+
+		_, _, err = searchArea(0, 0, 0, "13.3,52.4,13.5,north", "")
+
+		assert.EqualError(t, err, "bbox: \"north\" is not a number")
+	})
+	t.Run("invalid polygon", func(t *testing.T) {
+		_, _, err := searchArea(0, 0, 0, "", "{")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPhotosInArea(t *testing.T) {
+	area, err := s2.Rect(0, 0, 10, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	photos := []PhotoResult{{ID: 1, PhotoLat: 5, PhotoLng: 5}, {ID: 2, PhotoLat: 11, PhotoLng: 5}, {ID: 3, PhotoLat: 1, PhotoLng: 1}, {ID: 4, PhotoLat: 2, PhotoLng: 2}}
+
+	result := photosInArea(photos, area)
+
+	if assert.Len(t, result, 3) {
+		assert.Equal(t, uint(1), result[0].ID)
+		assert.Equal(t, uint(3), result[1].ID)
+		assert.Equal(t, uint(4), result[2].ID)
+	}
+}
@@ -0,0 +1,167 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+// MaxZoom is the maximum map zoom level supported by clustering.
+const MaxZoom = 19
+
+// GeoCluster represents photos in the same grid cell for displaying them on a map.
+type GeoCluster struct {
+	ID         string  `json:"ID"`
+	Lat        float64 `json:"Lat"`
+	Lng        float64 `json:"Lng"`
+	Count      int     `json:"Count"`
+	PhotoUUID  string  `json:"PhotoUUID"`
+	FileHash   string  `json:"FileHash"`
+	FileWidth  int     `json:"FileWidth"`
+	FileHeight int     `json:"FileHeight"`
+	LatMin     float64 `json:"-"`
+	LatMax     float64 `json:"-"`
+	LngMin     float64 `json:"-"`
+	LngMax     float64 `json:"-"`
+}
+
+// geoCell represents the aggregated photos of a grid cell.
+type geoCell struct {
+	CellLat   int
+	CellLng   int
+	Count     int
+	Lat       float64
+	Lng       float64
+	LatMin    float64
+	LatMax    float64
+	LngMin    float64
+	LngMax    float64
+	PhotoUUID string
+}
+
+// geoCover represents the primary file of a cluster's cover photo.
+type geoCover struct {
+	PhotoUUID  string
+	FileHash   string
+	FileWidth  int
+	FileHeight int
+}
+
+// ClusterSize returns the grid cell size in degrees used for clustering at a map zoom level,
+// cells are about a quarter of a map tile wide.
+func ClusterSize(zoom int) float64 {
+	if zoom < 0 {
+		zoom = 0
+	} else if zoom > MaxZoom {
+		zoom = MaxZoom
+	}
+
+	return 360 / math.Pow(2, float64(zoom+2))
+}
+
+// GeoClusters searches for photos like Geo and groups them by grid cell at the form's zoom level.
+// Photos are aggregated by the database, so circles and polygons are only matched by their bounds.
+// The cover of a cluster is the most recent favorite or the most recent photo if there are no favorites.
+func (s *Repo) GeoClusters(f form.GeoSearch) (results []GeoCluster, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("clusters: %+v", f)))
+
+	size := ClusterSize(f.Zoom)
+
+	q := s.db.NewScope(nil).DB()
+
+	q = q.Table("photos").
+		Select(`FLOOR(photos.photo_lat / ?) AS cell_lat, FLOOR(photos.photo_lng / ?) AS cell_lng, COUNT(*) AS count,
+		AVG(photos.photo_lat) AS lat, AVG(photos.photo_lng) AS lng,
+		MIN(photos.photo_lat) AS lat_min, MAX(photos.photo_lat) AS lat_max,
+		MIN(photos.photo_lng) AS lng_min, MAX(photos.photo_lng) AS lng_max,
+		SUBSTRING_INDEX(GROUP_CONCAT(photos.photo_uuid ORDER BY photos.photo_favorite DESC, photos.taken_at DESC), ',', 1) AS photo_uuid`, size, size).
+		Where(`EXISTS (SELECT 1 FROM files WHERE files.photo_id = photos.id
+		AND files.file_missing = 0 AND files.file_primary AND files.deleted_at IS NULL)`).
+		Group("cell_lat, cell_lng")
+
+	if q, _, _, err = whereGeo(q, f); err != nil {
+		return results, err
+	}
+
+	var cells []geoCell
+
+	if err := q.Scan(&cells).Error; err != nil {
+		return results, err
+	}
+
+	if len(cells) == 0 {
+		return results, nil
+	}
+
+	covers, err := s.geoCovers(cells)
+
+	if err != nil {
+		return results, err
+	}
+
+	for _, cell := range cells {
+		c := GeoCluster{
+			ID:        fmt.Sprintf("%d-%d-%d", f.Zoom, cell.CellLat, cell.CellLng),
+			Lat:       cell.Lat,
+			Lng:       cell.Lng,
+			Count:     cell.Count,
+			PhotoUUID: cell.PhotoUUID,
+			LatMin:    cell.LatMin,
+			LatMax:    cell.LatMax,
+			LngMin:    cell.LngMin,
+			LngMax:    cell.LngMax,
+		}
+
+		if cover, ok := covers[cell.PhotoUUID]; ok {
+			c.FileHash = cover.FileHash
+			c.FileWidth = cover.FileWidth
+			c.FileHeight = cover.FileHeight
+		}
+
+		results = append(results, c)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+
+		return results[i].ID < results[j].ID
+	})
+
+	return results, nil
+}
+
+// geoCovers returns the primary files of the cluster cover photos by photo uuid.
+func (s *Repo) geoCovers(cells []geoCell) (result map[string]geoCover, err error) {
+	result = make(map[string]geoCover, len(cells))
+
+	uuids := make([]string, len(cells))
+
+	for i, cell := range cells {
+		uuids[i] = cell.PhotoUUID
+	}
+
+	var covers []geoCover
+
+	if err := s.db.Table("files").
+		Select("photo_uuid, file_hash, file_width, file_height").
+		Where("photo_uuid IN (?) AND file_missing = 0 AND file_primary AND deleted_at IS NULL", uuids).
+		Scan(&covers).Error; err != nil {
+		return result, err
+	}
+
+	for _, cover := range covers {
+		result[cover.PhotoUUID] = cover
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterSize(t *testing.T) {
+	assert.Equal(t, 90.0, ClusterSize(-1))
+	assert.Equal(t, 90.0, ClusterSize(0))
+	assert.Equal(t, 360.0/4096, ClusterSize(10))
+	assert.Equal(t, ClusterSize(MaxZoom), ClusterSize(25))
+}
+
+func TestRepo_GeoClusters(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("search all photos", func(t *testing.T) {
+		f := form.NewGeoSearch("")
+		f.Zoom = 3
+
+		result, err := search.GeoClusters(f)
+
+		assert.Nil(t, err)
+
+		count := 0
+
+		for _, c := range result {
+			count += c.Count
+		}
+
+		assert.Equal(t, 4, count)
+	})
+	t.Run("cover files", func(t *testing.T) {
+		f := form.NewGeoSearch("")
+		f.Zoom = MaxZoom
+
+		result, err := search.GeoClusters(f)
+
+		assert.Nil(t, err)
+
+		for _, c := range result {
+			assert.NotEmpty(t, c.PhotoUUID)
+			assert.NotEmpty(t, c.FileHash)
+			assert.True(t, c.LatMin <= c.Lat && c.Lat <= c.LatMax)
+			assert.True(t, c.LngMin <= c.Lng && c.Lng <= c.LngMax)
+		}
+	})
+	t.Run("empty bbox", func(t *testing.T) {
+		f := form.NewGeoSearch("")
+		f.Zoom = 3
+		f.Bbox = "-1,-1,-0.5,-0.5"
+
+		result, err := search.GeoClusters(f)
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+}
@@ -19,6 +19,7 @@ type LabelResult struct {
 	UpdatedAt        time.Time
 	DeletedAt        time.Time
 	LabelUUID        string
+	CoverUUID        string
 	LabelSlug        string
 	LabelName        string
 	LabelPriority    int
@@ -50,41 +51,61 @@ func (s *Repo) FindLabelByUUID(labelUUID string) (label entity.Label, err error)
 func (s *Repo) FindLabelThumbBySlug(labelSlug string) (file entity.File, err error) {
 	// s.db.LogMode(true)
 
-	if err := s.db.Where("files.file_primary AND files.deleted_at IS NULL").
-		Joins("JOIN labels ON labels.label_slug = ?", labelSlug).
-		Joins("JOIN photos_labels ON photos_labels.label_id = labels.id AND photos_labels.photo_id = files.photo_id").
-		Order("photos_labels.label_uncertainty ASC").
-		First(&file).Error; err != nil {
-		return file, err
-	}
-
-	return file, nil
+	return s.findLabelThumb("labels.label_slug = ?", labelSlug)
 }
 
 // FindLabelThumbByUUID returns a label preview file based on the label UUID.
 func (s *Repo) FindLabelThumbByUUID(labelUUID string) (file entity.File, err error) {
 	// Search matching label
-	err = s.db.Where("files.file_primary AND files.deleted_at IS NULL").
-		Joins("JOIN labels ON labels.label_uuid = ?", labelUUID).
-		Joins("JOIN photos_labels ON photos_labels.label_id = labels.id AND photos_labels.photo_id = files.photo_id").
-		Order("photos_labels.label_uncertainty ASC").
-		First(&file).Error
+	file, err = s.findLabelThumb("labels.label_uuid = ?", labelUUID)
 
 	if err == nil {
 		return file, nil
 	}
 
 	// If failed, search for category instead
-	err = s.db.Where("files.file_primary AND files.deleted_at IS NULL").
+	err = s.db.Where("files.file_primary AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
 		Joins("JOIN photos_labels ON photos_labels.photo_id = files.photo_id").
 		Joins("JOIN categories c ON photos_labels.label_id = c.label_id").
 		Joins("JOIN labels ON c.category_id = labels.id AND labels.label_uuid= ?", labelUUID).
+		Order(coverOrder).
+		Order("photos_labels.label_uncertainty ASC").
+		First(&file).Error
+
+	return file, err
+}
+
+// findLabelThumb returns the cover photo file of the first matching label if set,
+// otherwise the best photo is picked automatically.
+func (s *Repo) findLabelThumb(where string, value string) (file entity.File, err error) {
+	q := s.db.Where("files.file_primary AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Joins("JOIN labels ON "+where, value).
+		Joins("JOIN photos_labels ON photos_labels.label_id = labels.id AND photos_labels.photo_id = files.photo_id").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL")
+
+	if err := q.Where("labels.cover_uuid = files.photo_uuid").First(&file).Error; err == nil {
+		return file, nil
+	}
+
+	err = q.Order(coverOrder).
 		Order("photos_labels.label_uncertainty ASC").
 		First(&file).Error
 
 	return file, err
 }
 
+// FindPhotoLabel returns the label assignment of a photo.
+func (s *Repo) FindPhotoLabel(labelID uint, photoUUID string) (result entity.PhotoLabel, err error) {
+	if err := s.db.Joins("JOIN photos ON photos.id = photos_labels.photo_id").
+		Where("photos_labels.label_id = ? AND photos.photo_uuid = ?", labelID, photoUUID).
+		First(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
 // Labels searches labels based on their name.
 func (s *Repo) Labels(f form.LabelSearch) (results []LabelResult, err error) {
 	if err := f.ParseQueryString(); err != nil {
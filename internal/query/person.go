@@ -0,0 +1,123 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+)
+
+// PersonResult contains found people
+type PersonResult struct {
+	ID         uint
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	PersonUUID string
+	PersonSlug string
+	PersonName string
+	FaceCount  int
+}
+
+// FaceResult contains the faces of a person
+type FaceResult struct {
+	ID        uint
+	FileID    uint
+	FileHash  string
+	PhotoUUID string
+	FaceX     float32
+	FaceY     float32
+	FaceW     float32
+	FaceH     float32
+	FaceScore float32
+}
+
+// FindPersonByUUID returns a person based on the person UUID.
+func (s *Repo) FindPersonByUUID(personUUID string) (person entity.Person, err error) {
+	if err := s.db.Where("person_uuid = ?", personUUID).First(&person).Error; err != nil {
+		return person, err
+	}
+
+	return person, nil
+}
+
+// FindPersonBySlug returns a named person based on the slug name.
+func (s *Repo) FindPersonBySlug(personSlug string) (person entity.Person, err error) {
+	if err := s.db.Where("person_slug = ? AND person_slug <> ''", personSlug).First(&person).Error; err != nil {
+		return person, err
+	}
+
+	return person, nil
+}
+
+// People searches people, named people are listed first.
+func (s *Repo) People(f form.PersonSearch) (results []PersonResult, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("people: %+v", f)))
+
+	q := s.db.NewScope(nil).DB()
+
+	q = q.Table("people").
+		Select(`people.*`).
+		Where("people.deleted_at IS NULL")
+
+	if f.ID != "" {
+		q = q.Where("people.person_uuid = ?", f.ID)
+
+		if result := q.Scan(&results); result.Error != nil {
+			return results, result.Error
+		}
+
+		return results, nil
+	}
+
+	if f.Query != "" {
+		likeString := "%" + strings.ToLower(f.Query) + "%"
+		q = q.Where("LOWER(people.person_name) LIKE ? OR people.person_slug = ?", likeString, slug.Make(f.Query))
+	}
+
+	if f.Slug != "" {
+		q = q.Where("people.person_slug = ?", f.Slug)
+	}
+
+	if f.Named {
+		q = q.Where("people.person_name <> ''")
+	}
+
+	switch f.Order {
+	case "name":
+		q = q.Order("people.person_name = '', people.person_slug ASC")
+	default:
+		q = q.Order("people.person_name = '', people.face_count DESC, people.id ASC")
+	}
+
+	if f.Count > 0 && f.Count <= 1000 {
+		q = q.Limit(f.Count).Offset(f.Offset)
+	} else {
+		q = q.Limit(100).Offset(0)
+	}
+
+	if result := q.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	return results, nil
+}
+
+// PersonFaces returns all faces assigned to a person.
+func (s *Repo) PersonFaces(personID uint) (results []FaceResult, err error) {
+	err = s.db.Table("files_faces").
+		Select("files_faces.*, files.file_hash, files.photo_uuid").
+		Joins("JOIN files ON files.id = files_faces.file_id AND files.deleted_at IS NULL").
+		Where("files_faces.person_id = ?", personID).
+		Order("files_faces.face_score DESC").
+		Scan(&results).Error
+
+	return results, err
+}
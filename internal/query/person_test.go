@@ -0,0 +1,87 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepo_FindPersonByUUID(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("person found", func(t *testing.T) {
+		person, err := search.FindPersonByUUID("20")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "Jens Mander", person.PersonName)
+	})
+	t.Run("person not found", func(t *testing.T) {
+		_, err := search.FindPersonByUUID("111")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRepo_FindPersonBySlug(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("named person", func(t *testing.T) {
+		person, err := search.FindPersonBySlug("jens-mander")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "20", person.PersonUUID)
+	})
+	t.Run("unnamed people are ignored", func(t *testing.T) {
+		_, err := search.FindPersonBySlug("")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRepo_People(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	t.Run("named people", func(t *testing.T) {
+		f := form.NewPersonSearch("named:true count:10")
+
+		result, err := search.People(f)
+
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, len(result), 1)
+
+		for _, r := range result {
+			assert.NotEmpty(t, r.PersonName)
+		}
+	})
+	t.Run("search by name", func(t *testing.T) {
+		f := form.NewPersonSearch("jens")
+		f.Count = 10
+
+		result, err := search.People(f)
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "Jens Mander", result[0].PersonName)
+	})
+}
+
+func TestRepo_PersonFaces(t *testing.T) {
+	conf := config.TestConfig()
+
+	search := New(conf.OriginalsPath(), conf.Db())
+
+	result, err := search.PersonFaces(1)
+
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, len(result), 1)
+	assert.Equal(t, "123xxx", result[0].FileHash)
+	assert.Equal(t, "654", result[0].PhotoUUID)
+}
@@ -9,42 +9,56 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/s2"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // PhotoResult contains found photos and their main file plus other meta data.
 type PhotoResult struct {
 	// Photo
-	ID               uint
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	DeletedAt        time.Time
-	TakenAt          time.Time
-	TakenAtLocal     time.Time
-	TimeZone         string
-	PhotoUUID        string
-	PhotoPath        string
-	PhotoName        string
-	PhotoTitle       string
-	PhotoDescription string
-	PhotoYear        int
-	PhotoMonth       int
-	PhotoCountry     string
-	PhotoArtist      string
-	PhotoKeywords    string
-	PhotoColors      string
-	PhotoColor       string
-	PhotoFavorite    bool
-	PhotoPrivate     bool
-	PhotoSensitive   bool
-	PhotoStory       bool
-	PhotoLat         float64
-	PhotoLng         float64
-	PhotoAltitude    int
-	PhotoFocalLength int
-	PhotoIso         int
-	PhotoFNumber     float64
-	PhotoExposure    string
+	ID                uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         time.Time
+	TakenAt           time.Time
+	TakenAtLocal      time.Time
+	TakenSrc          string
+	TimeZone          string
+	PhotoUUID         string
+	PhotoPath         string
+	PhotoName         string
+	PhotoTitle        string
+	PhotoDescription  string
+	PhotoYear         int
+	PhotoMonth        int
+	PhotoCountry      string
+	PhotoArtist       string
+	PhotoKeywords     string
+	PhotoColors       string
+	PhotoColor        string
+	PhotoFavorite     bool
+	PhotoPrivate      bool
+	PhotoSensitive    bool
+	PhotoStory        bool
+	PhotoLat          float64
+	PhotoLng          float64
+	PhotoAltitude     int
+	PhotoDirection    float64
+	PhotoFocalLength  int
+	PhotoIso          int
+	PhotoFNumber      float64
+	PhotoExposure     string
+	PhotoBias         float64
+	PhotoProgram      string
+	PhotoMetering     string
+	PhotoWhiteBalance string
+	PhotoFlash        bool
+	PhotoFlashMode    string
+	PhotoDistance     float64
+	GPSSpeed          float64
+	SearchScore       int
 
 	// Camera
 	CameraID    uint
@@ -76,6 +90,7 @@ type PhotoResult struct {
 	FilePerceptualHash string
 	FileType           string
 	FileMime           string
+	FileSoftware       string
 	FileWidth          int
 	FileHeight         int
 	FileOrientation    int
@@ -106,20 +121,48 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 
 	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("photos: %+v", f)))
 
+	var text search.Query
+
+	if f.Query != "" {
+		if len(strings.TrimSpace(f.Query)) < 2 {
+			return results, fmt.Errorf("query too short")
+		}
+
+		if text, err = search.Parse(f.Query); err != nil {
+			return results, err
+		}
+	}
+
 	q := s.db.NewScope(nil).DB()
 
 	// q.LogMode(true)
 
-	q = q.Table("photos").
-		Select(`photos.*,
+	columns := `photos.*,
 		files.id AS file_id, files.file_uuid, files.file_primary, files.file_missing, files.file_name, files.file_hash, 
-		files.file_type, files.file_mime, files.file_width, files.file_height, files.file_aspect_ratio, 
+		files.file_type, files.file_mime, files.file_software, files.file_width, files.file_height, files.file_aspect_ratio, 
 		files.file_orientation, files.file_main_color, files.file_colors, files.file_luminance, files.file_chroma,
 		cameras.camera_make, cameras.camera_model,
 		lenses.lens_make, lenses.lens_model,
-		places.loc_label, places.loc_city, places.loc_state, places.loc_country
-		`).
-		Joins("JOIN files ON files.photo_id = photos.id AND files.file_primary AND files.deleted_at IS NULL").
+		places.loc_label, places.loc_city, places.loc_state, places.loc_country`
+
+	if text.Empty() {
+		q = q.Select(columns)
+	} else {
+		score, values := searchScore(text)
+		q = q.Select(columns+", "+score+" AS search_score", values...)
+	}
+
+	filesJoin := "JOIN files ON files.photo_id = photos.id AND files.file_primary AND files.deleted_at IS NULL"
+
+	// RAW and HEIF files that failed to convert are never primary files, so photos without a primary
+	// file are returned with their first file instead, which shows a placeholder thumbnail.
+	if f.Error {
+		filesJoin = `JOIN files ON files.id = (SELECT f.id FROM files f WHERE f.photo_id = photos.id 
+		AND f.file_missing = 0 AND f.deleted_at IS NULL ORDER BY f.file_primary DESC, f.id LIMIT 1)`
+	}
+
+	q = q.Table("photos").
+		Joins(filesJoin).
 		Joins("JOIN cameras ON cameras.id = photos.camera_id").
 		Joins("JOIN lenses ON lenses.id = photos.lens_id").
 		Joins("JOIN places ON photos.place_id = places.id").
@@ -127,6 +170,10 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 		Where("files.file_missing = 0").
 		Group("photos.id, files.id")
 
+	if f.Error {
+		q = q.Where("EXISTS (SELECT 1 FROM files e WHERE e.photo_id = photos.id AND e.file_error <> '' AND e.deleted_at IS NULL)")
+	}
+
 	if f.ID != "" {
 		q = q.Where("photos.photo_uuid = ?", f.ID)
 
@@ -158,42 +205,21 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 		}
 	}
 
+	if f.Person != "" {
+		q = q.Where("photos.id IN (SELECT files.photo_id FROM files JOIN files_faces ON files_faces.file_id = files.id "+
+			"JOIN people ON people.id = files_faces.person_id AND people.deleted_at IS NULL "+
+			"WHERE people.person_uuid = ? OR people.person_slug = ?)", f.Person, strings.ToLower(f.Person))
+	}
+
 	if f.Location == true {
 		q = q.Where("location_id > 0")
+	}
 
-		if f.Query != "" {
-			q = q.Joins("LEFT JOIN photos_keywords ON photos_keywords.photo_id = photos.id").
-				Joins("LEFT JOIN keywords ON photos_keywords.keyword_id = keywords.id").
-				Where("keywords.keyword LIKE ?", strings.ToLower(f.Query)+"%")
-		}
-	} else if f.Query != "" {
-		if len(f.Query) < 2 {
-			return results, fmt.Errorf("query too short")
-		}
-
-		slugString := slug.Make(f.Query)
-		lowerString := strings.ToLower(f.Query)
-		likeString := lowerString + "%"
-
-		q = q.Joins("LEFT JOIN photos_keywords ON photos_keywords.photo_id = photos.id").
-			Joins("LEFT JOIN keywords ON photos_keywords.keyword_id = keywords.id")
-
-		if result := s.db.First(&label, "label_slug = ?", slugString); result.Error != nil {
-			log.Infof("search: label \"%s\" not found, using fuzzy search", f.Query)
-
-			q = q.Where("keywords.keyword LIKE ?", likeString)
-		} else {
-			labelIds = append(labelIds, label.ID)
-
-			s.db.Where("category_id = ?", label.ID).Find(&categories)
-
-			for _, category := range categories {
-				labelIds = append(labelIds, category.LabelID)
-			}
-
-			log.Infof("search: label \"%s\" includes %d categories", label.LabelName, len(labelIds))
+	if !text.Empty() {
+		q = searchText(q, text)
 
-			q = q.Where("photos_labels.label_id IN (?) OR keywords.keyword LIKE ?", labelIds, likeString)
+		if f.Order == "" {
+			f.Order = "relevance"
 		}
 	}
 
@@ -203,12 +229,16 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 		q = q.Where("photos.deleted_at IS NULL")
 	}
 
-	if f.Error {
-		q = q.Where("files.file_error <> ''")
-	}
-
 	if f.Album != "" {
-		q = q.Joins("JOIN photos_albums ON photos_albums.photo_uuid = photos.photo_uuid").Where("photos_albums.album_uuid = ?", f.Album)
+		q = q.Joins("JOIN photos_albums ON photos_albums.photo_uuid = photos.photo_uuid").Where("photos_albums.album_uuid = ?", f.Album).
+			Group("photos.id, files.id, photos_albums.photo_uuid, photos_albums.album_uuid")
+
+		// Use the album sort order by default
+		if f.Order == "" {
+			if album, err := s.FindAlbumByUUID(f.Album); err == nil {
+				f.Order = album.AlbumOrder
+			}
+		}
 	}
 
 	if f.Camera > 0 {
@@ -255,6 +285,10 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 		q = q.Where("photos.photo_country = ?", f.Country)
 	}
 
+	if f.Dated != "" {
+		q = q.Where("photos.taken_src = ?", strings.ToLower(f.Dated))
+	}
+
 	if f.Title != "" {
 		q = q.Where("LOWER(photos.photo_title) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(f.Title)))
 	}
@@ -295,23 +329,70 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 		q = q.Where("photos.photo_f_number <= ?", f.Fmax)
 	}
 
-	if f.Dist == 0 {
-		f.Dist = 20
-	} else if f.Dist > 5000 {
-		f.Dist = 5000
+	area, hasArea, err := searchArea(f.Lat, f.Lng, f.Dist, f.Bbox, f.Polygon)
+
+	if err != nil {
+		return results, err
+	} else if hasArea {
+		q = whereArea(q, area)
+	}
+
+	if f.Iso > 0 {
+		q = q.Where("photos.photo_iso = ?", f.Iso)
+	}
+
+	if f.F > 0 {
+		q = whereNear(q, "photos.photo_f_number", f.F, 0.05)
+	}
+
+	if f.Bias != 0 {
+		q = whereNear(q, "photos.photo_bias", f.Bias, 0.05)
+	}
+
+	if f.Program != "" {
+		q = q.Where("photos.photo_program = ?", strings.ToLower(f.Program))
+	}
+
+	if f.Metering != "" {
+		q = q.Where("photos.photo_metering = ?", strings.ToLower(f.Metering))
+	}
+
+	if f.Balance != "" {
+		q = q.Where("photos.photo_white_balance = ?", strings.ToLower(f.Balance))
+	}
+
+	if f.Flash != "" {
+		q = q.Where("photos.photo_flash = ?", txt.Bool(f.Flash))
+	}
+
+	if f.Flashmode != "" {
+		q = q.Where("photos.photo_flash_mode = ?", strings.ToLower(f.Flashmode))
 	}
 
-	// Inaccurate distance search, but probably 'good enough' for now
-	if f.Lat > 0 {
-		latMin := f.Lat - SearchRadius*float64(f.Dist)
-		latMax := f.Lat + SearchRadius*float64(f.Dist)
-		q = q.Where("photos.photo_lat BETWEEN ? AND ?", latMin, latMax)
+	if f.Distance > 0 {
+		q = whereNear(q, "photos.photo_distance", f.Distance, 0.005)
 	}
 
-	if f.Lng > 0 {
-		lngMin := f.Lng - SearchRadius*float64(f.Dist)
-		lngMax := f.Lng + SearchRadius*float64(f.Dist)
-		q = q.Where("photos.photo_lng BETWEEN ? AND ?", lngMin, lngMax)
+	if f.Direction > 0 {
+		q = whereNear(q, "photos.photo_direction", f.Direction, 0.5)
+	}
+
+	if f.Speed > 0 {
+		q = whereNear(q, "photos.gps_speed", f.Speed, 0.5)
+	}
+
+	if f.Software != "" {
+		q = q.Where("LOWER(files.file_software) LIKE ?", fmt.Sprintf("%%%s%%", strings.ToLower(f.Software)))
+	}
+
+	if !f.Taken.IsZero() {
+		q = q.Where("photos.taken_at >= ? AND photos.taken_at < ?", f.Taken.Format("2006-01-02"), f.Taken.AddDate(0, 0, 1).Format("2006-01-02"))
+	}
+
+	for _, filter := range f.Filters {
+		if q, err = s.filterPhotos(q, filter); err != nil {
+			return results, err
+		}
 	}
 
 	if !f.Before.IsZero() {
@@ -324,30 +405,100 @@ func (s *Repo) Photos(f form.PhotoSearch) (results []PhotoResult, err error) {
 
 	switch f.Order {
 	case "relevance":
-		q = q.Order("photo_story DESC, photo_favorite DESC, taken_at DESC")
+		if text.Empty() {
+			q = q.Order("photo_story DESC, photo_favorite DESC, taken_at DESC")
+		} else {
+			q = q.Order("search_score DESC, photo_favorite DESC, taken_at DESC, photos.photo_uuid")
+		}
 	case "newest":
 		q = q.Order("taken_at DESC, photos.photo_uuid")
 	case "oldest":
 		q = q.Order("taken_at, photos.photo_uuid")
 	case "imported":
 		q = q.Order("photos.id DESC")
+	case "title":
+		q = q.Order("photos.photo_title, taken_at, photos.photo_uuid")
+	case "added":
+		if f.Album != "" {
+			q = q.Order("photos_albums.created_at DESC, photos.photo_uuid")
+		} else {
+			q = q.Order("photos.created_at DESC, photos.photo_uuid")
+		}
+	case "manual":
+		if f.Album != "" {
+			q = q.Order("photos_albums.photo_position, photos_albums.created_at, photos.photo_uuid")
+		} else {
+			q = q.Order("taken_at DESC, photos.photo_uuid")
+		}
 	default:
 		q = q.Order("taken_at DESC, photos.photo_uuid")
 	}
 
-	if f.Count > 0 && f.Count <= 1000 {
-		q = q.Limit(f.Count).Offset(f.Offset)
-	} else {
-		q = q.Limit(100).Offset(0)
+	if f.Count <= 0 || f.Count > 1000 {
+		f.Count, f.Offset = 100, 0
 	}
 
-	if result := q.Scan(&results); result.Error != nil {
+	// Results of geo searches must be checked exactly before applying the offset and limit
+	if hasArea {
+		return scanArea(q, area, f.Offset, f.Count)
+	}
+
+	if result := q.Limit(f.Count).Offset(f.Offset).Scan(&results); result.Error != nil {
 		return results, result.Error
 	}
 
 	return results, nil
 }
 
+// whereNear matches a float column within a tolerance, as values like 1/3 EV are stored with more digits than
+// users type and aren't exactly representable anyway. Tolerances are below half the step between common values.
+func whereNear(q *gorm.DB, column string, value, tolerance float64) *gorm.DB {
+	return q.Where(column+" BETWEEN ? AND ?", value-tolerance, value+tolerance)
+}
+
+// areaBatchSize is the number of prefiltered rows fetched at once by scanArea.
+const areaBatchSize = 1000
+
+// scanArea returns count photos inside an area, starting at offset. The query must already
+// be prefiltered with whereArea, rows are then fetched in batches until the page is full.
+func scanArea(q *gorm.DB, area s2.Area, offset, count int) (results []PhotoResult, err error) {
+	for pos := 0; ; pos += areaBatchSize {
+		var batch []PhotoResult
+
+		if err := q.Limit(areaBatchSize).Offset(pos).Scan(&batch).Error; err != nil {
+			return results, err
+		}
+
+		for _, p := range photosInArea(batch, area) {
+			if offset > 0 {
+				offset--
+				continue
+			}
+
+			results = append(results, p)
+
+			if len(results) >= count {
+				return results, nil
+			}
+		}
+
+		if len(batch) < areaBatchSize {
+			return results, nil
+		}
+	}
+}
+
+// photosInArea returns the photos inside an area.
+func photosInArea(photos []PhotoResult, area s2.Area) (results []PhotoResult) {
+	for _, p := range photos {
+		if area.Contains(p.PhotoLat, p.PhotoLng) {
+			results = append(results, p)
+		}
+	}
+
+	return results
+}
+
 // FindPhotoByID returns a Photo based on the ID.
 func (s *Repo) FindPhotoByID(photoID uint64) (photo entity.Photo, err error) {
 	if err := s.db.Where("id = ?", photoID).First(&photo).Error; err != nil {
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 )
 
@@ -100,22 +101,70 @@ func TestSearch_Photos_Query(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		t.Logf("results: %+v", photos)
+		assert.Empty(t, photos)
+	})
+	t.Run("full-text query", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "bridge OR cake -flower title:neckar"
+		f.Count = 3
+		f.Offset = 0
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Photo 654 is a cake, but also a flower.
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "658", photos[0].PhotoUUID)
+		}
+	})
+	t.Run("full-text alternatives", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "bridge OR cake"
+		f.Count = 3
+		f.Offset = 0
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var uuids []string
+
+		for _, p := range photos {
+			uuids = append(uuids, p.PhotoUUID)
+		}
+
+		assert.ElementsMatch(t, []string{"654", "658"}, uuids)
+	})
+	t.Run("invalid full-text query", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "\"golden gate"
+		f.Count = 3
+		f.Offset = 0
+
+		_, err := search.Photos(f)
+
+		assert.Error(t, err)
 	})
 	t.Run("label query", func(t *testing.T) {
 		var f form.PhotoSearch
-		f.Query = "label:dog"
+		f.Query = "label:cake"
 		f.Count = 3
 		f.Offset = 0
 
 		photos, err := search.Photos(f)
 
 		if err != nil {
-			// TODO: Add database fixtures to avoid failing queries
-			t.Logf("query failed: %s", err.Error())
+			t.Fatal(err)
 		}
 
-		t.Logf("results: %+v", photos)
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "654", photos[0].PhotoUUID)
+		}
 	})
 	t.Run("invalid label query", func(t *testing.T) {
 		var f form.PhotoSearch
@@ -356,6 +405,98 @@ func TestSearch_Photos_Query(t *testing.T) {
 
 		t.Logf("results: %+v", photos)
 	})
+	t.Run("bbox with offset", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Bbox = "9,48,10,49"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Reunion is outside the bounding box.
+		assert.Len(t, photos, 3)
+
+		f.Count = 1
+		f.Offset = 2
+
+		page, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, page, 1) {
+			assert.Equal(t, photos[2].PhotoUUID, page[0].PhotoUUID)
+		}
+	})
+	t.Run("dated after migration", func(t *testing.T) {
+		entity.MigrateTakenSrc(conf.Db())
+
+		var f form.PhotoSearch
+		f.Query = "dated:file"
+		f.Count = 10
+		f.Offset = 0
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Fixtures have neither camera details nor a date source.
+		assert.Len(t, photos, 4)
+	})
+	t.Run("conversion errors", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Error = true
+		f.Count = 10
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Photos with several failed files are returned once with their primary file.
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "658", photos[0].PhotoUUID)
+			assert.Equal(t, "bridge.jpg", photos[0].FileName)
+		}
+	})
+	t.Run("non-integer exposure values", func(t *testing.T) {
+		for _, query := range []string{"bias:-0.33", "f:5.6", "distance:1.27", "direction:123.5"} {
+			var f form.PhotoSearch
+			f.Query = query
+			f.Count = 3
+			f.Offset = 0
+
+			photos, err := search.Photos(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if assert.Len(t, photos, 1, query) {
+				assert.Equal(t, "659", photos[0].PhotoUUID, query)
+			}
+		}
+
+		var f form.PhotoSearch
+		f.Query = "bias:-0.67"
+		f.Count = 3
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
 	t.Run("form.Before and form.After", func(t *testing.T) {
 		var f form.PhotoSearch
 		f.Query = "Before:2005-01-01 After:2003-01-01"
@@ -370,5 +511,43 @@ func TestSearch_Photos_Query(t *testing.T) {
 
 		t.Logf("results: %+v", photos)
 	})
+	t.Run("form.program and form.flash", func(t *testing.T) {
+		var f form.PhotoSearch
+		f.Query = "program:aperture flash:yes bias:-1..1 software:lightroom speed:12"
+		f.Count = 3
+		f.Offset = 0
+
+		photos, err := search.Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "659", photos[0].PhotoUUID)
+			assert.Equal(t, 12.34, photos[0].GPSSpeed)
+		}
+	})
+	t.Run("form.flash no", func(t *testing.T) {
+		for _, query := range []string{"flash:no", "-flash:yes"} {
+			var f form.PhotoSearch
+			f.Query = query
+			f.Count = 10
+			f.Offset = 0
+
+			photos, err := search.Photos(f)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.NotEmpty(t, photos, query)
+
+			for _, p := range photos {
+				assert.False(t, p.PhotoFlash, query)
+				assert.NotEqual(t, "659", p.PhotoUUID, query)
+			}
+		}
+	})
 
 }
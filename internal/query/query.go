@@ -15,9 +15,6 @@ import (
 
 var log = event.Log
 
-// About 1km ('good enough' for now)
-const SearchRadius = 0.009
-
 // Repo searches given an originals path and a db instance.
 type Repo struct {
 	originalsPath string
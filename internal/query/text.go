@@ -0,0 +1,104 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// MaxPhraseWords is the maximum number of words of a phrase, longer phrases are truncated.
+const MaxPhraseWords = 8
+
+// termCondition returns an SQL condition and values matching photos that contain a search term.
+// It only uses subqueries and LIKE prefix matches, so it works the same on SQLite, MySQL and TiDB.
+func termCondition(t search.Term) (string, []interface{}) {
+	var values []interface{}
+	var sql string
+
+	if !t.Phrase {
+		sql = "SELECT t0.photo_id FROM photos_terms t0 WHERE t0.term LIKE ?"
+		values = append(values, t.Words[0]+"%")
+	} else {
+		words := t.Words
+
+		if len(words) > MaxPhraseWords {
+			words = words[:MaxPhraseWords]
+		}
+
+		var joins, where []string
+
+		for i, w := range words {
+			if i > 0 {
+				joins = append(joins, fmt.Sprintf("JOIN photos_terms t%d ON t%d.photo_id = t0.photo_id AND t%d.field = t0.field AND t%d.position = t0.position + %d", i, i, i, i, i))
+			}
+
+			where = append(where, fmt.Sprintf("t%d.term = ?", i))
+			values = append(values, w)
+		}
+
+		sql = "SELECT t0.photo_id FROM photos_terms t0 " + strings.Join(joins, " ") + " WHERE " + strings.Join(where, " AND ")
+	}
+
+	if t.Field != "" {
+		sql += " AND t0.field = ?"
+		values = append(values, t.Field)
+	}
+
+	if t.Negate {
+		return "photos.id NOT IN (" + sql + ")", values
+	}
+
+	return "photos.id IN (" + sql + ")", values
+}
+
+// searchText adds full-text search conditions to a photo query.
+func searchText(q *gorm.DB, query search.Query) *gorm.DB {
+	for _, group := range query {
+		var conditions []string
+		var values []interface{}
+
+		for _, t := range group {
+			sql, v := termCondition(t)
+			conditions = append(conditions, sql)
+			values = append(values, v...)
+		}
+
+		q = q.Where("("+strings.Join(conditions, " OR ")+")", values...)
+	}
+
+	return q
+}
+
+// searchScore returns an SQL expression and values computing the relevance of a photo,
+// which is the sum of field weights of all matching terms. Exact matches count twice.
+func searchScore(query search.Query) (string, []interface{}) {
+	var exact, conditions []string
+	var exactValues, values []interface{}
+
+	for _, t := range query.Positive() {
+		for _, w := range t.Words {
+			exact = append(exact, "?")
+			exactValues = append(exactValues, w)
+
+			if t.Field != "" {
+				conditions = append(conditions, "(photos_terms.field = ? AND photos_terms.term LIKE ?)")
+				values = append(values, t.Field, w+"%")
+			} else {
+				conditions = append(conditions, "photos_terms.term LIKE ?")
+				values = append(values, w+"%")
+			}
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "0", nil
+	}
+
+	sql := "(SELECT COALESCE(SUM(CASE WHEN photos_terms.term IN (" + strings.Join(exact, ", ") + ") " +
+		"THEN photos_terms.weight * 2 ELSE photos_terms.weight END), 0) FROM photos_terms " +
+		"WHERE photos_terms.photo_id = photos.id AND (" + strings.Join(conditions, " OR ") + "))"
+
+	return sql, append(exactValues, values...)
+}
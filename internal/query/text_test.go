@@ -0,0 +1,74 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermCondition(t *testing.T) {
+	t.Run("word", func(t *testing.T) {
+		sql, values := termCondition(search.Term{Words: []string{"bridge"}})
+
+		assert.Equal(t, "photos.id IN (SELECT t0.photo_id FROM photos_terms t0 WHERE t0.term LIKE ?)", sql)
+		assert.Equal(t, []interface{}{"bridge%"}, values)
+	})
+	t.Run("negated field", func(t *testing.T) {
+		sql, values := termCondition(search.Term{Field: "place", Words: []string{"berlin"}, Negate: true})
+
+		assert.Equal(t, "photos.id NOT IN (SELECT t0.photo_id FROM photos_terms t0 WHERE t0.term LIKE ? AND t0.field = ?)", sql)
+		assert.Equal(t, []interface{}{"berlin%", "place"}, values)
+	})
+	t.Run("phrase", func(t *testing.T) {
+		sql, values := termCondition(search.Term{Words: []string{"golden", "gate"}, Phrase: true})
+
+		assert.Equal(t, "photos.id IN (SELECT t0.photo_id FROM photos_terms t0 "+
+			"JOIN photos_terms t1 ON t1.photo_id = t0.photo_id AND t1.field = t0.field AND t1.position = t0.position + 1 "+
+			"WHERE t0.term = ? AND t1.term = ?)", sql)
+		assert.Equal(t, []interface{}{"golden", "gate"}, values)
+	})
+}
+
+func TestSearchScore(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		sql, values := searchScore(search.Query{})
+
+		assert.Equal(t, "0", sql)
+		assert.Empty(t, values)
+	})
+	t.Run("terms", func(t *testing.T) {
+		q, err := search.Parse("bridge -fog title:neckar")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sql, values := searchScore(q)
+
+		assert.Contains(t, sql, "photos_terms.term LIKE ? OR (photos_terms.field = ? AND photos_terms.term LIKE ?)")
+		assert.Equal(t, []interface{}{"bridge", "neckar", "bridge%", "title", "neckar%"}, values)
+	})
+}
+
+func TestPurgeTerms(t *testing.T) {
+	db := config.TestConfig().Db()
+
+	count, err := entity.PurgeTerms(db)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int64(1), count)
+
+	var terms int
+
+	if err := db.Model(&entity.PhotoTerm{}).Where("photo_id = 99").Count(&terms).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, terms)
+}
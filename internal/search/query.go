@@ -0,0 +1,210 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Term represents a single word or phrase of a query. Single words also match terms starting with the word.
+type Term struct {
+	Field  string
+	Words  []string
+	Phrase bool
+	Negate bool
+}
+
+// Group contains alternative terms, one of them must match.
+type Group []Term
+
+// Query contains groups that must all match.
+type Query []Group
+
+// Empty returns true if the query has no terms.
+func (q Query) Empty() bool {
+	return len(q) == 0
+}
+
+// Positive returns all terms that are not negated, they are used to rank results.
+func (q Query) Positive() (result []Term) {
+	for _, g := range q {
+		for _, t := range g {
+			if !t.Negate {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result
+}
+
+// String returns the query in normalized form.
+func (q Query) String() string {
+	var groups []string
+
+	for _, g := range q {
+		var terms []string
+
+		for _, t := range g {
+			terms = append(terms, t.String())
+		}
+
+		groups = append(groups, strings.Join(terms, " OR "))
+	}
+
+	return strings.Join(groups, " ")
+}
+
+// String returns the term in query syntax.
+func (t Term) String() string {
+	var s string
+
+	if t.Negate {
+		s = "-"
+	}
+
+	if t.Field != "" {
+		s += t.Field + ":"
+	}
+
+	if t.Phrase {
+		return s + "\"" + strings.Join(t.Words, " ") + "\""
+	}
+
+	return s + strings.Join(t.Words, " ")
+}
+
+// token represents a word, quoted phrase or operator of the query string.
+type token struct {
+	text   string
+	quoted bool
+	prefix int // Length of the unquoted prefix, which may contain a negation and field name
+}
+
+// tokenize splits a query string at whitespace outside of quotes.
+func tokenize(s string) (tokens []token, err error) {
+	var current []rune
+	var quoted, inQuotes bool
+	var prefix int
+
+	flush := func() {
+		if !quoted {
+			prefix = len(string(current))
+		}
+
+		if len(current) > 0 || quoted {
+			tokens = append(tokens, token{text: string(current), quoted: quoted, prefix: prefix})
+		}
+
+		current = current[:0]
+		quoted = false
+	}
+
+	for _, char := range s {
+		switch {
+		case char == '"':
+			if !quoted {
+				prefix = len(string(current))
+			}
+
+			inQuotes = !inQuotes
+			quoted = true
+		case unicode.IsSpace(char) && !inQuotes:
+			flush()
+		default:
+			current = append(current, char)
+		}
+	}
+
+	if inQuotes {
+		return tokens, fmt.Errorf("missing closing quote")
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+// Parse returns the parsed query, terms without searchable characters are ignored.
+func Parse(s string) (result Query, err error) {
+	tokens, err := tokenize(s)
+
+	if err != nil {
+		return result, err
+	}
+
+	var group Group
+	var negateNext, orNext bool
+
+	for _, tok := range tokens {
+		if !tok.quoted {
+			switch tok.text {
+			case "OR", "|":
+				if len(group) == 0 {
+					return result, fmt.Errorf("OR must follow a search term")
+				}
+
+				orNext = true
+				continue
+			case "AND", "&":
+				continue
+			case "NOT":
+				negateNext = true
+				continue
+			}
+		}
+
+		term := Term{Negate: negateNext, Phrase: tok.quoted}
+		text, prefix := tok.text, tok.prefix
+		negateNext = false
+
+		if prefix > 0 && strings.HasPrefix(text, "-") {
+			term.Negate = !term.Negate
+			text, prefix = text[1:], prefix-1
+		}
+
+		if i := strings.Index(text[:prefix], ":"); i > 0 {
+			field := Field(text[:i])
+
+			if field == "" {
+				return result, fmt.Errorf("unknown search field: %s", text[:i])
+			}
+
+			term.Field = field
+			text = text[i+1:]
+		}
+
+		term.Words = Words(text)
+
+		if len(term.Words) == 0 {
+			orNext = false
+			continue
+		}
+
+		if len(term.Words) > 1 {
+			term.Phrase = true
+		}
+
+		if orNext {
+			group = append(group, term)
+			orNext = false
+			continue
+		}
+
+		if len(group) > 0 {
+			result = append(result, group)
+		}
+
+		group = Group{term}
+	}
+
+	if orNext {
+		return result, fmt.Errorf("OR must be followed by a search term")
+	}
+
+	if len(group) > 0 {
+		result = append(result, group)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("terms", func(t *testing.T) {
+		result, err := Parse("Golden  gate")
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, []string{"golden"}, result[0][0].Words)
+		assert.False(t, result[0][0].Phrase)
+		assert.Equal(t, "golden gate", result.String())
+	})
+	t.Run("phrase", func(t *testing.T) {
+		result, err := Parse("\"Golden Gate\" bridge")
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+		assert.True(t, result[0][0].Phrase)
+		assert.Equal(t, []string{"golden", "gate"}, result[0][0].Words)
+	})
+	t.Run("negation", func(t *testing.T) {
+		result, err := Parse("beach -dog NOT cat")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "beach -dog -cat", result.String())
+		assert.Len(t, result.Positive(), 1)
+	})
+	t.Run("or", func(t *testing.T) {
+		result, err := Parse("cat OR dog AND beach")
+
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+		assert.Len(t, result[0], 2)
+		assert.Equal(t, "cat OR dog beach", result.String())
+	})
+	t.Run("fields", func(t *testing.T) {
+		result, err := Parse("place:berlin -keyword:\"night sky\" file:IMG_1234")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "place:berlin -keywords:\"night sky\" file:\"img 1234\"", result.String())
+	})
+	t.Run("empty", func(t *testing.T) {
+		result, err := Parse(" ... ")
+
+		assert.Nil(t, err)
+		assert.True(t, result.Empty())
+	})
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := Parse("foo:bar")
+
+		assert.EqualError(t, err, "unknown search field: foo")
+	})
+	t.Run("missing quote", func(t *testing.T) {
+		_, err := Parse("\"golden gate")
+
+		assert.EqualError(t, err, "missing closing quote")
+	})
+	t.Run("dangling or", func(t *testing.T) {
+		_, err := Parse("cat OR")
+
+		assert.EqualError(t, err, "OR must be followed by a search term")
+
+		_, err = Parse("OR cat")
+
+		assert.EqualError(t, err, "OR must follow a search term")
+	})
+}
@@ -0,0 +1,88 @@
+/*
+This package parses full-text search queries and splits text into search terms.
+
+Queries may contain multiple terms, quoted phrases, negations with "-" or NOT,
+alternatives with OR and field-qualified terms like place:berlin or -keywords:"golden gate".
+Adjacent terms must all match, terms joined by OR form a group where one must match.
+
+Additional information can be found in our Developer Guide:
+
+https://github.com/photoprism/photoprism/wiki
+*/
+package search
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Indexed fields
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldNotes       = "notes"
+	FieldKeywords    = "keywords"
+	FieldLabels      = "labels"
+	FieldPlace       = "place"
+	FieldCamera      = "camera"
+	FieldFile        = "file"
+)
+
+// Weights contains the relevance of a matching term per field.
+var Weights = map[string]int{
+	FieldTitle:       8,
+	FieldLabels:      6,
+	FieldKeywords:    4,
+	FieldPlace:       4,
+	FieldDescription: 3,
+	FieldCamera:      2,
+	FieldFile:        2,
+	FieldNotes:       1,
+}
+
+// aliases maps alternative field names to indexed fields.
+var aliases = map[string]string{
+	"keyword":  FieldKeywords,
+	"filename": FieldFile,
+}
+
+// MaxTermLength is the maximum length of an indexed term in bytes.
+const MaxTermLength = 64
+
+var wordsRegexp = regexp.MustCompile("[\\p{L}\\p{N}]+")
+
+// Field returns the indexed field name for a field qualifier or an empty string if unknown.
+func Field(name string) string {
+	name = strings.ToLower(name)
+
+	if _, ok := Weights[name]; ok {
+		return name
+	}
+
+	return aliases[name]
+}
+
+// Words splits text into lowercase search terms in the order they appear.
+func Words(s string) (results []string) {
+	for _, w := range wordsRegexp.FindAllString(strings.ToLower(s), -1) {
+		results = append(results, clip(w))
+	}
+
+	return results
+}
+
+// clip shortens a term to MaxTermLength bytes without splitting a multi-byte character.
+func clip(w string) string {
+	if len(w) <= MaxTermLength {
+		return w
+	}
+
+	end := MaxTermLength
+
+	for end > 0 && !utf8.RuneStart(w[end]) {
+		end--
+	}
+
+	return w[:end]
+}
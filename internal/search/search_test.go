@@ -0,0 +1,34 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	t.Run("mixed", func(t *testing.T) {
+		result := Words("IMG_4120.jpg in Tübingen, Baden-Württemberg")
+
+		assert.Equal(t, []string{"img", "4120", "jpg", "in", "tübingen", "baden", "württemberg"}, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, Words(" - ... "))
+	})
+	t.Run("long multi-byte", func(t *testing.T) {
+		result := Words("a" + strings.Repeat("ü", 40))
+
+		if assert.Len(t, result, 1) {
+			assert.True(t, utf8.ValidString(result[0]))
+			assert.Equal(t, "a"+strings.Repeat("ü", 31), result[0])
+		}
+	})
+}
+
+func TestField(t *testing.T) {
+	assert.Equal(t, FieldKeywords, Field("Keyword"))
+	assert.Equal(t, FieldPlace, Field("place"))
+	assert.Equal(t, "", Field("iso"))
+}
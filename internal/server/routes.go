@@ -18,6 +18,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// JSON-REST API Version 1
 	v1 := router.Group("/api/v1")
 	{
+		api.GetStatus(v1, conf)
+		api.GetReadiness(v1, conf)
+
 		api.CreateSession(v1, conf)
 		api.DeleteSession(v1, conf)
 
@@ -28,12 +31,16 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DownloadZip(v1, conf)
 
 		api.GetGeo(v1, conf)
+		api.GetGeoClusters(v1, conf)
 		api.GetPhoto(v1, conf)
 		api.UpdatePhoto(v1, conf)
 		api.GetPhotos(v1, conf)
 		api.GetPhotoDownload(v1, conf)
 		api.LikePhoto(v1, conf)
 		api.DislikePhoto(v1, conf)
+		api.PhotoFilePrimary(v1, conf)
+		api.PhotoFileUnstack(v1, conf)
+		api.GetFileMetadata(v1, conf)
 		api.AddPhotoLabel(v1, conf)
 		api.RemovePhotoLabel(v1, conf)
 		api.GetMomentsTime(v1, conf)
@@ -43,17 +50,30 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.LikeLabel(v1, conf)
 		api.DislikeLabel(v1, conf)
 		api.LabelThumbnail(v1, conf)
+		api.SetLabelCover(v1, conf)
+		api.RemoveLabelCover(v1, conf)
+		api.MergeLabel(v1, conf)
+		api.AddLabelCategory(v1, conf)
+		api.RemoveLabelCategory(v1, conf)
+
+		api.GetPeople(v1, conf)
+		api.GetPersonFaces(v1, conf)
+		api.UpdatePerson(v1, conf)
+		api.MergePerson(v1, conf)
+		api.SplitPerson(v1, conf)
 
 		api.Upload(v1, conf)
 		api.StartImport(v1, conf)
 		api.CancelImport(v1, conf)
 		api.StartIndexing(v1, conf)
 		api.CancelIndexing(v1, conf)
+		api.StartClassify(v1, conf)
 
 		api.BatchPhotosArchive(v1, conf)
 		api.BatchPhotosRestore(v1, conf)
 		api.BatchPhotosPrivate(v1, conf)
 		api.BatchPhotosStory(v1, conf)
+		api.BatchPhotosTime(v1, conf)
 		api.BatchAlbumsDelete(v1, conf)
 		api.BatchLabelsDelete(v1, conf)
 
@@ -66,8 +86,19 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.LikeAlbum(v1, conf)
 		api.DislikeAlbum(v1, conf)
 		api.AlbumThumbnail(v1, conf)
+		api.SetAlbumCover(v1, conf)
+		api.RemoveAlbumCover(v1, conf)
 		api.AddPhotosToAlbum(v1, conf)
 		api.RemovePhotosFromAlbum(v1, conf)
+		api.UpdateAlbumPosition(v1, conf)
+
+		api.GetFolders(v1, conf)
+		api.FolderThumbnail(v1, conf)
+
+		api.GetRules(v1, conf)
+		api.GetRule(v1, conf)
+		api.SaveRule(v1, conf)
+		api.DeleteRule(v1, conf)
 
 		api.GetSettings(v1, conf)
 		api.SaveSettings(v1, conf)
@@ -79,6 +110,6 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 	// Default HTML page (client-side routing implemented via Vue.js)
 	router.NoRoute(func(c *gin.Context) {
-		c.HTML(http.StatusOK, "index.tmpl", gin.H{"clientConfig": conf.PublicClientConfig()})
+		c.HTML(http.StatusOK, "index.tmpl", gin.H{"clientConfig": conf.PublicClientConfig(), "preview": api.SharePreview(c.Request, conf)})
 	})
 }
@@ -0,0 +1,115 @@
+package thumb
+
+import (
+	"errors"
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+)
+
+// Collage represents the layout of a share preview image.
+type Collage struct {
+	Width  int
+	Height int
+	Cols   int
+	Rows   int
+	Gap    int
+}
+
+// Collages contains layouts suitable for OpenGraph and Twitter cards.
+var Collages = map[string]Collage{
+	"opengraph": {1200, 630, 4, 2, 4},
+	"twitter":   {1200, 600, 4, 2, 4},
+	"square":    {600, 600, 3, 3, 4},
+}
+
+// DefaultCollage is the name of the default share preview layout.
+const DefaultCollage = "opengraph"
+
+// Tiles returns the maximum number of images in the collage.
+func (c Collage) Tiles() int {
+	return c.Cols * c.Rows
+}
+
+// TileSize returns the width and height of a single tile.
+func (c Collage) TileSize() (width, height int) {
+	width = (c.Width - (c.Cols+1)*c.Gap) / c.Cols
+	height = (c.Height - (c.Rows+1)*c.Gap) / c.Rows
+
+	return width, height
+}
+
+// CreatePlaceholder saves a blank image with the size of the collage as JPEG,
+// so that share previews of empty selections match the advertised image type and size.
+func CreatePlaceholder(fileName string, c Collage) error {
+	result := imaging.New(c.Width, c.Height, color.NRGBA{238, 238, 238, 255})
+
+	if err := imaging.Save(result, fileName, imaging.JPEGQuality(JpegQuality)); err != nil {
+		log.Errorf("thumbs: failed to save %s", fileName)
+		return err
+	}
+
+	return nil
+}
+
+// CreateCollage renders the images as tiles and saves the result as JPEG.
+// Images that can't be opened are skipped, the number of rendered tiles is returned.
+func CreateCollage(images []string, fileName string, c Collage) (count int, err error) {
+	result, count, err := RenderCollage(images, c)
+
+	if err != nil {
+		return count, err
+	}
+
+	if err := imaging.Save(result, fileName, imaging.JPEGQuality(JpegQuality)); err != nil {
+		log.Errorf("thumbs: failed to save %s", fileName)
+		return count, err
+	}
+
+	return count, nil
+}
+
+// RenderCollage renders the images as tiles without saving the result.
+// Images that can't be opened are skipped, the number of rendered tiles is returned.
+func RenderCollage(images []string, c Collage) (result *image.NRGBA, count int, err error) {
+	if c.Cols < 1 || c.Rows < 1 {
+		return nil, 0, errors.New("thumbs: invalid collage layout")
+	}
+
+	tileWidth, tileHeight := c.TileSize()
+
+	if tileWidth < 1 || tileHeight < 1 {
+		return nil, 0, errors.New("thumbs: collage tiles too small")
+	}
+
+	result = imaging.New(c.Width, c.Height, color.NRGBA{255, 255, 255, 255})
+
+	for _, imageName := range images {
+		if count >= c.Tiles() {
+			break
+		}
+
+		img, err := imaging.Open(imageName, imaging.AutoOrientation(true))
+
+		if err != nil {
+			log.Warnf("thumbs: skipped %s in collage (%s)", imageName, err)
+			continue
+		}
+
+		tile := imaging.Fill(img, tileWidth, tileHeight, imaging.Center, Filter.Imaging())
+
+		x := c.Gap + (count%c.Cols)*(tileWidth+c.Gap)
+		y := c.Gap + (count/c.Cols)*(tileHeight+c.Gap)
+
+		result = imaging.Paste(result, tile, image.Pt(x, y))
+
+		count++
+	}
+
+	if count == 0 {
+		return nil, 0, errors.New("thumbs: no images for collage")
+	}
+
+	return result, count, nil
+}
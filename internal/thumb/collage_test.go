@@ -0,0 +1,113 @@
+package thumb
+
+import (
+	"image/color"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollage_TileSize(t *testing.T) {
+	width, height := Collages["opengraph"].TileSize()
+
+	assert.Equal(t, 295, width)
+	assert.Equal(t, 309, height)
+	assert.Equal(t, 8, Collages["opengraph"].Tiles())
+}
+
+func TestCreateCollage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collage")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	var images []string
+
+	for i, c := range []color.NRGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}} {
+		fileName := filepath.Join(dir, string(rune('a'+i))+".jpg")
+
+		if err := imaging.Save(imaging.New(100, 80, c), fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		images = append(images, fileName)
+	}
+
+	layout := Collage{Width: 210, Height: 110, Cols: 2, Rows: 1, Gap: 10}
+
+	t.Run("success", func(t *testing.T) {
+		fileName := filepath.Join(dir, "collage.jpg")
+
+		count, err := CreateCollage(images, fileName, layout)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+
+		result, err := imaging.Open(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 210, result.Bounds().Dx())
+		assert.Equal(t, 110, result.Bounds().Dy())
+
+		r, g, b, _ := result.At(50, 55).RGBA()
+		assert.True(t, r > g && r > b, "first tile should be red")
+
+		r, g, b, _ = result.At(150, 55).RGBA()
+		assert.True(t, g > r && g > b, "second tile should be green")
+
+		r, g, b, _ = result.At(5, 5).RGBA()
+		assert.True(t, r > 0xf000 && g > 0xf000 && b > 0xf000, "gap should be white")
+	})
+	t.Run("skip missing", func(t *testing.T) {
+		result, count, err := RenderCollage(append([]string{filepath.Join(dir, "missing.jpg")}, images...), layout)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, 210, result.Bounds().Dx())
+	})
+	t.Run("no images", func(t *testing.T) {
+		_, err := CreateCollage(nil, filepath.Join(dir, "empty.jpg"), layout)
+
+		assert.Error(t, err)
+		assert.False(t, fs.FileExists(filepath.Join(dir, "empty.jpg")))
+	})
+	t.Run("invalid layout", func(t *testing.T) {
+		_, err := CreateCollage(images, filepath.Join(dir, "invalid.jpg"), Collage{Width: 100, Height: 100})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCreatePlaceholder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "collage")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "placeholder.jpg")
+
+	assert.Nil(t, CreatePlaceholder(fileName, Collages["twitter"]))
+
+	result, err := imaging.Open(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1200, result.Bounds().Dx())
+	assert.Equal(t, 600, result.Bounds().Dy())
+}
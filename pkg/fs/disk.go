@@ -0,0 +1,30 @@
+// +build !windows
+
+package fs
+
+import (
+	"syscall"
+)
+
+// accessWrite is the W_OK mode of access(2).
+const accessWrite = 0x2
+
+// Writable returns true if the current user may create files in path, nothing is written to check this.
+func Writable(path string) bool {
+	if !PathExists(path) {
+		return false
+	}
+
+	return syscall.Access(path, accessWrite) == nil
+}
+
+// FreeSpace returns the number of bytes available to unprivileged users on the file system containing path.
+func FreeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
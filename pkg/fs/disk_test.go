@@ -0,0 +1,34 @@
+// +build !windows
+
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeSpace(t *testing.T) {
+	t.Run("existing path", func(t *testing.T) {
+		free, err := FreeSpace("./testdata")
+		assert.Nil(t, err)
+		assert.True(t, free > 0)
+	})
+	t.Run("not existing path", func(t *testing.T) {
+		free, err := FreeSpace("./xxx")
+		assert.Error(t, err)
+		assert.Equal(t, uint64(0), free)
+	})
+}
+
+func TestWritable(t *testing.T) {
+	t.Run("writable path", func(t *testing.T) {
+		assert.True(t, Writable("./testdata"))
+	})
+	t.Run("not existing path", func(t *testing.T) {
+		assert.False(t, Writable("./xxx"))
+	})
+	t.Run("file", func(t *testing.T) {
+		assert.False(t, Writable("./testdata/test.jpg"))
+	})
+}
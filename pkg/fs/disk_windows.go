@@ -0,0 +1,22 @@
+package fs
+
+import (
+	"errors"
+	"os"
+)
+
+// Writable returns true if path is a directory without the read-only attribute.
+func Writable(path string) bool {
+	info, err := os.Stat(path)
+
+	if err != nil || !info.IsDir() {
+		return false
+	}
+
+	return info.Mode().Perm()&0200 != 0
+}
+
+// FreeSpace is not implemented on Windows.
+func FreeSpace(path string) (uint64, error) {
+	return 0, errors.New("fs: free space not supported on windows")
+}
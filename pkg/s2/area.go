@@ -0,0 +1,233 @@
+package s2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/golang/geo/r1"
+	"github.com/golang/geo/r3"
+	"github.com/golang/geo/s1"
+	gs2 "github.com/golang/geo/s2"
+)
+
+// EarthRadius is the mean earth radius in km.
+const EarthRadius = 6371.01
+
+// MaxCells is the maximum number of cells used to cover a search area.
+var MaxCells = 16
+
+// TokenRange represents a range of cell tokens, tokens of cells at the same level sort like their ids.
+type TokenRange struct {
+	Min string
+	Max string
+}
+
+// Area represents a region on the earth's surface, e.g. a circle, rectangle or polygon.
+type Area struct {
+	region gs2.Region
+}
+
+// Circle returns a circular area with a radius in km.
+func Circle(lat, lng, radius float64) (Area, error) {
+	if err := validLatLng(lat, lng); err != nil {
+		return Area{}, err
+	}
+
+	if radius <= 0 {
+		return Area{}, errors.New("s2: radius must be positive")
+	}
+
+	center := gs2.PointFromLatLng(gs2.LatLngFromDegrees(lat, lng))
+	angle := s1.Angle(radius/EarthRadius) * s1.Radian
+
+	return Area{region: gs2.CapFromCenterAngle(center, angle)}, nil
+}
+
+// Rect returns a rectangular area, it crosses the antimeridian if lngMin is greater than lngMax.
+func Rect(latMin, lngMin, latMax, lngMax float64) (Area, error) {
+	if err := validLatLng(latMin, lngMin); err != nil {
+		return Area{}, err
+	}
+
+	if err := validLatLng(latMax, lngMax); err != nil {
+		return Area{}, err
+	}
+
+	if latMin > latMax {
+		return Area{}, fmt.Errorf("s2: min latitude %f is greater than max latitude %f", latMin, latMax)
+	}
+
+	rect := gs2.Rect{
+		Lat: r1.Interval{Lo: (s1.Angle(latMin) * s1.Degree).Radians(), Hi: (s1.Angle(latMax) * s1.Degree).Radians()},
+		Lng: s1.IntervalFromEndpoints((s1.Angle(lngMin) * s1.Degree).Radians(), (s1.Angle(lngMax) * s1.Degree).Radians()),
+	}
+
+	return Area{region: rect}, nil
+}
+
+// Polygon returns the area inside a closed ring of [lng, lat] positions as used by GeoJSON.
+// The orientation of the ring is ignored, the polygon always covers the smaller area.
+func Polygon(ring [][]float64) (Area, error) {
+	if len(ring) > 1 && equalPosition(ring[0], ring[len(ring)-1]) {
+		ring = ring[:len(ring)-1]
+	}
+
+	if len(ring) < 3 {
+		return Area{}, errors.New("s2: polygon needs at least 3 positions")
+	}
+
+	points := make([]gs2.Point, len(ring))
+
+	for i, pos := range ring {
+		if len(pos) < 2 {
+			return Area{}, fmt.Errorf("s2: invalid position %v", pos)
+		}
+
+		if err := validLatLng(pos[1], pos[0]); err != nil {
+			return Area{}, err
+		}
+
+		points[i] = gs2.PointFromLatLng(gs2.LatLngFromDegrees(pos[1], pos[0]))
+	}
+
+	loop := gs2.LoopFromPoints(points)
+	loop.Normalize()
+
+	if err := loop.Validate(); err != nil {
+		return Area{}, fmt.Errorf("s2: invalid polygon (%s)", err)
+	}
+
+	return Area{region: loop}, nil
+}
+
+// geoJSON contains the fields of GeoJSON objects needed to find a polygon.
+type geoJSON struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSON        `json:"geometry"`
+	Features    []geoJSON       `json:"features"`
+}
+
+// GeoJSON returns the area of a GeoJSON polygon, feature or feature collection with a single polygon.
+// Holes are not supported.
+func GeoJSON(data []byte) (Area, error) {
+	var obj geoJSON
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return Area{}, fmt.Errorf("s2: invalid geojson (%s)", err)
+	}
+
+	switch obj.Type {
+	case "FeatureCollection":
+		if len(obj.Features) != 1 {
+			return Area{}, errors.New("s2: feature collection must contain exactly one feature")
+		}
+
+		obj = obj.Features[0]
+
+		fallthrough
+	case "Feature":
+		if obj.Geometry == nil {
+			return Area{}, errors.New("s2: feature has no geometry")
+		}
+
+		obj = *obj.Geometry
+	}
+
+	if obj.Type != "Polygon" {
+		return Area{}, fmt.Errorf("s2: unsupported geojson type \"%s\"", obj.Type)
+	}
+
+	var rings [][][]float64
+
+	if err := json.Unmarshal(obj.Coordinates, &rings); err != nil {
+		return Area{}, fmt.Errorf("s2: invalid polygon coordinates (%s)", err)
+	}
+
+	if len(rings) == 0 {
+		return Area{}, errors.New("s2: polygon has no coordinates")
+	}
+
+	return Polygon(rings[0])
+}
+
+// Contains returns true if the coordinates are inside the area.
+func (a Area) Contains(lat, lng float64) bool {
+	if a.region == nil {
+		return false
+	}
+
+	return a.region.ContainsPoint(gs2.PointFromLatLng(gs2.LatLngFromDegrees(lat, lng)))
+}
+
+// Bounds returns the bounding box of the area in degrees, lngMin is greater than lngMax if it crosses the antimeridian.
+func (a Area) Bounds() (latMin, lngMin, latMax, lngMax float64) {
+	if a.region == nil {
+		return 0, 0, 0, 0
+	}
+
+	r := a.region.RectBound()
+
+	return r.Lo().Lat.Degrees(), r.Lo().Lng.Degrees(), r.Hi().Lat.Degrees(), r.Hi().Lng.Degrees()
+}
+
+// Ranges returns the token ranges of cells covering the area, which contain all default level tokens inside.
+func (a Area) Ranges() (result []TokenRange) {
+	if a.region == nil {
+		return result
+	}
+
+	coverer := &gs2.RegionCoverer{MaxLevel: DefaultLevel, MaxCells: MaxCells}
+
+	for _, c := range coverer.Covering(a.region) {
+		result = append(result, TokenRange{
+			Min: c.ChildBeginAtLevel(DefaultLevel).ToToken(),
+			Max: c.ChildEndAtLevel(DefaultLevel).Prev().ToToken(),
+		})
+	}
+
+	return result
+}
+
+// Distance returns the great-circle distance between two coordinates in km.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	return gs2.LatLngFromDegrees(lat1, lng1).Distance(gs2.LatLngFromDegrees(lat2, lng2)).Radians() * EarthRadius
+}
+
+func validLatLng(lat, lng float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("s2: invalid latitude %f", lat)
+	}
+
+	if lng < -180 || lng > 180 {
+		return fmt.Errorf("s2: invalid longitude %f", lng)
+	}
+
+	return nil
+}
+
+func equalPosition(a, b []float64) bool {
+	return len(a) >= 2 && len(b) >= 2 && a[0] == b[0] && a[1] == b[1]
+}
+
+// Centroid returns the center of [lat, lng] coordinates on the sphere, which also works across the antimeridian.
+func Centroid(coords [][2]float64) (lat, lng float64) {
+	if len(coords) == 0 {
+		return 0, 0
+	}
+
+	var sum r3.Vector
+
+	for _, c := range coords {
+		sum = sum.Add(gs2.PointFromLatLng(gs2.LatLngFromDegrees(c[0], c[1])).Vector)
+	}
+
+	if sum.Norm() == 0 {
+		return coords[0][0], coords[0][1]
+	}
+
+	ll := gs2.LatLngFromPoint(gs2.Point{Vector: sum.Normalize()})
+
+	return ll.Lat.Degrees(), ll.Lng.Degrees()
+}
@@ -0,0 +1,163 @@
+package s2
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	t.Run("berlin paris", func(t *testing.T) {
+		d := Distance(52.5200, 13.4050, 48.8566, 2.3522)
+
+		assert.InDelta(t, 878, d, 2)
+	})
+	t.Run("same", func(t *testing.T) {
+		assert.Equal(t, 0.0, Distance(-33.8688, 151.2093, -33.8688, 151.2093))
+	})
+}
+
+func TestCircle(t *testing.T) {
+	t.Run("tromsø", func(t *testing.T) {
+		area, err := Circle(69.6492, 18.9553, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 9.5 km east and 10.5 km east, which is about 0.25 degrees longitude this far north
+		assert.True(t, area.Contains(69.6492, 19.1999))
+		assert.False(t, area.Contains(69.6492, 19.2250))
+		assert.False(t, area.Contains(69.6492+0.1, 18.9553+0.2))
+	})
+	t.Run("southern hemisphere", func(t *testing.T) {
+		area, err := Circle(-33.8688, 151.2093, 5)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, area.Contains(-33.8568, 151.2153))
+		assert.False(t, area.Contains(33.8568, 151.2153))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Circle(91, 0, 5)
+		assert.Error(t, err)
+
+		_, err = Circle(10, 10, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestRect(t *testing.T) {
+	t.Run("antimeridian", func(t *testing.T) {
+		area, err := Rect(-20, 170, -10, -170)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, area.Contains(-15, 179))
+		assert.True(t, area.Contains(-15, -175))
+		assert.False(t, area.Contains(-15, 0))
+
+		latMin, lngMin, latMax, lngMax := area.Bounds()
+
+		assert.Equal(t, []float64{-20, 170, -10, -170}, []float64{latMin, lngMin, latMax, lngMax})
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Rect(10, 0, -10, 5)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGeoJSON(t *testing.T) {
+	t.Run("feature", func(t *testing.T) {
+		data := `{"type": "Feature", "properties": {}, "geometry": {"type": "Polygon", "coordinates": [[[13.3, 52.4], [13.5, 52.4], [13.5, 52.6], [13.3, 52.6], [13.3, 52.4]]]}}`
+		area, err := GeoJSON([]byte(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, area.Contains(52.52, 13.405))
+		assert.False(t, area.Contains(48.8566, 2.3522))
+	})
+	t.Run("clockwise", func(t *testing.T) {
+		data := `{"type": "Polygon", "coordinates": [[[13.3, 52.4], [13.3, 52.6], [13.5, 52.6], [13.5, 52.4]]]}`
+		area, err := GeoJSON([]byte(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, area.Contains(52.52, 13.405))
+		assert.False(t, area.Contains(48.8566, 2.3522))
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := GeoJSON([]byte(`{"type": "Point", "coordinates": [13.4, 52.5]}`))
+
+		assert.EqualError(t, err, "s2: unsupported geojson type \"Point\"")
+	})
+	t.Run("too few positions", func(t *testing.T) {
+		_, err := GeoJSON([]byte(`{"type": "Polygon", "coordinates": [[[13.3, 52.4], [13.5, 52.4], [13.3, 52.4]]]}`))
+
+		assert.EqualError(t, err, "s2: polygon needs at least 3 positions")
+	})
+}
+
+func TestArea_Ranges(t *testing.T) {
+	area, err := Circle(48.56344833333333, 8.996878333333333, 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ranges := area.Ranges()
+	token := Token(48.56344833333333, 8.996878333333333)
+	found := false
+
+	assert.NotEmpty(t, ranges)
+	assert.True(t, len(ranges) <= MaxCells)
+
+	for _, r := range ranges {
+		assert.True(t, r.Min <= r.Max)
+
+		if strings.Compare(token, r.Min) >= 0 && strings.Compare(token, r.Max) <= 0 {
+			found = true
+		}
+	}
+
+	assert.True(t, found)
+}
+
+func TestCentroid(t *testing.T) {
+	t.Run("antimeridian", func(t *testing.T) {
+		lat, lng := Centroid([][2]float64{{-17, 179}, {-17, -179}})
+
+		assert.InDelta(t, -17, lat, 0.01)
+		assert.InDelta(t, 180, abs(lng), 0.0001)
+	})
+	t.Run("single", func(t *testing.T) {
+		lat, lng := Centroid([][2]float64{{52.5, 13.4}})
+
+		assert.InDelta(t, 52.5, lat, 0.000001)
+		assert.InDelta(t, 13.4, lng, 0.000001)
+	})
+	t.Run("empty", func(t *testing.T) {
+		lat, lng := Centroid(nil)
+
+		assert.Equal(t, 0.0, lat)
+		assert.Equal(t, 0.0, lng)
+	})
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+
+	return f
+}
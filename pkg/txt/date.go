@@ -1,5 +1,13 @@
 package txt
 
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Months contains all month names in English.
 var Months = [...]string{
 	"Unknown",
@@ -16,3 +24,83 @@ var Months = [...]string{
 	"November",
 	"December",
 }
+
+// DateRegexp matches dates with an optional time of day in file names, e.g. IMG_20190612_101500 or 2019-06-12 10.15.00.
+var DateRegexp = regexp.MustCompile(`(?:^|\D)((?:19|20)\d{2})[-_.]?([01]\d)[-_.]?([0-3]\d)(?:[ _T.-]?([0-2]\d)[-_.:h]?([0-5]\d)[-_.:m]?([0-5]\d)(?:\d{3})?)?(?:\D|$)`)
+
+// YearRegexp matches folder names starting with a year and an optional month, e.g. 2008, "2008 Summer" or 2008-07.
+var YearRegexp = regexp.MustCompile(`^((?:19|20)\d{2})(?:[-_.]([01]\d))?(?:[ _.-]|$)`)
+
+// Date returns the date and time found in a file name, the time of day is zero if it is not part of the name.
+// Dates in the future are ignored, as they are more likely to be ids or serial numbers.
+func Date(s string) time.Time {
+	for _, match := range DateRegexp.FindAllStringSubmatch(s, -1) {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		day, _ := strconv.Atoi(match[3])
+
+		if !validDate(year, month, day) {
+			continue
+		}
+
+		var hour, min, sec int
+
+		if match[4] != "" {
+			hour, _ = strconv.Atoi(match[4])
+			min, _ = strconv.Atoi(match[5])
+			sec, _ = strconv.Atoi(match[6])
+		}
+
+		if hour > 23 {
+			hour, min, sec = 0, 0, 0
+		}
+
+		return time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC)
+	}
+
+	return time.Time{}
+}
+
+// DatePath returns the date found in the folder names of a path, starting with the deepest folder.
+// Folders like /2008/Summer/ or /2008-07 Holiday/ return the first day of the year or month.
+func DatePath(dir string) time.Time {
+	folders := strings.FieldsFunc(filepath.ToSlash(dir), func(r rune) bool { return r == '/' })
+
+	for i := len(folders) - 1; i >= 0; i-- {
+		if t := Date(folders[i]); !t.IsZero() {
+			return t
+		}
+	}
+
+	for i := len(folders) - 1; i >= 0; i-- {
+		match := YearRegexp.FindStringSubmatch(folders[i])
+
+		if match == nil {
+			continue
+		}
+
+		year, _ := strconv.Atoi(match[1])
+		month := 1
+
+		if match[2] != "" {
+			month, _ = strconv.Atoi(match[2])
+		}
+
+		if validDate(year, month, 1) {
+			return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		}
+	}
+
+	return time.Time{}
+}
+
+// validDate returns true if the date exists and is not in the future.
+func validDate(year, month, day int) bool {
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+
+	return t.Day() == day && t.Before(time.Now())
+}
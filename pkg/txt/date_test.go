@@ -11,3 +11,51 @@ func TestMonths(t *testing.T) {
 	assert.Equal(t, "January", Months[1])
 	assert.Equal(t, "December", Months[12])
 }
+
+func TestDate(t *testing.T) {
+	t.Run("android", func(t *testing.T) {
+		assert.Equal(t, "2019-06-12 10:15:00", Date("IMG_20190612_101500.jpg").Format("2006-01-02 15:04:05"))
+	})
+	t.Run("dropbox", func(t *testing.T) {
+		assert.Equal(t, "2019-06-12 10:15:00", Date("2019-06-12 10.15.00.jpg").Format("2006-01-02 15:04:05"))
+	})
+	t.Run("screenshot", func(t *testing.T) {
+		assert.Equal(t, "2019-06-12 10:15:07", Date("Screenshot_2019-06-12-10-15-07.png").Format("2006-01-02 15:04:05"))
+	})
+	t.Run("whatsapp", func(t *testing.T) {
+		assert.Equal(t, "2019-06-12 00:00:00", Date("IMG-20190612-WA0004.jpg").Format("2006-01-02 15:04:05"))
+	})
+	t.Run("pixel", func(t *testing.T) {
+		assert.Equal(t, "2021-01-01 12:34:56", Date("PXL_20210101_123456789.jpg").Format("2006-01-02 15:04:05"))
+	})
+	t.Run("invalid day", func(t *testing.T) {
+		assert.True(t, Date("IMG_20190231.jpg").IsZero())
+	})
+	t.Run("invalid time", func(t *testing.T) {
+		assert.Equal(t, "2019-06-12 00:00:00", Date("20190612_251500.jpg").Format("2006-01-02 15:04:05"))
+	})
+	t.Run("future", func(t *testing.T) {
+		assert.True(t, Date("IMG_20990101.jpg").IsZero())
+	})
+	t.Run("part of a number", func(t *testing.T) {
+		assert.True(t, Date("4520190612.jpg").IsZero())
+	})
+	t.Run("no date", func(t *testing.T) {
+		assert.True(t, Date("IMG_1234.jpg").IsZero())
+	})
+}
+
+func TestDatePath(t *testing.T) {
+	t.Run("year folder", func(t *testing.T) {
+		assert.Equal(t, "2008-01-01", DatePath("/photos/2008/Summer").Format("2006-01-02"))
+	})
+	t.Run("month folder", func(t *testing.T) {
+		assert.Equal(t, "2008-07-01", DatePath("scans/2005/2008-07 Holiday").Format("2006-01-02"))
+	})
+	t.Run("date folder", func(t *testing.T) {
+		assert.Equal(t, "2019-06-12", DatePath("2019/2019-06-12 Wedding/raw").Format("2006-01-02"))
+	})
+	t.Run("no date", func(t *testing.T) {
+		assert.True(t, DatePath("/photos/Summer/1234").IsZero())
+	})
+}